@@ -3,15 +3,26 @@ package aggregator
 import (
 	"errors"
 	"fmt"
+	"news-aggregator/aggregator/cache"
 	"news-aggregator/aggregator/model/article"
 	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/aggregator/parser"
+	"sync"
 )
 
+// DefaultWorkersCount is the default number of resource.Resource's parsed simultaneously
+// by AggregateConcurrently.
+const DefaultWorkersCount = 4
+
 // Aggregator is a processor that collects specific information from resource.Resource
 // and turns it into a collection of article.Article.
 type Aggregator struct {
 	parserFactory Factory
 	filters       []Filter
+	deduplicator  Filter
+	cache         *cache.Cache
+	workers       int
+	tolerant      bool
 }
 
 // New creates a new Aggregator instance.
@@ -23,9 +34,37 @@ func New(factory Factory) (*Aggregator, error) {
 
 	return &Aggregator{
 		parserFactory: factory,
+		workers:       DefaultWorkersCount,
 	}, nil
 }
 
+// SetWorkers sets the maximum number of resource.Resource's parsed simultaneously by AggregateConcurrently.
+// Values lower than one are ignored.
+func (agr *Aggregator) SetWorkers(workers int) {
+	if workers > 0 {
+		agr.workers = workers
+	}
+}
+
+// SetTolerant enables or disables the tolerant mode. In this mode resources parsed by a TolerantParser
+// skip the invalid items instead of failing, the skipped items are reported by AggregateConcurrently.
+func (agr *Aggregator) SetTolerant(tolerant bool) {
+	agr.tolerant = tolerant
+}
+
+// SetDeduplicator sets the stage removing the duplicates among the articles of all resources, e.g. a
+// dedup.Deduplicator. AggregateMultiple and AggregateConcurrently apply it once the articles are merged,
+// nil disables the stage.
+func (agr *Aggregator) SetDeduplicator(deduplicator Filter) {
+	agr.deduplicator = deduplicator
+}
+
+// SetCache sets the cache of the parsed articles, so that a resource parsed before is not parsed again.
+// The cache may be shared by several aggregators, nil disables caching.
+func (agr *Aggregator) SetCache(c *cache.Cache) {
+	agr.cache = c
+}
+
 // AddFilter adds a filter to the aggregator.
 func (agr *Aggregator) AddFilter(filter Filter) {
 	agr.filters = append(agr.filters, filter)
@@ -33,39 +72,170 @@ func (agr *Aggregator) AddFilter(filter Filter) {
 
 // Aggregate fetches articles from a resource and parses them.
 func (agr *Aggregator) Aggregate(resource resource.Resource) ([]article.Article, error) {
+	articles, _, err := agr.aggregate(resource)
+	return articles, err
+}
 
-	articlesParser, err := agr.parserFactory.GetParser(resource.Format(), resource.Source())
-	if err != nil {
-		return nil, err
+// AggregateMultiple fetches articles from a multiple resources and parses them.
+// An article repeated in several snapshots of its source is returned once, see AggregateConcurrently.
+func (agr *Aggregator) AggregateMultiple(resources []resource.Resource) ([]article.Article, error) {
+
+	var articles []article.Article
+
+	for _, res := range resources {
+		art, err := agr.Aggregate(res)
+		if err != nil {
+			return nil, fmt.Errorf("failed to aggregate articles: %w", err)
+		}
+		articles = append(articles, art...)
+	}
+
+	return agr.deduplicate(latestVersions(articles)), nil
+}
+
+// AggregateConcurrently fetches articles from multiple resources using a bounded pool of workers.
+// Unlike AggregateMultiple it does not stop on the first failed resource: articles of all successfully
+// parsed resources are returned together with a Report describing every resource that failed.
+// The order of the returned articles follows the order of the given resources. An article repeated in several
+// snapshots of its source is returned once, in its latest version, whether a deduplicator is set or not.
+func (agr *Aggregator) AggregateConcurrently(resources []resource.Resource) ([]article.Article, Report) {
+
+	results := make([][]article.Article, len(resources))
+	warnings := make([][]parser.ItemWarning, len(resources))
+	errs := make([]error, len(resources))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < min(agr.workers, len(resources)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], warnings[i], errs[i] = agr.aggregate(resources[i])
+			}
+		}()
+	}
+
+	for i := range resources {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var articles []article.Article
+	var report Report
+
+	for i, res := range resources {
+		for _, warning := range warnings[i] {
+			report.Warnings = append(report.Warnings, SourceWarning{
+				Source:      res.Source(),
+				Format:      res.Format(),
+				ItemWarning: warning,
+			})
+		}
+
+		if errs[i] != nil {
+			report.Errors = append(report.Errors, SourceError{
+				Source: res.Source(),
+				Format: res.Format(),
+				Err:    errs[i],
+			})
+			continue
+		}
+		articles = append(articles, results[i]...)
 	}
 
-	articles, err := articlesParser.Parse(resource)
+	return agr.deduplicate(latestVersions(articles)), report
+}
+
+// aggregate parses the resource and filters the articles.
+// In the tolerant mode it also returns the items skipped by a TolerantParser.
+func (agr *Aggregator) aggregate(resource resource.Resource) ([]article.Article, []parser.ItemWarning, error) {
 
+	articles, warnings, err := agr.parse(resource)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse articles: %w", err)
+		return nil, nil, err
 	}
 
 	if agr.filters != nil {
-		return agr.getFilteredArticles(articles), nil
+		return agr.getFilteredArticles(articles), warnings, nil
 	}
 
-	return articles, nil
+	return articles, warnings, nil
 }
 
-// AggregateMultiple fetches articles from a multiple resources and parses them.
-func (agr *Aggregator) AggregateMultiple(resources []resource.Resource) ([]article.Article, error) {
+// parse parses the resource with the parser of its format and source, unless the result is cached.
+// The content of a stored snapshot is read only if it is not cached.
+func (agr *Aggregator) parse(resource resource.Resource) ([]article.Article, []parser.ItemWarning, error) {
+	if agr.cache != nil {
+		if articles, warnings, cached := agr.cache.Get(resource, agr.tolerant); cached {
+			return articles, warnings, nil
+		}
+	}
+
+	if err := resource.Load(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read snapshot: %v", err)
+	}
+
+	articlesParser, err := agr.parserFactory.GetParser(resource.Format(), resource.Source())
+	if err != nil {
+		return nil, nil, err
+	}
 
 	var articles []article.Article
+	var warnings []parser.ItemWarning
 
-	for _, res := range resources {
-		art, err := agr.Aggregate(res)
-		if err != nil {
-			return nil, fmt.Errorf("failed to aggregate articles: %w", err)
+	if tolerantParser, ok := articlesParser.(TolerantParser); ok && agr.tolerant {
+		articles, warnings, err = tolerantParser.ParseTolerant(resource)
+	} else {
+		articles, err = articlesParser.Parse(resource)
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse articles: %w", err)
+	}
+
+	if agr.cache != nil {
+		agr.cache.Put(resource, agr.tolerant, articles, warnings)
+	}
+
+	return articles, warnings, nil
+}
+
+// snapshotKey identifies an article among the snapshots of its source.
+type snapshotKey struct {
+	source resource.Source
+	id     article.ID
+}
+
+// latestVersions returns the articles with a single version of every article repeated in the snapshots
+// of its source, the one of the latest snapshot, in place of the first one.
+// The resources of a source are in the order of their snapshots, so that the latest version comes last.
+// The same article carried by several sources is left to the deduplicator.
+func latestVersions(articles []article.Article) []article.Article {
+	positions := make(map[snapshotKey]int, len(articles))
+	latest := make([]article.Article, 0, len(articles))
+
+	for _, art := range articles {
+		key := snapshotKey{source: art.Source(), id: art.ID()}
+		if i, exists := positions[key]; exists {
+			latest[i] = art
+			continue
 		}
-		articles = append(articles, art...)
+		positions[key] = len(latest)
+		latest = append(latest, art)
 	}
 
-	return articles, nil
+	return latest
+}
+
+// deduplicate applies the deduplicator to the merged articles, if one is set.
+func (agr *Aggregator) deduplicate(articles []article.Article) []article.Article {
+	if agr.deduplicator == nil {
+		return articles
+	}
+	return agr.deduplicator.Apply(articles)
 }
 
 // GetFilteredArticles applies all filters to the articles and returns this filtered articles.
@@ -2,8 +2,11 @@ package aggregator_test
 
 import (
 	"news-aggregator/aggregator"
+	"news-aggregator/aggregator/cache"
+	"news-aggregator/aggregator/dedup"
 	"news-aggregator/aggregator/model/article"
 	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/aggregator/parser"
 	"testing"
 	"time"
 
@@ -28,6 +31,9 @@ func (m *MockFactory) GetParser(format resource.Format, source resource.Source)
 	if format == 0 || source == "invalid" {
 		return nil, assert.AnError
 	}
+	if source == "tolerant" {
+		return &MockTolerantParser{}, nil
+	}
 	return &MockParser{}, nil
 }
 
@@ -54,6 +60,15 @@ func (m *MockParser) Parse(res resource.Resource) ([]article.Article, error) {
 	return articles, nil
 }
 
+type MockTolerantParser struct {
+	MockParser
+}
+
+func (m *MockTolerantParser) ParseTolerant(res resource.Resource) ([]article.Article, []parser.ItemWarning, error) {
+	articles, _ := m.Parse(res)
+	return articles, []parser.ItemWarning{{Index: 1, Field: "date", Reason: "unknown format"}}, nil
+}
+
 func TestAggregator(t *testing.T) {
 	factory := &MockFactory{}
 	agg, _ := aggregator.New(factory)
@@ -95,6 +110,147 @@ func TestAggregator(t *testing.T) {
 		assert.Equal(t, "source2", string(articles[0].Source()))
 	})
 
+	t.Run("Aggregate concurrently skips failed resources", func(t *testing.T) {
+		concurrentAgg, _ := aggregator.New(factory)
+		concurrentAgg.SetWorkers(2)
+
+		res1, err := resource.New("source1", resource.JSON, "content1")
+		assert.NoError(t, err)
+		res2, err := resource.New("invalid", resource.RSS, "content2")
+		assert.NoError(t, err)
+		res3, err := resource.New("source3", resource.JSON, "content3")
+		assert.NoError(t, err)
+
+		articles, report := concurrentAgg.AggregateConcurrently([]resource.Resource{*res1, *res2, *res3})
+		assert.Equal(t, 2, len(articles))
+		assert.Equal(t, "source1", string(articles[0].Source()))
+		assert.Equal(t, "source3", string(articles[1].Source()))
+
+		assert.True(t, report.HasErrors())
+		assert.Equal(t, 1, len(report.Errors))
+		assert.Equal(t, resource.Source("invalid"), report.Errors[0].Source)
+		assert.Equal(t, resource.Format(resource.RSS), report.Errors[0].Format)
+		assert.ErrorIs(t, report.Errors[0], assert.AnError)
+	})
+
+	t.Run("Aggregate concurrently without resources", func(t *testing.T) {
+		concurrentAgg, _ := aggregator.New(factory)
+
+		articles, report := concurrentAgg.AggregateConcurrently(nil)
+		assert.Empty(t, articles)
+		assert.False(t, report.HasErrors())
+	})
+
+	t.Run("Aggregate concurrently in tolerant mode reports warnings", func(t *testing.T) {
+		res1, err := resource.New("source1", resource.RSS, "content1")
+		assert.NoError(t, err)
+		res2, err := resource.New("tolerant", resource.RSS, "content2")
+		assert.NoError(t, err)
+
+		strictAgg, _ := aggregator.New(factory)
+		articles, report := strictAgg.AggregateConcurrently([]resource.Resource{*res1, *res2})
+		assert.Equal(t, 2, len(articles))
+		assert.False(t, report.HasWarnings(), "Tolerant parsing should be disabled by default")
+
+		tolerantAgg, _ := aggregator.New(factory)
+		tolerantAgg.SetTolerant(true)
+		articles, report = tolerantAgg.AggregateConcurrently([]resource.Resource{*res1, *res2})
+		assert.Equal(t, 2, len(articles))
+		assert.False(t, report.HasErrors())
+		assert.True(t, report.HasWarnings())
+		assert.Equal(t, 1, len(report.Warnings))
+		assert.Equal(t, resource.Source("tolerant"), report.Warnings[0].Source)
+		assert.Equal(t, 1, report.Warnings[0].Index)
+		assert.Equal(t, "date", report.Warnings[0].Field)
+	})
+
+	t.Run("Aggregate concurrently removes duplicates across resources", func(t *testing.T) {
+		res1, err := resource.New("source1", resource.JSON, "content1")
+		assert.NoError(t, err)
+		res2, err := resource.New("source2", resource.JSON, "content2")
+		assert.NoError(t, err)
+
+		deduplicator := dedup.New()
+		deduplicator.SetCollapse(true)
+
+		dedupAgg, _ := aggregator.New(factory)
+		dedupAgg.SetDeduplicator(deduplicator)
+
+		articles, report := dedupAgg.AggregateConcurrently([]resource.Resource{*res1, *res2})
+		assert.False(t, report.HasErrors())
+		assert.Equal(t, 1, len(articles))
+		assert.ElementsMatch(t, []resource.Source{"source1", "source2"}, articles[0].Sources())
+
+		articles, err = dedupAgg.AggregateMultiple([]resource.Resource{*res1, *res2})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(articles))
+	})
+
+	t.Run("Aggregate concurrently keeps the latest version of the articles of the snapshots of a source",
+		func(t *testing.T) {
+			rss := func(description string, items ...string) resource.Content {
+				content := `<rss version="2.0"><channel>`
+				for _, title := range items {
+					content += `<item><title>` + title + `</title><link>http://example.com/` + title + `</link>` +
+						`<pubDate>Mon, 01 Jan 2024 00:00:00 +0000</pubDate>` +
+						`<description>` + description + `</description></item>`
+				}
+				return resource.Content(content + `</channel></rss>`)
+			}
+
+			first, err := resource.New("bbc", resource.RSS, rss("first", "story", "old"))
+			assert.NoError(t, err)
+			second, err := resource.New("bbc", resource.RSS, rss("second", "new", "story"))
+			assert.NoError(t, err)
+			other, err := resource.New("cnn", resource.RSS, rss("other", "story"))
+			assert.NoError(t, err)
+
+			snapshotAgg, _ := aggregator.New(aggregator.NewParserFactory())
+			articles, report := snapshotAgg.AggregateConcurrently([]resource.Resource{*first, *second, *other})
+			assert.False(t, report.HasErrors())
+
+			var versions []string
+			for _, a := range articles {
+				versions = append(versions, string(a.Source())+" "+a.TitleStr()+" "+a.DescriptionStr())
+			}
+			assert.Equal(t, []string{"bbc story second", "bbc old first", "bbc new second", "cnn story other"},
+				versions, "The same article of another source should be left to the deduplicator")
+
+			articles, err = snapshotAgg.AggregateMultiple([]resource.Resource{*first, *second})
+			assert.NoError(t, err)
+			assert.Equal(t, 3, len(articles))
+		})
+
+	t.Run("Aggregate with cache parses every resource once", func(t *testing.T) {
+		res1, err := resource.New("source1", resource.JSON, "content1")
+		assert.NoError(t, err)
+		res2, err := resource.New("source2", resource.JSON, "content2")
+		assert.NoError(t, err)
+		invalid, err := resource.New("invalid", resource.JSON, "content3")
+		assert.NoError(t, err)
+
+		c := cache.New()
+
+		cachedAgg, _ := aggregator.New(factory)
+		cachedAgg.SetCache(c)
+		first, report := cachedAgg.AggregateConcurrently([]resource.Resource{*res1, *res2, *invalid})
+		assert.Equal(t, 2, len(first))
+		assert.True(t, report.HasErrors())
+
+		// The cache is shared with the aggregator of another request, filtering the cached articles.
+		filteredAgg, _ := aggregator.New(factory)
+		filteredAgg.SetCache(c)
+		filteredAgg.AddFilter(&MockFilter{})
+		second, report := filteredAgg.AggregateConcurrently([]resource.Resource{*res1, *res2, *invalid})
+		assert.Equal(t, []article.Article{first[1]}, second, "Cached articles should be filtered")
+		assert.True(t, report.HasErrors(), "Failures should not be cached")
+
+		stats := c.Stats()
+		assert.Equal(t, 2, stats.Entries)
+		assert.Equal(t, uint64(2), stats.Hits)
+		assert.Equal(t, uint64(4), stats.Misses, "The failed resource should miss every time")
+	})
+
 	t.Run("Aggregate incorrect resource", func(t *testing.T) {
 		res, err := resource.New("invalid", resource.JSON, "invalid")
 		assert.NoError(t, err)
@@ -0,0 +1,163 @@
+package analysis
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/reiver/go-porterstemmer"
+)
+
+// sharedAnalyzer is the Analyzer with the default stopwords, created on the first use.
+var sharedAnalyzer = sync.OnceValue(func() *Analyzer {
+	return New(DefaultStopwords)
+})
+
+// Token is a word of an analyzed text.
+type Token struct {
+	// Text is the word as it appears in the text.
+	Text string
+	// Word is the lower-cased word without the possessive suffix.
+	Word string
+	// Term is the stem of the word the texts are compared by.
+	Term string
+	// Start and End are the byte offsets of the word in the text.
+	Start, End int
+	// Stopword reports whether the word is ignored when the texts are compared.
+	Stopword bool
+}
+
+// Analyzer splits texts into tokens and compares them by the stems of their words.
+// An Analyzer is immutable and safe for concurrent use.
+type Analyzer struct {
+	stopwords map[string]struct{}
+}
+
+// New creates a new Analyzer ignoring the given stopwords.
+func New(stopwords []string) *Analyzer {
+	set := make(map[string]struct{}, len(stopwords))
+	for _, stopword := range stopwords {
+		set[strings.ToLower(stopword)] = struct{}{}
+	}
+	return &Analyzer{stopwords: set}
+}
+
+// Default returns the shared Analyzer ignoring the DefaultStopwords.
+func Default() *Analyzer {
+	return sharedAnalyzer()
+}
+
+// Tokens splits the text into tokens, including the stopwords.
+func (a *Analyzer) Tokens(text string) []Token {
+	var tokens []Token
+	start := -1
+
+	for offset, r := range text {
+		if isWordRune(r) || (start >= 0 && isApostrophe(r)) {
+			if start < 0 {
+				start = offset
+			}
+			continue
+		}
+		if start >= 0 {
+			tokens = a.appendToken(tokens, text, start, offset)
+			start = -1
+		}
+	}
+	if start >= 0 {
+		tokens = a.appendToken(tokens, text, start, len(text))
+	}
+
+	return tokens
+}
+
+// Terms returns the terms of the text without the stopwords.
+func (a *Analyzer) Terms(text string) []string {
+	var terms []string
+	for _, token := range a.Tokens(text) {
+		if !token.Stopword {
+			terms = append(terms, token.Term)
+		}
+	}
+	return terms
+}
+
+// Words returns the lower-cased words of the text, including the stopwords.
+func (a *Analyzer) Words(text string) []string {
+	var words []string
+	for _, token := range a.Tokens(text) {
+		words = append(words, token.Word)
+	}
+	return words
+}
+
+// Contains reports whether the terms of the needle appear in the text one after another.
+// A needle consisting of stopwords only is ignored and is contained in any text.
+func (a *Analyzer) Contains(text, needle string) bool {
+	return ContainsSequence(a.Terms(text), a.Terms(needle))
+}
+
+// ContainsPhrase reports whether the words of the phrase appear in the text one after another,
+// without stemming the words or ignoring the stopwords.
+func (a *Analyzer) ContainsPhrase(text, phrase string) bool {
+	return ContainsSequence(a.Words(text), a.Words(phrase))
+}
+
+// ContainsSequence reports whether the needle is a contiguous subsequence of the haystack.
+// An empty needle is contained in any haystack.
+func ContainsSequence(haystack, needle []string) bool {
+	if len(needle) == 0 {
+		return true
+	}
+
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		matched := true
+		for j := range needle {
+			if haystack[i+j] != needle[j] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Analyzer) appendToken(tokens []Token, text string, start, end int) []Token {
+	raw := strings.TrimRightFunc(text[start:end], isApostrophe)
+	end = start + len(raw)
+
+	word := strings.ToLower(strings.ReplaceAll(raw, "’", "'"))
+	word = strings.TrimSuffix(word, "'s")
+
+	_, stopword := a.stopwords[word]
+
+	return append(tokens, Token{
+		Text:     raw,
+		Word:     word,
+		Term:     stem(word),
+		Start:    start,
+		End:      end,
+		Stopword: stopword,
+	})
+}
+
+// stem reduces English words to their Porter stem and keeps the words of other languages unchanged.
+func stem(word string) string {
+	for _, r := range word {
+		if r > unicode.MaxASCII || !unicode.IsLetter(r) {
+			return word
+		}
+	}
+	return porterstemmer.StemString(word)
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’'
+}
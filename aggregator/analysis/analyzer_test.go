@@ -0,0 +1,83 @@
+package analysis_test
+
+import (
+	"news-aggregator/aggregator/analysis"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzer_Tokens(t *testing.T) {
+	text := "Ukraine’s grain, exported (again)!"
+
+	tokens := analysis.Default().Tokens(text)
+
+	assert.Equal(t, []analysis.Token{
+		{Text: "Ukraine’s", Word: "ukraine", Term: "ukrain", Start: 0, End: 11},
+		{Text: "grain", Word: "grain", Term: "grain", Start: 12, End: 17},
+		{Text: "exported", Word: "exported", Term: "export", Start: 19, End: 27},
+		{Text: "again", Word: "again", Term: "again", Start: 29, End: 34},
+	}, tokens)
+
+	for _, token := range tokens {
+		assert.Equal(t, token.Text, text[token.Start:token.End])
+	}
+}
+
+func TestAnalyzer_Terms(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{"Stems words", "Running elections", []string{"run", "elect"}},
+		{"Skips stopwords", "The war in the east", []string{"war", "east"}},
+		{"Splits on punctuation", "U.S.-China talks", []string{"u", "s", "china", "talk"}},
+		{"Keeps inner apostrophes", "'don't' panic", []string{"don't", "panic"}},
+		{"Keeps non-English words", "Київ та Харків", []string{"київ", "та", "харків"}},
+		{"Keeps numbers", "G7 summit 2024", []string{"g7", "summit", "2024"}},
+		{"Empty text", " - ", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, analysis.Default().Terms(tt.text))
+		})
+	}
+}
+
+func TestAnalyzer_Contains(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		needle   string
+		expected bool
+	}{
+		{"Matches stemmed words", "Elections were held", "election", true},
+		{"Does not match parts of words", "Award-winning software", "war", false},
+		{"Matches words next to punctuation", "A war, again.", "war", true},
+		{"Matches a sequence of words", "The war in Ukraine continues", "war in ukraine", true},
+		{"Does not match scattered words", "Ukraine marks the end of war", "war ukraine", false},
+		{"Ignores stopwords only needle", "Anything", "the", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, analysis.Default().Contains(tt.text, tt.needle))
+		})
+	}
+}
+
+func TestAnalyzer_ContainsPhrase(t *testing.T) {
+	a := analysis.Default()
+
+	assert.True(t, a.ContainsPhrase("Peace talks, resumed", "peace talks"))
+	assert.False(t, a.ContainsPhrase("Peace talk resumed", "peace talks"))
+	assert.False(t, a.ContainsPhrase("War on Ukraine", "war in ukraine"))
+}
+
+func TestNew(t *testing.T) {
+	a := analysis.New([]string{"Breaking"})
+
+	assert.Equal(t, []string{"the", "new"}, a.Terms("Breaking: the news"))
+}
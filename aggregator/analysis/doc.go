@@ -0,0 +1,7 @@
+// Package analysis provides the text analysis shared by the article filters and the printer,
+// so that the articles selected by a keyword and the words highlighted for it agree.
+//
+// A text is split into word tokens on any character that is neither a letter nor a digit, keeping the apostrophes
+// inside words. Every token is lower-cased, stripped of the possessive "'s" and, for English words, reduced to its
+// Porter stem. Stopwords are ignored when the texts are compared.
+package analysis
@@ -0,0 +1,11 @@
+package analysis
+
+// DefaultStopwords is the list of common English words ignored by the default Analyzer.
+var DefaultStopwords = []string{
+	"a", "about", "after", "all", "also", "an", "and", "any", "are", "as", "at",
+	"be", "been", "but", "by", "can", "could", "did", "do", "does", "for", "from",
+	"had", "has", "have", "he", "her", "his", "how", "i", "if", "in", "into", "is", "it", "its",
+	"just", "may", "more", "most", "no", "not", "of", "on", "or", "our", "out", "over",
+	"she", "so", "some", "than", "that", "the", "their", "them", "then", "there", "these", "they", "this", "to",
+	"up", "was", "we", "were", "what", "when", "which", "who", "will", "with", "would", "you", "your",
+}
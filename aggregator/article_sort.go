@@ -2,6 +2,7 @@ package aggregator
 
 import (
 	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/ranking"
 	"sort"
 	"time"
 )
@@ -27,3 +28,9 @@ func SortArticlesByDateDesc(articles []article.Article) []article.Article {
 	})
 	return sortedArticles
 }
+
+// SortArticlesByRelevance sorts the given array of articles by relevance for the given keywords or phrases
+// in descending order, see ranking.Scorer.
+func SortArticlesByRelevance(articles []article.Article, queries []string) []ranking.ScoredArticle {
+	return ranking.NewScorer(queries).Rank(articles)
+}
@@ -72,3 +72,34 @@ func TestSortArticlesByDateDesc(t *testing.T) {
 		t.Errorf("SortArticlesByDateDesc returned %v, expected %v", sortedDesc, expectedDesc)
 	}
 }
+
+func TestSortArticlesByRelevance(t *testing.T) {
+	date := article.CreationDate(time.Now())
+
+	articleA, _ := article.NewArticleBuilder().
+		SetDate(date).
+		SetTitle("Markets").
+		SetDescription("Grain prices rise").
+		SetSource("nbc").
+		Build()
+
+	articleB, _ := article.NewArticleBuilder().
+		SetDate(date).
+		SetTitle("Grain exports").
+		SetDescription("Grain ships leave port").
+		SetSource("nbc").
+		Build()
+
+	scored := SortArticlesByRelevance([]article.Article{*articleA, *articleB}, []string{"grain"})
+
+	if len(scored) != 2 {
+		t.Fatalf("SortArticlesByRelevance returned %d articles, expected 2", len(scored))
+	}
+	if !reflect.DeepEqual(scored[0].Article, *articleB) || !reflect.DeepEqual(scored[1].Article, *articleA) {
+		t.Errorf("SortArticlesByRelevance returned %v, expected %v first", scored, *articleB)
+	}
+	if scored[0].Score <= scored[1].Score {
+		t.Errorf("SortArticlesByRelevance returned scores %v and %v, expected descending order",
+			scored[0].Score, scored[1].Score)
+	}
+}
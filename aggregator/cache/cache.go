@@ -0,0 +1,201 @@
+package cache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/aggregator/parser"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultMaxBytes is the default limit of the estimated size of the articles kept by a Cache.
+const DefaultMaxBytes = 64 << 20
+
+// articleOverhead is the estimated size of an article besides its texts.
+const articleOverhead = 256
+
+// Stats are the statistics of a Cache.
+type Stats struct {
+	// Entries is the number of the cached resources.
+	Entries int
+	// Bytes is the estimated size of the cached articles.
+	Bytes int64
+	// MaxBytes is the limit of Bytes.
+	MaxBytes int64
+	// Hits is the number of the resources found in the cache.
+	Hits uint64
+	// Misses is the number of the resources not found in the cache.
+	Misses uint64
+	// Evictions is the number of the entries evicted to keep the cache within the limit.
+	Evictions uint64
+}
+
+// entry is the result of parsing a resource.
+type entry struct {
+	key      string
+	source   resource.Source
+	articles []article.Article
+	warnings []parser.ItemWarning
+	size     int64
+}
+
+// Cache is a least recently used cache of the articles parsed from resources, limited by their estimated size.
+// It is safe for concurrent use.
+type Cache struct {
+	mu       sync.Mutex
+	maxBytes int64
+	bytes    int64
+	entries  map[string]*list.Element
+	order    *list.List
+	stats    Stats
+}
+
+// New creates a new Cache limited to DefaultMaxBytes.
+func New() *Cache {
+	return &Cache{
+		maxBytes: DefaultMaxBytes,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// SetMaxBytes sets the limit of the estimated size of the cached articles, evicting entries above it.
+// Values lower than one are ignored.
+func (c *Cache) SetMaxBytes(maxBytes int64) {
+	if maxBytes < 1 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.maxBytes = maxBytes
+	c.evict()
+}
+
+// Get returns the articles and the warnings of the resource parsed in the given tolerant mode,
+// and reports whether they were cached.
+func (c *Cache) Get(res resource.Resource, tolerant bool) ([]article.Article, []parser.ItemWarning, bool) {
+	key := Key(res, tolerant)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, exists := c.entries[key]
+	if !exists {
+		c.stats.Misses++
+		return nil, nil, false
+	}
+
+	c.stats.Hits++
+	c.order.MoveToFront(element)
+
+	e := element.Value.(*entry)
+	return slices.Clone(e.articles), slices.Clone(e.warnings), true
+}
+
+// Put caches the articles and the warnings of the resource parsed in the given tolerant mode.
+// Results larger than the limit of the cache are not cached.
+func (c *Cache) Put(res resource.Resource, tolerant bool, articles []article.Article, warnings []parser.ItemWarning) {
+	e := &entry{
+		key:      Key(res, tolerant),
+		source:   res.Source(),
+		articles: slices.Clone(articles),
+		warnings: slices.Clone(warnings),
+		size:     size(articles),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if e.size > c.maxBytes {
+		return
+	}
+
+	if element, exists := c.entries[e.key]; exists {
+		c.remove(element)
+	}
+
+	c.entries[e.key] = c.order.PushFront(e)
+	c.bytes += e.size
+	c.evict()
+}
+
+// Invalidate removes all entries of the source.
+func (c *Cache) Invalidate(source resource.Source) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, element := range c.entries {
+		if element.Value.(*entry).source == source {
+			c.remove(element)
+		}
+	}
+}
+
+// Stats returns the current statistics of the cache.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = len(c.entries)
+	stats.Bytes = c.bytes
+	stats.MaxBytes = c.maxBytes
+	return stats
+}
+
+// evict removes the least recently used entries until the cache is within the limit.
+func (c *Cache) evict() {
+	for c.bytes > c.maxBytes {
+		c.remove(c.order.Back())
+		c.stats.Evictions++
+	}
+}
+
+func (c *Cache) remove(element *list.Element) {
+	e := element.Value.(*entry)
+	c.order.Remove(element)
+	delete(c.entries, e.key)
+	c.bytes -= e.size
+}
+
+// Key returns the key of the resource parsed in the given tolerant mode. A resource of a stored snapshot
+// is keyed by its source, format and the identity of the snapshot, so that its content is neither read nor hashed,
+// any other resource by a hash of its source, format and content.
+func Key(res resource.Resource, tolerant bool) string {
+	prefix := strings.Join([]string{
+		string(res.Source()), strconv.Itoa(int(res.Format())), strconv.FormatBool(tolerant), "",
+	}, "\x00")
+
+	if snapshot := res.Snapshot(); snapshot != "" {
+		return prefix + "snapshot:" + snapshot
+	}
+
+	hash := sha256.Sum256([]byte(res.Content()))
+	return prefix + "content:" + hex.EncodeToString(hash[:])
+}
+
+// size returns the estimated size of the articles in bytes.
+func size(articles []article.Article) int64 {
+	var total int64
+
+	for _, art := range articles {
+		total += articleOverhead
+		total += int64(len(art.ID()) + len(art.Title()) + len(art.Description()) + len(art.Source()) +
+			len(art.Author()) + len(art.Link()) + len(art.Image()) + len(art.Language()) + len(art.Content()))
+		for _, category := range art.Categories() {
+			total += int64(len(category))
+		}
+		for _, tag := range art.Tags() {
+			total += int64(len(tag))
+		}
+	}
+
+	return total
+}
@@ -0,0 +1,143 @@
+package cache_test
+
+import (
+	"news-aggregator/aggregator/cache"
+	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/aggregator/parser"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createResource(t *testing.T, source resource.Source, content string) resource.Resource {
+	res, err := resource.New(source, resource.RSS, resource.Content(content))
+	assert.NoError(t, err)
+	return *res
+}
+
+func createArticles(t *testing.T, source resource.Source, count int, description string) []article.Article {
+	var articles []article.Article
+	for i := 0; i < count; i++ {
+		art, err := article.NewArticleBuilder().
+			SetTitle("Title").
+			SetDescription(article.Description(description)).
+			SetDate(article.CreationDate(time.Date(2024, 5, 19, i, 0, 0, 0, time.UTC))).
+			SetSource(source).
+			Build()
+		assert.NoError(t, err)
+		articles = append(articles, *art)
+	}
+	return articles
+}
+
+func TestCache_GetPut(t *testing.T) {
+	c := cache.New()
+	res := createResource(t, "bbc-world", "content")
+	articles := createArticles(t, "bbc-world", 2, "Description")
+	warnings := []parser.ItemWarning{{Index: 2, Field: "date", Reason: "unknown format"}}
+
+	_, _, cached := c.Get(res, true)
+	assert.False(t, cached)
+
+	c.Put(res, true, articles, warnings)
+
+	cachedArticles, cachedWarnings, cached := c.Get(res, true)
+	assert.True(t, cached)
+	assert.Equal(t, articles, cachedArticles)
+	assert.Equal(t, warnings, cachedWarnings)
+
+	_, _, cached = c.Get(res, false)
+	assert.False(t, cached, "The result of another mode should not be cached")
+	_, _, cached = c.Get(createResource(t, "bbc-world", "new content"), true)
+	assert.False(t, cached, "A new snapshot should not be cached")
+	_, _, cached = c.Get(createResource(t, "abc-news", "content"), true)
+	assert.False(t, cached, "The same content of another source should not be cached")
+
+	cachedArticles[0] = articles[1]
+	again, _, _ := c.Get(res, true)
+	assert.Equal(t, articles, again, "The cached articles should not be modified by the caller")
+
+	stats := c.Stats()
+	assert.Equal(t, 1, stats.Entries)
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(4), stats.Misses)
+	assert.Greater(t, stats.Bytes, int64(0))
+	assert.Equal(t, int64(cache.DefaultMaxBytes), stats.MaxBytes)
+}
+
+func TestCache_SetMaxBytes(t *testing.T) {
+	c := cache.New()
+	articles := createArticles(t, "bbc-world", 1, "Description")
+
+	for _, content := range []string{"first", "second", "third"} {
+		c.Put(createResource(t, "bbc-world", content), true, articles, nil)
+	}
+	entrySize := c.Stats().Bytes / 3
+
+	// The first snapshot becomes the most recently used one.
+	_, _, cached := c.Get(createResource(t, "bbc-world", "first"), true)
+	assert.True(t, cached)
+
+	c.SetMaxBytes(2 * entrySize)
+
+	stats := c.Stats()
+	assert.Equal(t, 2, stats.Entries)
+	assert.Equal(t, uint64(1), stats.Evictions)
+	assert.LessOrEqual(t, stats.Bytes, stats.MaxBytes)
+
+	_, _, cached = c.Get(createResource(t, "bbc-world", "second"), true)
+	assert.False(t, cached, "The least recently used entry should be evicted")
+	_, _, cached = c.Get(createResource(t, "bbc-world", "first"), true)
+	assert.True(t, cached)
+
+	c.Put(createResource(t, "bbc-world", "large"), true,
+		createArticles(t, "bbc-world", 1, strings.Repeat("Description", 100)), nil)
+	_, _, cached = c.Get(createResource(t, "bbc-world", "large"), true)
+	assert.False(t, cached, "A result larger than the limit should not be cached")
+
+	c.SetMaxBytes(0)
+	assert.Equal(t, 2*entrySize, c.Stats().MaxBytes, "Invalid limits should be ignored")
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	c := cache.New()
+
+	c.Put(createResource(t, "bbc-world", "first"), true, createArticles(t, "bbc-world", 1, "Description"), nil)
+	c.Put(createResource(t, "bbc-world", "second"), true, createArticles(t, "bbc-world", 1, "Description"), nil)
+	c.Put(createResource(t, "abc-news", "first"), true, createArticles(t, "abc-news", 1, "Description"), nil)
+
+	c.Invalidate("bbc-world")
+
+	stats := c.Stats()
+	assert.Equal(t, 1, stats.Entries)
+	_, _, cached := c.Get(createResource(t, "abc-news", "first"), true)
+	assert.True(t, cached)
+	_, _, cached = c.Get(createResource(t, "bbc-world", "first"), true)
+	assert.False(t, cached)
+}
+
+func TestKey(t *testing.T) {
+	snapshot := func(identity string) resource.Resource {
+		res, err := resource.NewSnapshot("bbc-world", resource.RSS, identity, func() (resource.Content, error) {
+			t.Fatal("The snapshot should not be read to compute its key")
+			return "", nil
+		})
+		assert.NoError(t, err)
+		return *res
+	}
+
+	key := cache.Key(snapshot("bbc-world_1.xml:1:10"), false)
+	assert.Equal(t, key, cache.Key(snapshot("bbc-world_1.xml:1:10"), false))
+	assert.NotEqual(t, key, cache.Key(snapshot("bbc-world_1.xml:2:10"), false))
+	assert.NotEqual(t, key, cache.Key(snapshot("bbc-world_1.xml:1:10"), true))
+
+	assert.Equal(t, cache.Key(createResource(t, "bbc-world", "content"), false),
+		cache.Key(createResource(t, "bbc-world", "content"), false))
+	assert.NotEqual(t, cache.Key(createResource(t, "bbc-world", "content"), false),
+		cache.Key(createResource(t, "bbc-world", "changed"), false))
+	assert.NotEqual(t, cache.Key(createResource(t, "bbc-world", "content"), false),
+		cache.Key(createResource(t, "cnn", "content"), false))
+}
@@ -0,0 +1,9 @@
+// Package cache provides an in-memory cache of the article.Article's parsed from resource.Resource's,
+// so that the snapshots of the sources are not parsed again on every aggregation.
+//
+// Entries of the stored snapshots are keyed by the source, format and identity of the snapshot, e.g. its name,
+// modification time and size, so a snapshot found in the cache is not read again and a new or changed snapshot
+// is never served from it. Other resources are keyed by a hash of their source, format and content.
+// The cache is limited by the estimated size of the kept articles, the least recently used entries are evicted
+// first. Entries of a source may also be invalidated explicitly, e.g. when the parser of the source changes.
+package cache
@@ -0,0 +1,156 @@
+package cluster
+
+import (
+	"news-aggregator/aggregator/analysis"
+	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/model/resource"
+	"sort"
+	"time"
+)
+
+// Default parameters of a Clusterer.
+const (
+	// DefaultThreshold is the smallest cosine similarity of an article to the centroid of the story it joins.
+	DefaultThreshold = 0.3
+	// DefaultTitleBoost is the number of description terms a title term counts as.
+	DefaultTitleBoost = 2.0
+)
+
+// Cluster is a story: a group of articles about the same event.
+type Cluster struct {
+	// Representative is the article the most similar to the centroid of the story.
+	Representative article.Article
+	// Articles are the members of the story from the oldest to the newest one.
+	Articles []article.Article
+	// Sources are the sources that carried the story, in the order of their first article.
+	Sources []resource.Source
+	// Start and End are the dates of the oldest and the newest article of the story.
+	Start, End time.Time
+}
+
+// Headline returns the title of the representative article.
+func (c Cluster) Headline() string {
+	return c.Representative.TitleStr()
+}
+
+// SourceDiversity returns the number of distinct sources that carried the story.
+func (c Cluster) SourceDiversity() int {
+	return len(c.Sources)
+}
+
+// TimeSpan returns the time between the oldest and the newest article of the story.
+func (c Cluster) TimeSpan() time.Duration {
+	return c.End.Sub(c.Start)
+}
+
+// Clusterer groups articles into stories.
+type Clusterer struct {
+	analyzer   *analysis.Analyzer
+	threshold  float64
+	titleBoost float64
+}
+
+// New creates a new Clusterer with the default threshold.
+func New() *Clusterer {
+	return &Clusterer{
+		analyzer:   analysis.Default(),
+		threshold:  DefaultThreshold,
+		titleBoost: DefaultTitleBoost,
+	}
+}
+
+// SetThreshold sets the smallest cosine similarity of an article to the centroid of the story it joins,
+// from 0 to 1. Higher values produce more and smaller stories. Values out of the range are ignored.
+func (c *Clusterer) SetThreshold(threshold float64) {
+	if threshold >= 0 && threshold <= 1 {
+		c.threshold = threshold
+	}
+}
+
+// Cluster groups the articles into stories, ordered from the largest to the smallest one.
+// Stories of the same size are ordered by the number of sources and then from the newest to the oldest one.
+func (c *Clusterer) Cluster(articles []article.Article) []Cluster {
+	order := make([]int, len(articles))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return time.Time(articles[order[i]].Date()).Before(time.Time(articles[order[j]].Date()))
+	})
+
+	vectors := vectorize(c.analyzer, articles, c.titleBoost)
+
+	type story struct {
+		centroid vector
+		members  []int
+	}
+	var stories []*story
+
+	for _, index := range order {
+		var best *story
+		bestSimilarity := 0.0
+
+		for _, s := range stories {
+			similarity := cosine(vectors[index], s.centroid)
+			if similarity > bestSimilarity {
+				best, bestSimilarity = s, similarity
+			}
+		}
+
+		if best == nil || bestSimilarity < c.threshold || len(vectors[index]) == 0 {
+			best = &story{centroid: make(vector)}
+			stories = append(stories, best)
+		}
+
+		best.centroid.add(vectors[index])
+		best.members = append(best.members, index)
+	}
+
+	clusters := make([]Cluster, 0, len(stories))
+	for _, s := range stories {
+		clusters = append(clusters, c.newCluster(articles, vectors, s.members, s.centroid))
+	}
+
+	sort.SliceStable(clusters, func(i, j int) bool {
+		if len(clusters[i].Articles) != len(clusters[j].Articles) {
+			return len(clusters[i].Articles) > len(clusters[j].Articles)
+		}
+		if clusters[i].SourceDiversity() != clusters[j].SourceDiversity() {
+			return clusters[i].SourceDiversity() > clusters[j].SourceDiversity()
+		}
+		return clusters[i].End.After(clusters[j].End)
+	})
+
+	return clusters
+}
+
+// newCluster builds the story of the members, given in the chronological order.
+func (c *Clusterer) newCluster(articles []article.Article, vectors []vector, members []int, centroid vector) Cluster {
+	cluster := Cluster{
+		Start: time.Time(articles[members[0]].Date()),
+		End:   time.Time(articles[members[len(members)-1]].Date()),
+	}
+
+	seen := make(map[resource.Source]struct{})
+	representative := members[0]
+	bestSimilarity := -1.0
+
+	for _, index := range members {
+		a := articles[index]
+		cluster.Articles = append(cluster.Articles, a)
+
+		for _, source := range a.Sources() {
+			if _, exists := seen[source]; !exists {
+				seen[source] = struct{}{}
+				cluster.Sources = append(cluster.Sources, source)
+			}
+		}
+
+		if similarity := cosine(vectors[index], centroid); similarity > bestSimilarity {
+			representative, bestSimilarity = index, similarity
+		}
+	}
+
+	cluster.Representative = articles[representative]
+	return cluster
+}
@@ -0,0 +1,117 @@
+package cluster_test
+
+import (
+	"news-aggregator/aggregator/cluster"
+	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/model/resource"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var baseDate = time.Date(2024, 5, 18, 12, 0, 0, 0, time.UTC)
+
+func createArticle(source, title, description string, offset time.Duration) article.Article {
+	art, err := article.NewArticleBuilder().
+		SetTitle(article.Title(title)).
+		SetDescription(article.Description(description)).
+		SetDate(article.CreationDate(baseDate.Add(offset))).
+		SetSource(resource.Source(source)).
+		Build()
+	if err != nil {
+		panic(err)
+	}
+	return *art
+}
+
+// firstTitles returns the title of the oldest article of every cluster.
+func firstTitles(clusters []cluster.Cluster) []string {
+	var result []string
+	for _, c := range clusters {
+		result = append(result, c.Articles[0].TitleStr())
+	}
+	return result
+}
+
+func TestClusterer_Cluster(t *testing.T) {
+	articles := []article.Article{
+		createArticle("nbc-news", "Usyk beats Fury to become undisputed heavyweight champion",
+			"Oleksandr Usyk beat Tyson Fury by split decision in Riyadh.", 2*time.Hour),
+		createArticle("abc-news", "Slovak PM in serious condition after shooting",
+			"Slovak Prime Minister Robert Fico remains in serious condition after the shooting.", time.Hour),
+		createArticle("bbc-world", "Usyk reaches boxing's summit after beating Fury",
+			"Usyk beat Fury by split decision to become the undisputed heavyweight champion.", 3*time.Hour),
+		createArticle("washington-times", "Undisputed heavyweight champion Usyk beats Fury",
+			"Tyson Fury lost to Oleksandr Usyk by split decision.", 0),
+		createArticle("abc-news", "Markets rally on rate cut hopes",
+			"Stocks rose on Friday as investors bet on a rate cut in September.", 4*time.Hour),
+		createArticle("nbc-news", "Slovak prime minister Fico stable after shooting",
+			"Fico is stable after the shooting as the suspect appears in court.", 5*time.Hour),
+	}
+
+	clusters := cluster.New().Cluster(articles)
+
+	assert.Len(t, clusters, 3)
+
+	boxing := clusters[0]
+	assert.Len(t, boxing.Articles, 3)
+	assert.Equal(t, []resource.Source{"washington-times", "nbc-news", "bbc-world"}, boxing.Sources)
+	assert.Equal(t, 3, boxing.SourceDiversity())
+	assert.Equal(t, baseDate, boxing.Start)
+	assert.Equal(t, baseDate.Add(3*time.Hour), boxing.End)
+	assert.Equal(t, 3*time.Hour, boxing.TimeSpan())
+	assert.Contains(t, boxing.Headline(), "Usyk")
+
+	slovakia := clusters[1]
+	assert.Len(t, slovakia.Articles, 2)
+	assert.Equal(t, "Slovak PM in serious condition after shooting", slovakia.Articles[0].TitleStr())
+
+	assert.Equal(t, "Markets rally on rate cut hopes", clusters[2].Headline())
+}
+
+func TestClusterer_SetThreshold(t *testing.T) {
+	articles := []article.Article{
+		createArticle("nbc-news", "Usyk beats Fury", "Split decision in Riyadh", 0),
+		createArticle("bbc-world", "Usyk beats Fury again", "Another split decision", time.Hour),
+		createArticle("abc-news", "Markets rally", "Stocks rose on Friday", 2*time.Hour),
+	}
+
+	tests := []struct {
+		name      string
+		threshold float64
+		expected  int
+	}{
+		{"Zero threshold joins articles sharing a term", 0, 2},
+		{"Default threshold", cluster.DefaultThreshold, 2},
+		{"Full similarity is required", 1, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clusterer := cluster.New()
+			clusterer.SetThreshold(tt.threshold)
+
+			assert.Len(t, clusterer.Cluster(articles), tt.expected)
+		})
+	}
+}
+
+func TestClusterer_Cluster_Empty(t *testing.T) {
+	assert.Empty(t, cluster.New().Cluster(nil))
+}
+
+func TestClusterer_Cluster_Order(t *testing.T) {
+	articles := []article.Article{
+		createArticle("nbc-news", "Bakery opens downtown", "Fresh bread every morning", 0),
+		createArticle("abc-news", "Usyk beats Fury", "Split decision", time.Hour),
+		createArticle("abc-news", "Usyk beats Fury again", "Split decision", 2*time.Hour),
+		createArticle("bbc-world", "Fico stable", "Slovak prime minister", 3*time.Hour),
+		createArticle("nbc-news", "Fico still stable", "Slovak prime minister", 4*time.Hour),
+		createArticle("abc-news", "Volcano erupts in Iceland", "Lava reaches the town", 5*time.Hour),
+	}
+
+	clusters := cluster.New().Cluster(articles)
+
+	assert.Equal(t, []string{"Fico stable", "Usyk beats Fury", "Volcano erupts in Iceland", "Bakery opens downtown"}, firstTitles(clusters))
+}
@@ -0,0 +1,7 @@
+// Package cluster provides the grouping of article.Article's into stories about the same event.
+//
+// Every article is turned into a TF-IDF vector of the terms of its title and description, see the analysis package.
+// The articles are then clustered in a single pass from the oldest to the newest one: an article joins the story
+// whose centroid is the most similar to it if the cosine similarity reaches the threshold, and starts a new story
+// otherwise.
+package cluster
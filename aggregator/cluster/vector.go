@@ -0,0 +1,89 @@
+package cluster
+
+import (
+	"math"
+	"news-aggregator/aggregator/analysis"
+	"news-aggregator/aggregator/model/article"
+)
+
+// vector is a sparse vector of term weights.
+type vector map[string]float64
+
+// dot returns the dot product of the vectors.
+func (v vector) dot(other vector) float64 {
+	if len(other) < len(v) {
+		v, other = other, v
+	}
+
+	product := 0.0
+	for term, weight := range v {
+		product += weight * other[term]
+	}
+	return product
+}
+
+// norm returns the Euclidean length of the vector.
+func (v vector) norm() float64 {
+	return math.Sqrt(v.dot(v))
+}
+
+// normalize scales the vector to the unit length in place.
+func (v vector) normalize() vector {
+	norm := v.norm()
+	if norm == 0 {
+		return v
+	}
+	for term := range v {
+		v[term] /= norm
+	}
+	return v
+}
+
+// add adds the other vector to the vector in place.
+func (v vector) add(other vector) {
+	for term, weight := range other {
+		v[term] += weight
+	}
+}
+
+// cosine returns the cosine similarity of the vectors.
+func cosine(a, b vector) float64 {
+	norms := a.norm() * b.norm()
+	if norms == 0 {
+		return 0
+	}
+	return a.dot(b) / norms
+}
+
+// vectorize returns the normalized TF-IDF vectors of the articles, where the title terms weigh titleBoost times
+// as much as the description terms. The rarity of the terms is measured in the given articles.
+func vectorize(analyzer *analysis.Analyzer, articles []article.Article, titleBoost float64) []vector {
+	frequencies := make([]vector, len(articles))
+	documentFrequencies := make(map[string]int)
+
+	for i, a := range articles {
+		frequency := make(vector)
+		for _, term := range analyzer.Terms(a.TitleStr()) {
+			frequency[term] += titleBoost
+		}
+		for _, term := range analyzer.Terms(a.DescriptionStr()) {
+			frequency[term]++
+		}
+
+		for term := range frequency {
+			documentFrequencies[term]++
+		}
+		frequencies[i] = frequency
+	}
+
+	total := float64(len(articles))
+	for _, frequency := range frequencies {
+		for term, weight := range frequency {
+			idf := math.Log((1+total)/(1+float64(documentFrequencies[term]))) + 1
+			frequency[term] = (1 + math.Log(weight)) * idf
+		}
+		frequency.normalize()
+	}
+
+	return frequencies
+}
@@ -0,0 +1,265 @@
+package dedup
+
+import (
+	"fmt"
+	"news-aggregator/aggregator/analysis"
+	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/model/resource"
+	"strings"
+	"time"
+)
+
+// Default thresholds of a Deduplicator.
+const (
+	// DefaultMaxDistance is the largest Hamming distance of the fingerprints of near-duplicates.
+	// Fingerprints of unrelated articles differ in about 32 bits, and those of rewrites of the same short story,
+	// e.g. a title and a summary, differ in about 5 bits.
+	DefaultMaxDistance = 8
+	// DefaultMinTerms is the smallest number of terms an article needs to be compared for near-duplicates,
+	// shorter texts are too likely to collide.
+	DefaultMinTerms = 5
+)
+
+// The modes of the duplicate detection accepted by NewForMode.
+const (
+	ModeOff   = "off"
+	ModeExact = "exact"
+	ModeNear  = "near"
+)
+
+// titleWeight is the weight of the title words in the fingerprint relative to the description words.
+const titleWeight = 2
+
+// Deduplicator is an aggregator.Filter that keeps a single article of every group of duplicates.
+// Articles are exact duplicates if their normalized links are equal, or if they have no link and the words
+// of their titles and descriptions are equal. Near-duplicates are detected only if enabled.
+// The earliest article of a group is kept, optionally listing the sources of all articles of the group.
+type Deduplicator struct {
+	analyzer    *analysis.Analyzer
+	near        bool
+	maxDistance int
+	minTerms    int
+	collapse    bool
+}
+
+// New creates a new Deduplicator detecting exact duplicates.
+func New() *Deduplicator {
+	return &Deduplicator{
+		analyzer:    analysis.Default(),
+		maxDistance: DefaultMaxDistance,
+		minTerms:    DefaultMinTerms,
+	}
+}
+
+// NewForMode creates a new Deduplicator detecting exact duplicates for ModeExact, or exact and near-duplicates
+// for ModeNear. It returns nil for ModeOff.
+func NewForMode(mode string) (*Deduplicator, error) {
+	switch mode {
+	case ModeOff:
+		return nil, nil
+	case ModeExact:
+		return New(), nil
+	case ModeNear:
+		deduplicator := New()
+		deduplicator.SetNearDuplicates(true)
+		return deduplicator, nil
+	default:
+		return nil, fmt.Errorf("unknown deduplication mode \"%s\"", mode)
+	}
+}
+
+// SetNearDuplicates enables or disables the detection of near-duplicates.
+func (d *Deduplicator) SetNearDuplicates(enabled bool) {
+	d.near = enabled
+}
+
+// SetMaxDistance sets the largest Hamming distance of the fingerprints of near-duplicates, from 0 to 64.
+// Values out of the range are ignored.
+func (d *Deduplicator) SetMaxDistance(distance int) {
+	if distance >= 0 && distance <= 64 {
+		d.maxDistance = distance
+	}
+}
+
+// SetMinTerms sets the smallest number of terms an article needs to be compared for near-duplicates.
+// Negative values are ignored.
+func (d *Deduplicator) SetMinTerms(terms int) {
+	if terms >= 0 {
+		d.minTerms = terms
+	}
+}
+
+// SetCollapse makes the kept article of every group list the sources of all articles of the group.
+func (d *Deduplicator) SetCollapse(collapse bool) {
+	d.collapse = collapse
+}
+
+// Apply returns the articles without duplicates, keeping the order of the kept articles.
+func (d *Deduplicator) Apply(articles []article.Article) []article.Article {
+	groups := d.Groups(articles)
+
+	kept := make(map[int][]int, len(groups))
+	for _, group := range groups {
+		kept[d.earliest(articles, group)] = group
+	}
+
+	var deduplicated []article.Article
+	for i, a := range articles {
+		group, isKept := kept[i]
+		if !isKept {
+			continue
+		}
+
+		if d.collapse {
+			sources := make([]resource.Source, 0, len(group))
+			for _, member := range group {
+				sources = append(sources, articles[member].Sources()...)
+			}
+			a = a.WithSources(sources)
+		}
+
+		deduplicated = append(deduplicated, a)
+	}
+
+	return deduplicated
+}
+
+// Groups returns the indexes of the articles grouped by duplicates, including the groups of a single article.
+// Duplication is transitive: two articles are in the same group if they are linked by a chain of duplicates.
+// The groups and their members are ordered by the first index.
+func (d *Deduplicator) Groups(articles []article.Article) [][]int {
+	groups := newUnionFind(len(articles))
+
+	exactKeys := make(map[string]int, len(articles))
+	for i, a := range articles {
+		key := d.exactKey(a)
+		if first, exists := exactKeys[key]; exists {
+			groups.union(first, i)
+		} else {
+			exactKeys[key] = i
+		}
+	}
+
+	if d.near {
+		d.groupNearDuplicates(articles, groups)
+	}
+
+	indexes := make(map[int]int)
+	var result [][]int
+	for i := range articles {
+		root := groups.find(i)
+		index, exists := indexes[root]
+		if !exists {
+			index = len(result)
+			indexes[root] = index
+			result = append(result, nil)
+		}
+		result[index] = append(result[index], i)
+	}
+
+	return result
+}
+
+func (d *Deduplicator) groupNearDuplicates(articles []article.Article, groups *unionFind) {
+	type fingerprint struct {
+		index int
+		hash  uint64
+	}
+
+	var fingerprints []fingerprint
+	for i, a := range articles {
+		features, terms := d.features(a)
+		if terms < d.minTerms {
+			continue
+		}
+		fingerprints = append(fingerprints, fingerprint{index: i, hash: SimHash(features)})
+	}
+
+	for i := range fingerprints {
+		for j := i + 1; j < len(fingerprints); j++ {
+			if Distance(fingerprints[i].hash, fingerprints[j].hash) <= d.maxDistance {
+				groups.union(fingerprints[i].index, fingerprints[j].index)
+			}
+		}
+	}
+}
+
+// exactKey returns the normalized link of the article, or its words if it has no link.
+func (d *Deduplicator) exactKey(a article.Article) string {
+	if link := NormalizeLink(string(a.Link())); link != "" {
+		return "link:" + link
+	}
+
+	title := strings.Join(d.analyzer.Words(a.TitleStr()), " ")
+	description := strings.Join(d.analyzer.Words(a.DescriptionStr()), " ")
+	return "text:" + title + "\n" + description
+}
+
+// features returns the weighted terms and term pairs of the title and the description, and the number of terms.
+func (d *Deduplicator) features(a article.Article) (map[string]float64, int) {
+	features := make(map[string]float64)
+	count := 0
+
+	for _, text := range []struct {
+		terms  []string
+		weight float64
+	}{
+		{d.analyzer.Terms(a.TitleStr()), titleWeight},
+		{d.analyzer.Terms(a.DescriptionStr()), 1},
+	} {
+		for i, term := range text.terms {
+			features[term] += text.weight
+			if i > 0 {
+				features[text.terms[i-1]+" "+term] += text.weight
+			}
+		}
+		count += len(text.terms)
+	}
+
+	return features, count
+}
+
+// earliest returns the index of the earliest article of the group, or the first one among equally early articles.
+func (d *Deduplicator) earliest(articles []article.Article, group []int) int {
+	earliest := group[0]
+	for _, index := range group[1:] {
+		if time.Time(articles[index].Date()).Before(time.Time(articles[earliest].Date())) {
+			earliest = index
+		}
+	}
+	return earliest
+}
+
+// unionFind is a disjoint-set forest of the article indexes.
+type unionFind struct {
+	parents []int
+}
+
+func newUnionFind(size int) *unionFind {
+	parents := make([]int, size)
+	for i := range parents {
+		parents[i] = i
+	}
+	return &unionFind{parents: parents}
+}
+
+func (u *unionFind) find(i int) int {
+	for u.parents[i] != i {
+		u.parents[i] = u.parents[u.parents[i]]
+		i = u.parents[i]
+	}
+	return i
+}
+
+// union joins the sets of the elements, the smaller root becomes the root of the joined set.
+func (u *unionFind) union(a, b int) {
+	rootA, rootB := u.find(a), u.find(b)
+	if rootA == rootB {
+		return
+	}
+	if rootA < rootB {
+		u.parents[rootB] = rootA
+	} else {
+		u.parents[rootA] = rootB
+	}
+}
@@ -0,0 +1,137 @@
+package dedup_test
+
+import (
+	"news-aggregator/aggregator/dedup"
+	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/model/resource"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var baseDate = time.Date(2024, 5, 18, 12, 0, 0, 0, time.UTC)
+
+func createArticle(source, title, description, link string, offset time.Duration) article.Article {
+	art, err := article.NewArticleBuilder().
+		SetTitle(article.Title(title)).
+		SetDescription(article.Description(description)).
+		SetDate(article.CreationDate(baseDate.Add(offset))).
+		SetSource(resource.Source(source)).
+		SetLink(article.Link(link)).
+		Build()
+	if err != nil {
+		panic(err)
+	}
+	return *art
+}
+
+const (
+	wireTitle       = "Ukraine and Russia exchange prisoners of war in deal brokered by the United Arab Emirates"
+	wireDescription = "Ukraine and Russia exchanged dozens of prisoners of war on Saturday in a swap mediated by " +
+		"the United Arab Emirates, officials in Kyiv and Moscow said, the latest in a series of exchanges."
+	rewrittenTitle       = "Ukraine, Russia exchange prisoners of war in deal brokered by United Arab Emirates"
+	rewrittenDescription = "Ukraine and Russia exchanged dozens of prisoners of war on Saturday in a swap mediated by " +
+		"the United Arab Emirates, officials in Kyiv and Moscow said, the latest in a long series of exchanges."
+)
+
+func TestDeduplicator_Apply(t *testing.T) {
+	articles := []article.Article{
+		createArticle("abc-news", wireTitle, wireDescription, "https://abcnews.go.com/story?id=1&utm_source=rss", time.Hour),
+		createArticle("abc-news", wireTitle, wireDescription, "http://www.abcnews.go.com/story/?id=1#top", 2*time.Hour),
+		createArticle("bbc-world", rewrittenTitle, rewrittenDescription, "https://www.bbc.com/news/1", 0),
+		createArticle("washington-times", "Markets rally on rate cut hopes",
+			"Stocks rose on Friday as investors bet on a rate cut in September.", "", 0),
+		createArticle("washington-times", "Markets rally on rate cut hopes",
+			"Stocks rose on Friday as investors bet on a rate cut in September.", "", time.Hour),
+	}
+
+	t.Run("Exact duplicates", func(t *testing.T) {
+		deduplicated := dedup.New().Apply(articles)
+
+		assert.Len(t, deduplicated, 3)
+		assert.Equal(t, resource.Source("abc-news"), deduplicated[0].Source())
+		assert.Equal(t, resource.Source("bbc-world"), deduplicated[1].Source())
+		assert.Equal(t, resource.Source("washington-times"), deduplicated[2].Source())
+		assert.Equal(t, article.CreationDate(baseDate.Add(time.Hour)), deduplicated[0].Date())
+	})
+
+	t.Run("Near duplicates keep the earliest article", func(t *testing.T) {
+		deduplicator := dedup.New()
+		deduplicator.SetNearDuplicates(true)
+
+		deduplicated := deduplicator.Apply(articles)
+
+		assert.Len(t, deduplicated, 2)
+		assert.Equal(t, resource.Source("bbc-world"), deduplicated[0].Source())
+		assert.Equal(t, []resource.Source{"bbc-world"}, deduplicated[0].Sources())
+	})
+
+	t.Run("Collapsed duplicates list all sources", func(t *testing.T) {
+		deduplicator := dedup.New()
+		deduplicator.SetNearDuplicates(true)
+		deduplicator.SetCollapse(true)
+
+		deduplicated := deduplicator.Apply(articles)
+
+		assert.Len(t, deduplicated, 2)
+		assert.Equal(t, []resource.Source{"bbc-world", "abc-news"}, deduplicated[0].Sources())
+		assert.Equal(t, []resource.Source{"washington-times"}, deduplicated[1].Sources())
+	})
+
+	t.Run("Zero distance keeps rewritten stories", func(t *testing.T) {
+		deduplicator := dedup.New()
+		deduplicator.SetNearDuplicates(true)
+		deduplicator.SetMaxDistance(0)
+
+		assert.Len(t, deduplicator.Apply(articles), 3)
+	})
+
+	t.Run("Short articles are not compared", func(t *testing.T) {
+		deduplicator := dedup.New()
+		deduplicator.SetNearDuplicates(true)
+		deduplicator.SetMinTerms(100)
+
+		assert.Len(t, deduplicator.Apply(articles), 3)
+	})
+}
+
+func TestDeduplicator_Groups(t *testing.T) {
+	articles := []article.Article{
+		createArticle("a", "First", "Description", "https://example.com/1", 0),
+		createArticle("b", "Second", "Description", "https://example.com/2", 0),
+		createArticle("c", "First again", "Description", "https://example.com/1/", 0),
+	}
+
+	assert.Equal(t, [][]int{{0, 2}, {1}}, dedup.New().Groups(articles))
+}
+
+func TestDistance(t *testing.T) {
+	features := map[string]float64{"ukrain": 2, "russia": 2, "prison": 1}
+
+	assert.Equal(t, 0, dedup.Distance(dedup.SimHash(features), dedup.SimHash(features)))
+	assert.Equal(t, 64, dedup.Distance(0, ^uint64(0)))
+}
+
+func TestNewForMode(t *testing.T) {
+	articles := []article.Article{
+		createArticle("abc-news", wireTitle, wireDescription, "https://abcnews.go.com/story?id=1", 0),
+		createArticle("abc-news", wireTitle, wireDescription, "https://abcnews.go.com/story?id=1", 0),
+		createArticle("bbc-world", rewrittenTitle, rewrittenDescription, "https://www.bbc.com/news/1", 0),
+	}
+
+	deduplicator, err := dedup.NewForMode(dedup.ModeOff)
+	assert.NoError(t, err)
+	assert.Nil(t, deduplicator)
+
+	deduplicator, err = dedup.NewForMode(dedup.ModeExact)
+	assert.NoError(t, err)
+	assert.Len(t, deduplicator.Apply(articles), 2)
+
+	deduplicator, err = dedup.NewForMode(dedup.ModeNear)
+	assert.NoError(t, err)
+	assert.Len(t, deduplicator.Apply(articles), 1)
+
+	_, err = dedup.NewForMode("fuzzy")
+	assert.EqualError(t, err, "unknown deduplication mode \"fuzzy\"")
+}
@@ -0,0 +1,7 @@
+// Package dedup provides the detection of duplicate article.Article's carried by several sources
+// or by several snapshots of the same source.
+//
+// Exact duplicates share a link once it is normalized: the scheme, the "www." prefix, the fragment,
+// the trailing slash and the tracking parameters are ignored. Near-duplicates have similar titles and descriptions,
+// which is measured by the Hamming distance of the 64-bit SimHash fingerprints of their words.
+package dedup
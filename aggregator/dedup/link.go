@@ -0,0 +1,9 @@
+package dedup
+
+import "news-aggregator/aggregator/model/article"
+
+// NormalizeLink returns the canonical form of the link used to detect exact duplicates,
+// or an empty string if the link is empty or cannot be parsed, see article.Link.Canonical.
+func NormalizeLink(link string) string {
+	return article.Link(link).Canonical()
+}
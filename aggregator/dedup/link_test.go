@@ -0,0 +1,31 @@
+package dedup_test
+
+import (
+	"news-aggregator/aggregator/dedup"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeLink(t *testing.T) {
+	tests := []struct {
+		name     string
+		link     string
+		expected string
+	}{
+		{"Scheme and www prefix", "http://www.Example.com/news/1", "example.com/news/1"},
+		{"Fragment and trailing slash", "https://example.com/news/1/#comments", "example.com/news/1"},
+		{"Tracking parameters", "https://example.com/news?id=1&utm_source=rss&fbclid=abc", "example.com/news?id=1"},
+		{"Parameters are sorted", "https://example.com/news?b=2&a=1", "example.com/news?a=1&b=2"},
+		{"Default port", "https://example.com:443/news", "example.com/news"},
+		{"Custom port", "https://example.com:8443/news", "example.com:8443/news"},
+		{"Empty link", " ", ""},
+		{"Relative link", "/news/1", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, dedup.NormalizeLink(tt.link))
+		})
+	}
+}
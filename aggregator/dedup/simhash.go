@@ -0,0 +1,40 @@
+package dedup
+
+import (
+	"hash/fnv"
+	"math/bits"
+)
+
+// SimHash returns the 64-bit SimHash fingerprint of the weighted features.
+// Texts sharing most of their features get fingerprints that differ in a few bits only.
+func SimHash(features map[string]float64) uint64 {
+	var weights [64]float64
+
+	for feature, weight := range features {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(feature))
+		hash := h.Sum64()
+
+		for bit := 0; bit < 64; bit++ {
+			if hash&(1<<bit) != 0 {
+				weights[bit] += weight
+			} else {
+				weights[bit] -= weight
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for bit, weight := range weights {
+		if weight > 0 {
+			fingerprint |= 1 << bit
+		}
+	}
+
+	return fingerprint
+}
+
+// Distance returns the number of bits the fingerprints differ in.
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
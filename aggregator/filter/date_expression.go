@@ -0,0 +1,181 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// isoDateLayout is the standard layout of calendar dates, e.g. 2024-05-19.
+	isoDateLayout = "2006-01-02"
+	// legacyDateLayout is the yyyy-dd-mm layout accepted by the first versions of the API, e.g. 2024-19-05.
+	legacyDateLayout = "2006-02-01"
+)
+
+// ErrAmbiguousDate is returned for a calendar date standing for different days in the YYYY-MM-DD
+// and the legacy yyyy-dd-mm layouts, once the parser rejects such dates, see SetRejectAmbiguous.
+var ErrAmbiguousDate = errors.New("ambiguous date")
+
+// DateExpressionParser turns the date expressions of the date filters into points in time.
+// Supported expressions are:
+//   - calendar dates in the YYYY-MM-DD layout, or yyyy-dd-mm if the legacy layout is enabled;
+//   - RFC3339 timestamps, e.g. 2024-05-19T10:00:00Z;
+//   - the keywords now, today, yesterday, last-week and last-month;
+//   - durations relative to now, e.g. -24h, 7d or -2w, where the sign is optional and d and w stand for days and weeks.
+//
+// A calendar date, today and yesterday stand for the start of the day when used as a start date,
+// and for the end of the day when used as an end date.
+type DateExpressionParser struct {
+	legacyLayout    bool
+	rejectAmbiguous bool
+	now             time.Time
+	location        *time.Location
+}
+
+// NewDateExpressionParser creates a new DateExpressionParser for the standard YYYY-MM-DD layout.
+func NewDateExpressionParser() *DateExpressionParser {
+	return &DateExpressionParser{
+		location: time.UTC,
+	}
+}
+
+// SetLegacyLayout makes calendar dates to be read in the legacy yyyy-dd-mm layout.
+func (p *DateExpressionParser) SetLegacyLayout(legacy bool) {
+	p.legacyLayout = legacy
+}
+
+// SetRejectAmbiguous makes calendar dates standing for different days in the YYYY-MM-DD and the legacy yyyy-dd-mm
+// layouts, e.g. 2024-05-06, to be rejected with ErrAmbiguousDate unless the legacy layout is set, so that
+// the clients still sending legacy dates are not served another range while they switch to the standard layout.
+func (p *DateExpressionParser) SetRejectAmbiguous(reject bool) {
+	p.rejectAmbiguous = reject
+}
+
+// SetNow sets the time the relative expressions are resolved against. The zero value stands for the current time.
+func (p *DateExpressionParser) SetNow(now time.Time) {
+	p.now = now
+}
+
+// ParseStart parses the expression as the start of a date range.
+func (p *DateExpressionParser) ParseStart(expression string) (time.Time, error) {
+	return p.parse(expression, false)
+}
+
+// ParseEnd parses the expression as the end of a date range.
+func (p *DateExpressionParser) ParseEnd(expression string) (time.Time, error) {
+	return p.parse(expression, true)
+}
+
+// ParseSince parses a duration like 24h, 7d or 2w as the start of a date range ending now.
+func (p *DateExpressionParser) ParseSince(duration string) (time.Time, error) {
+	offset, err := ParseDuration(duration)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return p.currentTime().Add(-offset), nil
+}
+
+// ParseDuration parses a duration like 24h, 7d or 2w, where d and w stand for days and weeks.
+// The sign is optional, the duration always points to the past.
+func ParseDuration(duration string) (time.Duration, error) {
+	offset, ok := parseRelativeDuration(strings.ToLower(strings.TrimSpace(duration)))
+	if !ok {
+		return 0, fmt.Errorf("unsupported duration \"%s\"", duration)
+	}
+	return offset, nil
+}
+
+func (p *DateExpressionParser) parse(expression string, end bool) (time.Time, error) {
+	expression = strings.ToLower(strings.TrimSpace(expression))
+	if expression == "" {
+		return time.Time{}, errors.New("date expression cannot be empty")
+	}
+
+	now := p.currentTime()
+
+	switch expression {
+	case "now":
+		return now, nil
+	case "today":
+		return p.boundOfDay(now, end), nil
+	case "yesterday":
+		return p.boundOfDay(now.AddDate(0, 0, -1), end), nil
+	case "last-week":
+		return now.AddDate(0, 0, -7), nil
+	case "last-month":
+		return now.AddDate(0, -1, 0), nil
+	}
+
+	layout := isoDateLayout
+	if p.legacyLayout {
+		layout = legacyDateLayout
+	}
+
+	if date, err := time.ParseInLocation(layout, expression, p.location); err == nil {
+		if p.rejectAmbiguous && !p.legacyLayout {
+			legacy, err := time.ParseInLocation(legacyDateLayout, expression, p.location)
+			if err == nil && !legacy.Equal(date) {
+				return time.Time{}, fmt.Errorf("%w \"%s\", it stands for %s in the legacy yyyy-dd-mm layout",
+					ErrAmbiguousDate, expression, legacy.Format(isoDateLayout))
+			}
+		}
+		return p.boundOfDay(date, end), nil
+	}
+
+	if date, err := time.Parse(time.RFC3339, strings.ToUpper(expression)); err == nil {
+		return date, nil
+	}
+
+	if offset, ok := parseRelativeDuration(expression); ok {
+		return now.Add(-offset), nil
+	}
+
+	return time.Time{}, fmt.Errorf("unsupported date expression \"%s\"", expression)
+}
+
+func (p *DateExpressionParser) currentTime() time.Time {
+	if p.now.IsZero() {
+		return time.Now().In(p.location)
+	}
+	return p.now.In(p.location)
+}
+
+// boundOfDay returns the first moment of the day of the date, or the last one for the end of a range.
+func (p *DateExpressionParser) boundOfDay(date time.Time, end bool) time.Time {
+	start := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	if end {
+		return start.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	return start
+}
+
+// parseRelativeDuration parses a duration pointing to the past, e.g. "-24h", "7d" or "-2w".
+func parseRelativeDuration(expression string) (time.Duration, bool) {
+	value := strings.TrimPrefix(expression, "-")
+	if value == "" {
+		return 0, false
+	}
+
+	unit := value[len(value)-1]
+	if unit == 'd' || unit == 'w' {
+		count, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil || count < 0 {
+			return 0, false
+		}
+
+		days := count
+		if unit == 'w' {
+			days *= 7
+		}
+		return time.Duration(days) * 24 * time.Hour, true
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, false
+	}
+	return duration, true
+}
@@ -0,0 +1,172 @@
+package filter_test
+
+import (
+	"errors"
+	"news-aggregator/aggregator/filter"
+	"testing"
+	"time"
+)
+
+func TestDateExpressionParser_Parse(t *testing.T) {
+	now := time.Date(2024, 6, 20, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		expression    string
+		legacy        bool
+		expectedStart time.Time
+		expectedEnd   time.Time
+		hasError      bool
+	}{
+		{"ISO date", "2024-06-15", false,
+			time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 6, 15, 23, 59, 59, 999999999, time.UTC), false},
+		{"RFC3339 timestamp", "2024-06-15T10:00:00+02:00", false,
+			time.Date(2024, 6, 15, 8, 0, 0, 0, time.UTC),
+			time.Date(2024, 6, 15, 8, 0, 0, 0, time.UTC), false},
+		{"Relative hours", "-24h", false,
+			time.Date(2024, 6, 19, 15, 30, 0, 0, time.UTC),
+			time.Date(2024, 6, 19, 15, 30, 0, 0, time.UTC), false},
+		{"Relative days without sign", "7d", false,
+			time.Date(2024, 6, 13, 15, 30, 0, 0, time.UTC),
+			time.Date(2024, 6, 13, 15, 30, 0, 0, time.UTC), false},
+		{"Relative weeks", "-2w", false,
+			time.Date(2024, 6, 6, 15, 30, 0, 0, time.UTC),
+			time.Date(2024, 6, 6, 15, 30, 0, 0, time.UTC), false},
+		{"Now", "now", false, now, now, false},
+		{"Today", "today", false,
+			time.Date(2024, 6, 20, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 6, 20, 23, 59, 59, 999999999, time.UTC), false},
+		{"Yesterday", "Yesterday", false,
+			time.Date(2024, 6, 19, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 6, 19, 23, 59, 59, 999999999, time.UTC), false},
+		{"Last week", "last-week", false,
+			time.Date(2024, 6, 13, 15, 30, 0, 0, time.UTC),
+			time.Date(2024, 6, 13, 15, 30, 0, 0, time.UTC), false},
+		{"Last month", "last-month", false,
+			time.Date(2024, 5, 20, 15, 30, 0, 0, time.UTC),
+			time.Date(2024, 5, 20, 15, 30, 0, 0, time.UTC), false},
+		{"Legacy date", "2024-15-06", true,
+			time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 6, 15, 23, 59, 59, 999999999, time.UTC), false},
+		{"Legacy date without legacy layout", "2024-15-06", false, time.Time{}, time.Time{}, true},
+		{"Unknown keyword", "next-week", false, time.Time{}, time.Time{}, true},
+		{"Negative duration", "--1h", false, time.Time{}, time.Time{}, true},
+		{"Empty expression", " ", false, time.Time{}, time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := filter.NewDateExpressionParser()
+			p.SetNow(now)
+			p.SetLegacyLayout(tt.legacy)
+
+			start, err := p.ParseStart(tt.expression)
+			if (err != nil) != tt.hasError {
+				t.Fatalf("expected error: %v, got: %v", tt.hasError, err)
+			}
+			if !tt.hasError && !start.Equal(tt.expectedStart) {
+				t.Errorf("expected start: %v, got: %v", tt.expectedStart, start)
+			}
+
+			end, err := p.ParseEnd(tt.expression)
+			if (err != nil) != tt.hasError {
+				t.Fatalf("expected error: %v, got: %v", tt.hasError, err)
+			}
+			if !tt.hasError && !end.Equal(tt.expectedEnd) {
+				t.Errorf("expected end: %v, got: %v", tt.expectedEnd, end)
+			}
+		})
+	}
+}
+
+func TestDateExpressionParser_RejectAmbiguous(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		legacy     bool
+		expected   time.Time
+		ambiguous  bool
+	}{
+		{"Ambiguous date", "2024-05-06", false, time.Time{}, true},
+		{"Day after the 12th", "2024-05-13", false, time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC), false},
+		{"Same day in both layouts", "2024-05-05", false, time.Date(2024, 5, 5, 0, 0, 0, 0, time.UTC), false},
+		{"Legacy layout", "2024-06-05", true, time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC), false},
+		{"Timestamp", "2024-05-06T10:00:00Z", false, time.Date(2024, 5, 6, 10, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := filter.NewDateExpressionParser()
+			p.SetLegacyLayout(tt.legacy)
+			p.SetRejectAmbiguous(true)
+
+			start, err := p.ParseStart(tt.expression)
+			if errors.Is(err, filter.ErrAmbiguousDate) != tt.ambiguous {
+				t.Fatalf("expected ambiguous: %v, got: %v", tt.ambiguous, err)
+			}
+			if !tt.ambiguous && (err != nil || !start.Equal(tt.expected)) {
+				t.Errorf("expected start: %v, got: %v, %v", tt.expected, start, err)
+			}
+		})
+	}
+}
+
+func TestDateExpressionParser_ParseSince(t *testing.T) {
+	now := time.Date(2024, 6, 20, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		duration string
+		expected time.Time
+		hasError bool
+	}{
+		{"Days", "7d", time.Date(2024, 6, 13, 15, 30, 0, 0, time.UTC), false},
+		{"Hours", "36h", time.Date(2024, 6, 19, 3, 30, 0, 0, time.UTC), false},
+		{"Weeks", "1w", time.Date(2024, 6, 13, 15, 30, 0, 0, time.UTC), false},
+		{"Calendar date", "2024-06-15", time.Time{}, true},
+		{"Keyword", "today", time.Time{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := filter.NewDateExpressionParser()
+			p.SetNow(now)
+
+			since, err := p.ParseSince(tt.duration)
+			if (err != nil) != tt.hasError {
+				t.Fatalf("expected error: %v, got: %v", tt.hasError, err)
+			}
+			if !tt.hasError && !since.Equal(tt.expected) {
+				t.Errorf("expected: %v, got: %v", tt.expected, since)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration string
+		expected time.Duration
+		hasError bool
+	}{
+		{"Days", "7d", 7 * 24 * time.Hour, false},
+		{"Negative weeks", "-2w", 14 * 24 * time.Hour, false},
+		{"Go duration", " 90m ", 90 * time.Minute, false},
+		{"Empty", "", 0, true},
+		{"Unknown unit", "3y", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			duration, err := filter.ParseDuration(tt.duration)
+			if (err != nil) != tt.hasError {
+				t.Fatalf("expected error: %v, got: %v", tt.hasError, err)
+			}
+			if duration != tt.expected {
+				t.Errorf("expected: %v, got: %v", tt.expected, duration)
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package filter
 
 import (
 	"news-aggregator/aggregator/model/article"
-	"news-aggregator/aggregator/parser"
 	"time"
 )
 
@@ -12,17 +11,21 @@ type EndDateFilter struct {
 	endDate *time.Time
 }
 
-// NewEndDateFilter creates a new EndDateFilter instance with the given end date.
+// NewEndDateFilter creates a new EndDateFilter instance with the given end date expression.
+// See DateExpressionParser for the supported expressions, calendar dates include the whole day.
 func NewEndDateFilter(endDateStr string) (*EndDateFilter, error) {
 
-	dateParser := parser.NewDateParser()
-
-	endDate, err := dateParser.ParseDefaultDateFormat(endDateStr)
+	endDate, err := NewDateExpressionParser().ParseEnd(endDateStr)
 	if err != nil {
 		return nil, err
 	}
 
-	return &EndDateFilter{endDate: &endDate}, nil
+	return NewEndDateFilterFromTime(endDate), nil
+}
+
+// NewEndDateFilterFromTime creates a new EndDateFilter instance with the given end time.
+func NewEndDateFilterFromTime(endDate time.Time) *EndDateFilter {
+	return &EndDateFilter{endDate: &endDate}
 }
 
 // Apply filters the article.Article's and returns a subset that meets predefined end date.
@@ -9,9 +9,9 @@ import (
 
 func TestEndDateFilter_Apply(t *testing.T) {
 	articles := []article.Article{
-		createArticleWithDate("2024-15-06"),
-		createArticleWithDate("2024-20-06"),
-		createArticleWithDate("2024-25-06"),
+		createArticleWithDate("2024-06-15"),
+		createArticleWithDate("2024-06-20"),
+		createArticleWithDate("2024-06-25"),
 	}
 
 	tests := []struct {
@@ -19,9 +19,10 @@ func TestEndDateFilter_Apply(t *testing.T) {
 		endDate  string
 		expected int
 	}{
-		{"Filter articles before end date", "2024-26-06", 3},
-		{"Filter articles before end date", "2024-21-06", 2},
-		{"Filter articles that are after end date", "2024-11-06", 0},
+		{"Filter articles before end date", "2024-06-26", 3},
+		{"Filter articles before end date", "2024-06-21", 2},
+		{"Filter articles that are after end date", "2024-06-11", 0},
+		{"Include articles of the end date", "2024-06-20", 2},
 	}
 
 	for _, test := range tests {
@@ -44,7 +45,7 @@ func TestEndDateFilter_Error(t *testing.T) {
 }
 
 func createArticleWithDate(dateStr string) article.Article {
-	date, _ := time.Parse("2006-02-01", dateStr)
+	date, _ := time.Parse("2006-01-02", dateStr)
 	builder := article.NewArticleBuilder().
 		SetTitle("Title").
 		SetDescription("Description").
@@ -1,20 +1,32 @@
 package filter
 
 import (
-	"github.com/reiver/go-porterstemmer"
+	"news-aggregator/aggregator/analysis"
 	"news-aggregator/aggregator/model/article"
-	"strings"
 )
 
 // KeywordFilter is an aggregator.Filter that creates a subset from a given set of article.Article's
 // corresponding to a given keywords set.
+// Keywords are compared with the words of the articles by their stems, see the analysis package,
+// and keywords consisting of stopwords only are ignored.
 type KeywordFilter struct {
-	keywords []string
+	keywords [][]string
+	analyzer *analysis.Analyzer
 }
 
 // NewKeywordFilter creates a new KeywordFilter instance with the given keywords.
 func NewKeywordFilter(keywords []string) *KeywordFilter {
-	return &KeywordFilter{keywords: keywords}
+	analyzer := analysis.Default()
+
+	var keywordTerms [][]string
+	for _, keyword := range keywords {
+		terms := analyzer.Terms(keyword)
+		if len(terms) > 0 {
+			keywordTerms = append(keywordTerms, terms)
+		}
+	}
+
+	return &KeywordFilter{keywords: keywordTerms, analyzer: analyzer}
 }
 
 // Apply filters the article.Article's and returns a subset of article.Article's containing the specified keywords.
@@ -31,19 +43,17 @@ func (f *KeywordFilter) Apply(articles []article.Article) []article.Article {
 	return filteredArticles
 }
 
-// matchKeywords checks if the given article contains any of the keywords.
+// matchKeywords checks if the title or the description of the given article contains any of the keywords.
 func (f *KeywordFilter) matchKeywords(a article.Article) bool {
 
 	if len(f.keywords) == 0 {
 		return true
 	}
 
-	title := strings.ToLower(string(a.Title()))
-	description := strings.ToLower(string(a.Description()))
+	title := f.analyzer.Terms(a.TitleStr())
+	description := f.analyzer.Terms(a.DescriptionStr())
 	for _, keyword := range f.keywords {
-		keyword = strings.ToLower(keyword)
-		stemmedKeyword := porterstemmer.StemString(keyword)
-		if strings.Contains(title, stemmedKeyword) || strings.Contains(description, stemmedKeyword) {
+		if analysis.ContainsSequence(title, keyword) || analysis.ContainsSequence(description, keyword) {
 			return true
 		}
 	}
@@ -12,6 +12,7 @@ func TestKeywordFilter_Apply(t *testing.T) {
 		createArticleWithKeywords("Title Ukraine", "Description Wonderful"),
 		createArticleWithKeywords("Title Southland", "Description Wonderful"),
 		createArticleWithKeywords("Title Kharkiv", "Description best city in Ukraine"),
+		createArticleWithKeywords("Award for software", "Elections were held in Kyiv, Ukraine's capital"),
 	}
 
 	tests := []struct {
@@ -22,15 +23,23 @@ func TestKeywordFilter_Apply(t *testing.T) {
 		{"Filter by Single Keyword",
 			[]string{"russia"}, 0}, // No articles should match
 		{"Filter by Title Keyword",
-			[]string{"ukraine"}, 2}, // Two articles should match
+			[]string{"ukraine"}, 3}, // Three articles should match
 		{"Filter by Description Keyword",
 			[]string{"wonderful"}, 2}, // Two articles should match
 		{"Filter by Description Keyword",
-			[]string{"description"}, 3}, // All articles should match
+			[]string{"description"}, 3}, // Three articles should match
 		{"Filter by Non-existent Keyword",
 			[]string{"best"}, 1}, // One articles should match
 		{"Filter by non provided keyword",
-			[]string{}, 3}, // All articles should match
+			[]string{}, 4}, // All articles should match
+		{"Filter does not match parts of words",
+			[]string{"war"}, 0}, // "Award" and "software" should not match
+		{"Filter by stemmed keyword",
+			[]string{"election"}, 1}, // "Elections" should match
+		{"Filter by phrase keyword",
+			[]string{"city in ukraine"}, 1}, // Stopwords are skipped inside the phrase
+		{"Filter ignores stopwords only keyword",
+			[]string{"the", "kyiv"}, 1}, // Only "kyiv" should be used
 	}
 
 	for _, test := range tests {
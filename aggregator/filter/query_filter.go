@@ -0,0 +1,42 @@
+package filter
+
+import (
+	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/query"
+)
+
+// QueryFilter is an aggregator.Filter that creates a subset from a given set of article.Article's
+// matching a boolean query, see the query package for the syntax.
+type QueryFilter struct {
+	query query.Node
+}
+
+// NewQueryFilter creates a new QueryFilter instance with the given query.
+func NewQueryFilter(queryStr string) (*QueryFilter, error) {
+
+	node, err := query.Parse(queryStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &QueryFilter{query: node}, nil
+}
+
+// Apply filters the article.Article's and returns a subset of article.Article's matching the query.
+func (f *QueryFilter) Apply(articles []article.Article) []article.Article {
+
+	var filteredArticles []article.Article
+
+	for _, selectedArticle := range articles {
+		if f.query.Match(selectedArticle) {
+			filteredArticles = append(filteredArticles, selectedArticle)
+		}
+	}
+
+	return filteredArticles
+}
+
+// Terms returns the terms the articles are searched for, skipping the negated ones.
+func (f *QueryFilter) Terms() []string {
+	return query.PositiveTerms(f.query)
+}
@@ -0,0 +1,52 @@
+package filter_test
+
+import (
+	"news-aggregator/aggregator/filter"
+	"news-aggregator/aggregator/model/article"
+	"testing"
+)
+
+func TestQueryFilter_Apply(t *testing.T) {
+	articles := []article.Article{
+		createArticleWithKeywords("Ukraine grain exports resume", "Ships leave Odesa with wheat"),
+		createArticleWithKeywords("Opinion: Ukraine needs more grain", "An opinion on exports"),
+		createArticleWithKeywords("Peace talks in Geneva", "Delegations from Ukraine meet"),
+		createArticleWithKeywords("Markets rally", "Stocks rise on peace talks hopes"),
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		expected int
+	}{
+		{"Single term", "ukraine", 3},
+		{"Implicit AND", "ukraine grain", 2},
+		{"Grouped OR", "ukraine AND (wheat OR geneva)", 2},
+		{"Excluded term", "ukraine -opinion", 2},
+		{"Title phrase", "title:\"peace talks\"", 1},
+		{"Phrase in any field", "\"peace talks\"", 2},
+		{"Combined", "ukraine AND (grain OR wheat) -opinion", 1},
+		{"No matches", "russia", 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			queryFilter, err := filter.NewQueryFilter(test.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			filteredArticles := queryFilter.Apply(articles)
+			if len(filteredArticles) != test.expected {
+				t.Errorf("Expected %d articles, got %d", test.expected, len(filteredArticles))
+			}
+		})
+	}
+}
+
+func TestQueryFilter_Error(t *testing.T) {
+	_, err := filter.NewQueryFilter("ukraine AND (grain")
+
+	if err == nil {
+		t.Errorf("Query filter should return an error for an invalid query")
+	}
+}
@@ -2,7 +2,6 @@ package filter
 
 import (
 	"news-aggregator/aggregator/model/article"
-	"news-aggregator/aggregator/parser"
 	"time"
 )
 
@@ -12,17 +11,21 @@ type StartDateFilter struct {
 	startDate *time.Time
 }
 
-// NewStartDateFilter creates a new StartDateFilter instance with the given start date.
+// NewStartDateFilter creates a new StartDateFilter instance with the given start date expression.
+// See DateExpressionParser for the supported expressions.
 func NewStartDateFilter(startDateStr string) (*StartDateFilter, error) {
 
-	dateParser := parser.NewDateParser()
-
-	startDate, err := dateParser.ParseDefaultDateFormat(startDateStr)
+	startDate, err := NewDateExpressionParser().ParseStart(startDateStr)
 	if err != nil {
 		return nil, err
 	}
 
-	return &StartDateFilter{startDate: &startDate}, nil
+	return NewStartDateFilterFromTime(startDate), nil
+}
+
+// NewStartDateFilterFromTime creates a new StartDateFilter instance with the given start time.
+func NewStartDateFilterFromTime(startDate time.Time) *StartDateFilter {
+	return &StartDateFilter{startDate: &startDate}
 }
 
 // Apply filters the article.Article's and returns a subset that meets predefined start date.
@@ -8,9 +8,9 @@ import (
 
 func TestStartDateFilter_Apply(t *testing.T) {
 	articles := []article.Article{
-		createArticleWithDate("2024-15-06"),
-		createArticleWithDate("2024-20-06"),
-		createArticleWithDate("2024-25-06"),
+		createArticleWithDate("2024-06-15"),
+		createArticleWithDate("2024-06-20"),
+		createArticleWithDate("2024-06-25"),
 	}
 
 	tests := []struct {
@@ -18,9 +18,10 @@ func TestStartDateFilter_Apply(t *testing.T) {
 		endDate  string
 		expected int
 	}{
-		{"Filter articles after start date", "2024-10-06", 3},
-		{"Filter a few articles after start date", "2024-16-06", 2},
-		{"Filter articles that are before start date", "2024-27-06", 0},
+		{"Filter articles after start date", "2024-06-10", 3},
+		{"Filter a few articles after start date", "2024-06-16", 2},
+		{"Filter articles that are before start date", "2024-06-27", 0},
+		{"Include articles of the start date", "2024-06-20", 2},
 	}
 
 	for _, test := range tests {
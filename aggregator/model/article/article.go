@@ -3,18 +3,34 @@ package article
 import (
 	"errors"
 	"news-aggregator/aggregator/model/resource"
+	"strings"
 	"time"
 )
 
 // The Article is a structured piece of writing about a particular subject.
-// It consists of a title, description, creation date, source, and optionally author and link.
+// It consists of a title, description, creation date, source, and optionally author, link, categories, tags,
+// image, update date, language and content. An article carried by several sources also lists all of them.
+// Every article has an ID derived from its content, see NewID.
 type Article struct {
+	id           ID
 	title        Title
 	description  Description
 	creationDate CreationDate
 	source       resource.Source
+	sources      []resource.Source
 	author       Author
 	link         Link
+	categories   []string
+	tags         []string
+	image        Link
+	updateDate   UpdateDate
+	language     Language
+	content      Content
+}
+
+// ID returns the stable identifier of the article.
+func (a *Article) ID() ID {
+	return a.id
 }
 
 func (a *Article) Title() Title {
@@ -41,6 +57,31 @@ func (a *Article) Source() resource.Source {
 	return a.source
 }
 
+// Sources returns all sources that carried the article, starting with its own source.
+func (a *Article) Sources() []resource.Source {
+	if len(a.sources) == 0 {
+		return []resource.Source{a.source}
+	}
+	return a.sources
+}
+
+// WithSources returns a copy of the article carried by its own source and the given ones.
+// The sources are listed once, in the order of their first appearance.
+func (a Article) WithSources(sources []resource.Source) Article {
+	all := []resource.Source{a.source}
+	seen := map[resource.Source]struct{}{a.source: {}}
+
+	for _, source := range sources {
+		if _, exists := seen[source]; !exists {
+			seen[source] = struct{}{}
+			all = append(all, source)
+		}
+	}
+
+	a.sources = all
+	return a
+}
+
 func (a *Article) Author() Author {
 	return a.author
 }
@@ -49,6 +90,34 @@ func (a *Article) Link() Link {
 	return a.link
 }
 
+// Categories returns the sections or categories the source filed the article under.
+func (a *Article) Categories() []string {
+	return a.categories
+}
+
+// Tags returns the keywords the source tagged the article with.
+func (a *Article) Tags() []string {
+	return a.tags
+}
+
+// Image returns the URL of the image illustrating the article.
+func (a *Article) Image() Link {
+	return a.image
+}
+
+// Updated returns the time the article was last updated, zero if it was never updated or the source does not tell.
+func (a *Article) Updated() UpdateDate {
+	return a.updateDate
+}
+
+func (a *Article) Language() Language {
+	return a.language
+}
+
+func (a *Article) Content() Content {
+	return a.content
+}
+
 type Builder struct {
 	article *Article
 }
@@ -87,6 +156,38 @@ func (b *Builder) SetLink(link Link) *Builder {
 	return b
 }
 
+// SetCategories sets the categories of the article. Blank and repeated categories are dropped.
+func (b *Builder) SetCategories(categories []string) *Builder {
+	b.article.categories = cleanLabels(categories)
+	return b
+}
+
+// SetTags sets the tags of the article. Blank and repeated tags are dropped.
+func (b *Builder) SetTags(tags []string) *Builder {
+	b.article.tags = cleanLabels(tags)
+	return b
+}
+
+func (b *Builder) SetImage(image Link) *Builder {
+	b.article.image = image
+	return b
+}
+
+func (b *Builder) SetUpdated(updateDate UpdateDate) *Builder {
+	b.article.updateDate = updateDate
+	return b
+}
+
+func (b *Builder) SetLanguage(language Language) *Builder {
+	b.article.language = language
+	return b
+}
+
+func (b *Builder) SetContent(content Content) *Builder {
+	b.article.content = content
+	return b
+}
+
 // Build validates the article and returns the final Article instance.
 // Checks all required fields are set. If not, returns an error.
 // If all fields are set, return the Article instance.
@@ -105,5 +206,25 @@ func (b *Builder) Build() (*Article, error) {
 		return nil, errors.New("source cannot be empty")
 	}
 
+	b.article.id = NewID(b.article.link, b.article.source, b.article.title, b.article.creationDate)
+
 	return b.article, nil
 }
+
+// cleanLabels returns the trimmed labels without the blank ones and the repetitions, which are case-insensitive.
+func cleanLabels(labels []string) []string {
+	var cleaned []string
+	seen := make(map[string]struct{})
+
+	for _, label := range labels {
+		label = strings.TrimSpace(label)
+		key := strings.ToLower(label)
+		if _, exists := seen[key]; exists || label == "" {
+			continue
+		}
+		seen[key] = struct{}{}
+		cleaned = append(cleaned, label)
+	}
+
+	return cleaned
+}
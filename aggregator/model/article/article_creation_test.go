@@ -107,3 +107,94 @@ func TestEmptySource(t *testing.T) {
 		t.Errorf("Expected error: 'source cannot be empty', Got: %v", err)
 	}
 }
+
+func TestArticleWithSources(t *testing.T) {
+	art, err := article.NewArticleBuilder().
+		SetTitle("Test Title").
+		SetDescription("Test Description").
+		SetDate(article.CreationDate(time.Now())).
+		SetSource("abc-news").
+		Build()
+	if err != nil {
+		t.Fatalf("Error occurred while creating art: %v", err)
+	}
+
+	if sources := art.Sources(); len(sources) != 1 || sources[0] != "abc-news" {
+		t.Errorf("Expected sources: [abc-news], Got: %v", sources)
+	}
+
+	collapsed := art.WithSources([]resource.Source{"bbc-world", "abc-news", "bbc-world", "washington-times"})
+
+	expected := []resource.Source{"abc-news", "bbc-world", "washington-times"}
+	sources := collapsed.Sources()
+	if len(sources) != len(expected) {
+		t.Fatalf("Expected sources: %v, Got: %v", expected, sources)
+	}
+	for i := range expected {
+		if sources[i] != expected[i] {
+			t.Errorf("Expected sources: %v, Got: %v", expected, sources)
+		}
+	}
+	if len(art.Sources()) != 1 {
+		t.Errorf("WithSources should not modify the original article, Got: %v", art.Sources())
+	}
+}
+
+func TestArticleOptionalFields(t *testing.T) {
+	updated := article.UpdateDate(time.Date(2024, 5, 19, 10, 0, 0, 0, time.UTC))
+
+	art, err := article.NewArticleBuilder().
+		SetTitle("Test Title").
+		SetDescription("Test Description").
+		SetDate(article.CreationDate(time.Date(2024, 5, 18, 10, 0, 0, 0, time.UTC))).
+		SetSource("abc-news").
+		SetCategories([]string{" World ", "", "world", "Politics"}).
+		SetTags([]string{"election", " "}).
+		SetImage("https://testlink.com/image.jpg").
+		SetUpdated(updated).
+		SetLanguage("en-US").
+		SetContent("Test Content").
+		Build()
+	if err != nil {
+		t.Fatalf("Error occurred while creating art: %v", err)
+	}
+
+	if categories := art.Categories(); len(categories) != 2 || categories[0] != "World" || categories[1] != "Politics" {
+		t.Errorf("Expected categories: [World Politics], Got: %v", categories)
+	}
+	if tags := art.Tags(); len(tags) != 1 || tags[0] != "election" {
+		t.Errorf("Expected tags: [election], Got: %v", tags)
+	}
+	if art.Image() != "https://testlink.com/image.jpg" {
+		t.Errorf("Expected image: https://testlink.com/image.jpg, Got: %s", art.Image())
+	}
+	if art.Updated() != updated || art.Updated().HumanReadableString() != "19 May 24 10:00 UTC" {
+		t.Errorf("Expected updated: %v, Got: %v", updated, art.Updated())
+	}
+	if art.Language() != "en-US" {
+		t.Errorf("Expected language: en-US, Got: %s", art.Language())
+	}
+	if art.Content() != "Test Content" {
+		t.Errorf("Expected content: Test Content, Got: %s", art.Content())
+	}
+}
+
+func TestArticleWithoutOptionalFields(t *testing.T) {
+	art, err := article.NewArticleBuilder().
+		SetTitle("Test Title").
+		SetDescription("Test Description").
+		SetDate(article.CreationDate(time.Now())).
+		SetSource("abc-news").
+		Build()
+	if err != nil {
+		t.Fatalf("Error occurred while creating art: %v", err)
+	}
+
+	if art.Categories() != nil || art.Tags() != nil || art.Image() != "" || !art.Updated().IsZero() ||
+		art.Language() != "" || art.Content() != "" {
+		t.Errorf("Expected optional fields to be empty, Got: %+v", art)
+	}
+	if art.ID() == "" {
+		t.Errorf("Expected the ID to be set")
+	}
+}
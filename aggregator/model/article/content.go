@@ -0,0 +1,4 @@
+package article
+
+// Content is the full text of the Article, while the Description is a summary of it.
+type Content string
@@ -0,0 +1,35 @@
+package article
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"news-aggregator/aggregator/model/resource"
+	"strings"
+	"time"
+)
+
+// ID is a stable identifier of an Article derived from its content,
+// so that the same article gets the same ID every time it is parsed.
+type ID string
+
+// idLength is the number of bytes of the hash kept in an ID.
+const idLength = 16
+
+// NewID returns the ID of the article with the given link, or with the given source, title and date
+// if the link is empty. Links are compared in the canonical form, so that an article keeps its ID
+// when it is linked with tracking parameters or carried by another source.
+func NewID(link Link, source resource.Source, title Title, date CreationDate) ID {
+	var key string
+	if canonical := link.Canonical(); canonical != "" {
+		key = "link:" + canonical
+	} else {
+		key = strings.Join([]string{
+			"text:" + string(source),
+			strings.TrimSpace(string(title)),
+			time.Time(date).UTC().Format(time.RFC3339),
+		}, "\n")
+	}
+
+	hash := sha256.Sum256([]byte(key))
+	return ID(hex.EncodeToString(hash[:idLength]))
+}
@@ -0,0 +1,64 @@
+package article_test
+
+import (
+	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/model/resource"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewID(t *testing.T) {
+	date := article.CreationDate(time.Date(2024, 5, 19, 10, 0, 0, 0, time.UTC))
+	otherDate := article.CreationDate(time.Date(2024, 5, 19, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60)))
+	id := article.NewID("https://www.bbc.com/news/123?utm_source=rss", "bbc-world", "Title", date)
+
+	tests := []struct {
+		name     string
+		link     article.Link
+		source   resource.Source
+		title    article.Title
+		date     article.CreationDate
+		sameAsID bool
+	}{
+		{"Same canonical link", "http://bbc.com/news/123/", "bbc-world", "Title", date, true},
+		{"Link identifies the article carried by another source", "https://bbc.com/news/123", "abc-news",
+			"Other Title", date, true},
+		{"Other link", "https://bbc.com/news/124", "bbc-world", "Title", date, false},
+		{"Source, title and date without a link", "", "bbc-world", "Title", date, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.sameAsID, article.NewID(tt.link, tt.source, tt.title, tt.date) == id)
+		})
+	}
+
+	t.Run("Without a link", func(t *testing.T) {
+		textID := article.NewID("", "bbc-world", "Title", date)
+
+		assert.Len(t, string(textID), 32)
+		assert.Equal(t, textID, article.NewID("", "bbc-world", " Title ", otherDate))
+		assert.NotEqual(t, textID, article.NewID("", "abc-news", "Title", date))
+		assert.NotEqual(t, textID, article.NewID("", "bbc-world", "Other Title", date))
+		assert.NotEqual(t, textID, article.NewID("", "bbc-world", "Title", article.CreationDate(time.Time(date).Add(time.Minute))))
+	})
+}
+
+func TestArticleID(t *testing.T) {
+	build := func(link article.Link) *article.Article {
+		art, err := article.NewArticleBuilder().
+			SetTitle("Title").
+			SetDescription("Description").
+			SetDate(article.CreationDate(time.Date(2024, 5, 19, 10, 0, 0, 0, time.UTC))).
+			SetSource("bbc-world").
+			SetLink(link).
+			Build()
+		assert.NoError(t, err)
+		return art
+	}
+
+	assert.Equal(t, build("https://bbc.com/news/123").ID(), build("https://www.bbc.com/news/123#comments").ID())
+	assert.NotEqual(t, build("https://bbc.com/news/123").ID(), build("").ID())
+}
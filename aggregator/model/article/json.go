@@ -0,0 +1,86 @@
+package article
+
+import (
+	"encoding/json"
+	"fmt"
+	"news-aggregator/aggregator/model/resource"
+	"time"
+)
+
+// articleJSON is the JSON form of an Article, used to store the parsed articles.
+type articleJSON struct {
+	ID          ID                `json:"id"`
+	Title       Title             `json:"title"`
+	Description Description       `json:"description"`
+	Date        time.Time         `json:"date"`
+	Source      resource.Source   `json:"source"`
+	Sources     []resource.Source `json:"sources,omitempty"`
+	Author      Author            `json:"author,omitempty"`
+	Link        Link              `json:"link,omitempty"`
+	Categories  []string          `json:"categories,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Image       Link              `json:"image,omitempty"`
+	Updated     *time.Time        `json:"updated,omitempty"`
+	Language    Language          `json:"language,omitempty"`
+	Content     Content           `json:"content,omitempty"`
+}
+
+// MarshalJSON encodes all fields of the article, so that it can be restored by UnmarshalJSON.
+func (a Article) MarshalJSON() ([]byte, error) {
+	value := articleJSON{
+		ID:          a.id,
+		Title:       a.title,
+		Description: a.description,
+		Date:        time.Time(a.creationDate),
+		Source:      a.source,
+		Sources:     a.sources,
+		Author:      a.author,
+		Link:        a.link,
+		Categories:  a.categories,
+		Tags:        a.tags,
+		Image:       a.image,
+		Language:    a.language,
+		Content:     a.content,
+	}
+	if !a.updateDate.IsZero() {
+		updated := time.Time(a.updateDate)
+		value.Updated = &updated
+	}
+
+	return json.Marshal(value)
+}
+
+// UnmarshalJSON decodes an article encoded by MarshalJSON. The article is validated the same way as by the Builder.
+func (a *Article) UnmarshalJSON(data []byte) error {
+	var value articleJSON
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	builder := NewArticleBuilder().
+		SetTitle(value.Title).
+		SetDescription(value.Description).
+		SetDate(CreationDate(value.Date)).
+		SetSource(value.Source).
+		SetAuthor(value.Author).
+		SetLink(value.Link).
+		SetCategories(value.Categories).
+		SetTags(value.Tags).
+		SetImage(value.Image).
+		SetLanguage(value.Language).
+		SetContent(value.Content)
+	if value.Updated != nil {
+		builder.SetUpdated(UpdateDate(*value.Updated))
+	}
+
+	art, err := builder.Build()
+	if err != nil {
+		return fmt.Errorf("invalid article: %v", err)
+	}
+
+	if len(value.Sources) > 0 {
+		*art = art.WithSources(value.Sources)
+	}
+	*a = *art
+	return nil
+}
@@ -0,0 +1,65 @@
+package article_test
+
+import (
+	"encoding/json"
+	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/model/resource"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestArticleJSON(t *testing.T) {
+	art, err := article.NewArticleBuilder().
+		SetTitle("Test Title").
+		SetDescription("Test Description").
+		SetDate(article.CreationDate(time.Date(2024, 5, 18, 10, 0, 0, 0, time.UTC))).
+		SetSource("abc-news").
+		SetAuthor("Test Author").
+		SetLink("https://testlink.com").
+		SetCategories([]string{"World"}).
+		SetTags([]string{"election", "vote"}).
+		SetImage("https://testlink.com/image.jpg").
+		SetUpdated(article.UpdateDate(time.Date(2024, 5, 19, 10, 0, 0, 0, time.UTC))).
+		SetLanguage("en").
+		SetContent("Test Content").
+		Build()
+	if err != nil {
+		t.Fatalf("Error occurred while creating art: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		article article.Article
+	}{
+		{name: "all fields", article: *art},
+		{name: "several sources", article: art.WithSources([]resource.Source{"bbc-world"})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.article)
+			if err != nil {
+				t.Fatalf("Error occurred while encoding the article: %v", err)
+			}
+
+			var decoded article.Article
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("Error occurred while decoding the article: %v", err)
+			}
+
+			if !reflect.DeepEqual(decoded, tt.article) {
+				t.Errorf("Expected article: %+v, Got: %+v", tt.article, decoded)
+			}
+		})
+	}
+}
+
+func TestArticleJSON_Invalid(t *testing.T) {
+	var decoded article.Article
+
+	err := json.Unmarshal([]byte(`{"title":"Test Title","date":"2024-05-18T10:00:00Z","source":"abc-news"}`), &decoded)
+	if err == nil || err.Error() != "invalid article: description cannot be empty" {
+		t.Errorf("Expected error: 'invalid article: description cannot be empty', Got: %v", err)
+	}
+}
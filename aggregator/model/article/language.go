@@ -0,0 +1,4 @@
+package article
+
+// Language is the language the Article is written in, as a tag like "en" or "en-US".
+type Language string
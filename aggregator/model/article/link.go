@@ -1,4 +1,58 @@
 package article
 
+import (
+	"net/url"
+	"strings"
+)
+
 // Link is a URL to the article.
 type Link string
+
+// trackingParameters are the query parameters that do not change the article a link points to.
+var trackingParameters = map[string]struct{}{
+	"fbclid":      {},
+	"gclid":       {},
+	"mc_cid":      {},
+	"mc_eid":      {},
+	"ref":         {},
+	"cmpid":       {},
+	"ftag":        {},
+	"at_medium":   {},
+	"at_campaign": {},
+}
+
+// Canonical returns the canonical form of the link, the same for all links pointing to the same article.
+// The scheme, the "www." prefix, the fragment, the trailing slash, default ports and tracking parameters
+// are dropped, and the query parameters are sorted. It returns an empty string if the link is empty
+// or cannot be parsed.
+func (l Link) Canonical() string {
+	link := strings.TrimSpace(string(l))
+	if link == "" {
+		return ""
+	}
+
+	parsed, err := url.Parse(link)
+	if err != nil || parsed.Host == "" {
+		return ""
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	if port := parsed.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+
+	query := parsed.Query()
+	for parameter := range query {
+		if _, tracking := trackingParameters[strings.ToLower(parameter)]; tracking ||
+			strings.HasPrefix(strings.ToLower(parameter), "utm_") {
+			query.Del(parameter)
+		}
+	}
+
+	canonical := host + strings.TrimSuffix(parsed.EscapedPath(), "/")
+	if encoded := query.Encode(); encoded != "" {
+		canonical += "?" + encoded
+	}
+
+	return canonical
+}
@@ -0,0 +1,16 @@
+package article
+
+import "time"
+
+// UpdateDate is the time the Article was last updated, after it was created.
+type UpdateDate time.Time
+
+// IsZero reports whether the update date is not set.
+func (ud UpdateDate) IsZero() bool {
+	return time.Time(ud).IsZero()
+}
+
+// HumanReadableString converts UpdateDate to a human-readable string in the same format as CreationDate.
+func (ud UpdateDate) HumanReadableString() string {
+	return CreationDate(ud).HumanReadableString()
+}
@@ -13,6 +13,11 @@ const (
 	RSS
 	HTML
 	JSON
+	ATOM
+	JSONFEED
+	// STORED is the format of the articles already parsed and kept by a storage.
+	// It is not a format of a feed, so it is not accepted by ParseFormat.
+	STORED
 )
 
 // FormatToString converts a Format to a string.
@@ -24,6 +29,12 @@ func FormatToString(f Format) string {
 		return "HTML"
 	case JSON:
 		return "JSON"
+	case ATOM:
+		return "ATOM"
+	case JSONFEED:
+		return "JSONFEED"
+	case STORED:
+		return "STORED"
 	default:
 		return "UNKNOWN"
 	}
@@ -38,6 +49,10 @@ func ParseFormat(formatStr string) (Format, error) {
 		return HTML, nil
 	case "JSON":
 		return JSON, nil
+	case "ATOM":
+		return ATOM, nil
+	case "JSONFEED":
+		return JSONFEED, nil
 	default:
 		return UNKNOWN, fmt.Errorf("unknown format: %s", formatStr)
 	}
@@ -14,6 +14,9 @@ func TestFormatToString(t *testing.T) {
 		{RSS, "RSS"},
 		{HTML, "HTML"},
 		{JSON, "JSON"},
+		{ATOM, "ATOM"},
+		{JSONFEED, "JSONFEED"},
+		{STORED, "STORED"},
 		{UNKNOWN, "UNKNOWN"},
 		{Format(999), "UNKNOWN"}, // Test with an invalid format
 	}
@@ -39,6 +42,11 @@ func TestParseFormat(t *testing.T) {
 		{"html", HTML, nil},
 		{"JSON", JSON, nil},
 		{"json", JSON, nil},
+		{"ATOM", ATOM, nil},
+		{"atom", ATOM, nil},
+		{"JSONFEED", JSONFEED, nil},
+		{"jsonfeed", JSONFEED, nil},
+		{"STORED", UNKNOWN, fmt.Errorf("unknown format: STORED")},
 		{"UNKNOWN", UNKNOWN, fmt.Errorf("unknown format: UNKNOWN")},
 		{"invalid", UNKNOWN, fmt.Errorf("unknown format: invalid")},
 	}
@@ -2,16 +2,23 @@ package resource
 
 import (
 	"errors"
+	"time"
 )
 
 // Resource a structured data supply containing a news report content with information about its parameters required
 // for future processing.
 type Resource struct {
-	source  Source
-	format  Format
-	content Content
+	source    Source
+	format    Format
+	content   Content
+	fetchedAt time.Time
+	snapshot  string
+	load      Loader
 }
 
+// Loader reads the content of a resource kept in a storage.
+type Loader func() (Content, error)
+
 // New is a constructor function for creating a new Resource.
 func New(source Source, format Format, content Content) (*Resource, error) {
 
@@ -34,6 +41,30 @@ func New(source Source, format Format, content Content) (*Resource, error) {
 	}, nil
 }
 
+// NewSnapshot creates a Resource of a stored snapshot whose content is read by the loader only when it is needed,
+// see Load. The snapshot identifies the stored content, e.g. by its name, modification time and size.
+func NewSnapshot(source Source, format Format, snapshot string, load Loader) (*Resource, error) {
+
+	if source == "" {
+		return nil, errors.New("source cannot be empty")
+	}
+
+	if format == 0 {
+		return nil, errors.New("format cannot be unknown")
+	}
+
+	if snapshot == "" || load == nil {
+		return nil, errors.New("snapshot cannot be empty")
+	}
+
+	return &Resource{
+		source:   source,
+		format:   format,
+		snapshot: snapshot,
+		load:     load,
+	}, nil
+}
+
 // Source returns the source of the resource.
 func (r *Resource) Source() Source {
 	return r.source
@@ -44,7 +75,41 @@ func (r *Resource) Format() Format {
 	return r.format
 }
 
-// Content returns the content of the resource.
+// Content returns the content of the resource, empty for a snapshot that is not loaded yet.
 func (r *Resource) Content() Content {
 	return r.content
 }
+
+// Snapshot returns the identity of the stored snapshot of the resource, empty if it is unknown.
+func (r *Resource) Snapshot() string {
+	return r.snapshot
+}
+
+// Load reads the content of a resource created by NewSnapshot, unless it is read already.
+func (r *Resource) Load() error {
+	if r.content != "" || r.load == nil {
+		return nil
+	}
+
+	content, err := r.load()
+	if err != nil {
+		return err
+	}
+	if content == "" {
+		return errors.New("content cannot be empty")
+	}
+
+	r.content = content
+	return nil
+}
+
+// FetchedAt returns the time the content of the resource was fetched at, zero if it is unknown.
+func (r *Resource) FetchedAt() time.Time {
+	return r.fetchedAt
+}
+
+// SetFetchedAt sets the time the content of the resource was fetched at.
+// The year of the dates without one is inferred relative to it.
+func (r *Resource) SetFetchedAt(fetchedAt time.Time) {
+	r.fetchedAt = fetchedAt
+}
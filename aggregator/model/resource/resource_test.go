@@ -83,3 +83,41 @@ func TestResourceMethods(t *testing.T) {
 		t.Errorf("Expected content: %s, but got: %s", expectedContent, content)
 	}
 }
+
+func TestResourceLoad(t *testing.T) {
+	loads := 0
+	r, err := resource.NewSnapshot("BBC", resource.RSS, "bbc_20240101120000.xml", func() (resource.Content, error) {
+		loads++
+		return "Some news content", nil
+	})
+	if err != nil {
+		t.Fatalf("Error creating r: %v", err)
+	}
+
+	if content := r.Content(); content != "" {
+		t.Errorf("Expected no content before loading, but got: %s", content)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := r.Load(); err != nil {
+			t.Fatalf("Error loading r: %v", err)
+		}
+	}
+
+	if content := r.Content(); content != "Some news content" {
+		t.Errorf("Expected content: Some news content, but got: %s", content)
+	}
+	if loads != 1 {
+		t.Errorf("Expected the content to be read once, but it was read %d times", loads)
+	}
+
+	failing, err := resource.NewSnapshot("BBC", resource.RSS, "bbc_20240101120000.xml", func() (resource.Content, error) {
+		return "", errors.New("file is removed")
+	})
+	if err != nil {
+		t.Fatalf("Error creating r: %v", err)
+	}
+	if err := failing.Load(); err == nil {
+		t.Error("Expected an error loading a removed snapshot, but got nil")
+	}
+}
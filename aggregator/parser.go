@@ -3,6 +3,7 @@ package aggregator
 import (
 	"news-aggregator/aggregator/model/article"
 	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/aggregator/parser"
 )
 
 // Parser is a component that takes input resource.Resource and converts it into a structured and unified
@@ -10,3 +11,10 @@ import (
 type Parser interface {
 	Parse(content resource.Resource) ([]article.Article, error)
 }
+
+// TolerantParser is a Parser that is able to skip the items of a resource.Resource that can not be turned
+// into an article.Article, reporting them as warnings instead of failing the whole resource.
+type TolerantParser interface {
+	Parser
+	ParseTolerant(content resource.Resource) ([]article.Article, []parser.ItemWarning, error)
+}
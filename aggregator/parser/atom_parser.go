@@ -0,0 +1,200 @@
+package parser
+
+import (
+	"encoding/xml"
+	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/model/resource"
+	"strings"
+	"time"
+)
+
+// AtomParser is the aggregator.Parser for parsing Atom 1.0 feeds.
+type AtomParser struct {
+	dateParsing
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+}
+
+type atomPerson struct {
+	Name  string `xml:"name"`
+	Email string `xml:"email"`
+}
+
+type atomCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr"`
+}
+
+type atomEntry struct {
+	ID          string           `xml:"id"`
+	Title       string           `xml:"title"`
+	Summary     string           `xml:"summary"`
+	Content     string           `xml:"content"`
+	Updated     string           `xml:"updated"`
+	Published   string           `xml:"published"`
+	Authors     []atomPerson     `xml:"author"`
+	Links       []atomLink       `xml:"link"`
+	Categories  []atomCategory   `xml:"category"`
+	MediaThumbs []mediaThumbnail `xml:"thumbnail"`
+	Lang        string           `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	Lang    string      `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+// Parse parses the Atom feed from the provided content and returns a list of articles.
+func (p *AtomParser) Parse(resource resource.Resource) ([]article.Article, error) {
+	byteContent := []byte(string(resource.Content()))
+
+	feed, err := p.unmarshalAtom(byteContent)
+	if err != nil {
+		return nil, err
+	}
+
+	articles, err := p.extractArticles(feed, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
+
+func (p *AtomParser) unmarshalAtom(content []byte) (*atomFeed, error) {
+	var feed atomFeed
+	err := xml.Unmarshal(content, &feed)
+	if err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+func (p *AtomParser) extractArticles(feed *atomFeed, resource resource.Resource) ([]article.Article, error) {
+	var articles []article.Article
+
+	for _, entry := range feed.Entries {
+		if entry.Lang == "" {
+			entry.Lang = feed.Lang
+		}
+		art, err := p.parseArticle(entry, resource)
+		if err != nil {
+			return nil, err
+		}
+		articles = append(articles, art)
+	}
+
+	return articles, nil
+}
+
+func (p *AtomParser) parseArticle(entry atomEntry, resource resource.Resource) (article.Article, error) {
+	creationDate, err := p.dates().ParseAt(p.entryDate(entry), resource.FetchedAt())
+	if err != nil {
+		return article.Article{}, err
+	}
+
+	builder := article.NewArticleBuilder().
+		SetTitle(article.Title(strings.TrimSpace(entry.Title))).
+		SetDescription(article.Description(p.entryDescription(entry))).
+		SetDate(article.CreationDate(creationDate)).
+		SetSource(resource.Source()).
+		SetAuthor(article.Author(p.entryAuthor(entry))).
+		SetLink(article.Link(p.entryLink(entry))).
+		SetCategories(p.entryCategories(entry)).
+		SetImage(article.Link(p.entryImage(entry))).
+		SetUpdated(article.UpdateDate(p.entryUpdated(entry, resource.FetchedAt()))).
+		SetLanguage(article.Language(strings.TrimSpace(entry.Lang))).
+		SetContent(article.Content(strings.TrimSpace(entry.Content)))
+
+	newArticle, err := builder.Build()
+	if err != nil {
+		return article.Article{}, err
+	}
+
+	return *newArticle, nil
+}
+
+// entryDate returns the publication date of the entry, falling back to the last update date
+// as the published element is optional in Atom.
+func (p *AtomParser) entryDate(entry atomEntry) string {
+	if published := strings.TrimSpace(entry.Published); published != "" {
+		return published
+	}
+	return strings.TrimSpace(entry.Updated)
+}
+
+// entryDescription returns the summary of the entry, falling back to its content.
+func (p *AtomParser) entryDescription(entry atomEntry) string {
+	if summary := strings.TrimSpace(entry.Summary); summary != "" {
+		return summary
+	}
+	return strings.TrimSpace(entry.Content)
+}
+
+func (p *AtomParser) entryAuthor(entry atomEntry) string {
+	var names []string
+	for _, author := range entry.Authors {
+		if name := strings.TrimSpace(author.Name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// entryLink returns the alternate link of the entry.
+// According to the Atom specification a link without the rel attribute is an alternate one.
+func (p *AtomParser) entryLink(entry atomEntry) string {
+	for _, link := range entry.Links {
+		if link.Rel == "" || link.Rel == "alternate" {
+			return strings.TrimSpace(link.Href)
+		}
+	}
+	return ""
+}
+
+// entryUpdated returns the last update date of the entry, or the zero time if the update date is used
+// as the publication date or cannot be parsed.
+func (p *AtomParser) entryUpdated(entry atomEntry, fetchedAt time.Time) time.Time {
+	if strings.TrimSpace(entry.Published) == "" {
+		return time.Time{}
+	}
+
+	updated, err := p.dates().ParseAt(strings.TrimSpace(entry.Updated), fetchedAt)
+	if err != nil {
+		return time.Time{}
+	}
+	return updated
+}
+
+// entryCategories returns the labels of the categories of the entry, falling back to their terms.
+func (p *AtomParser) entryCategories(entry atomEntry) []string {
+	var categories []string
+	for _, category := range entry.Categories {
+		if label := strings.TrimSpace(category.Label); label != "" {
+			categories = append(categories, label)
+		} else {
+			categories = append(categories, category.Term)
+		}
+	}
+	return categories
+}
+
+// entryImage returns the image enclosure of the entry, falling back to its media thumbnail.
+func (p *AtomParser) entryImage(entry atomEntry) string {
+	for _, link := range entry.Links {
+		if link.Rel == "enclosure" && strings.HasPrefix(link.Type, "image/") {
+			return strings.TrimSpace(link.Href)
+		}
+	}
+	for _, thumb := range entry.MediaThumbs {
+		if url := strings.TrimSpace(thumb.URL); url != "" {
+			return url
+		}
+	}
+	return ""
+}
@@ -0,0 +1,110 @@
+package parser
+
+import (
+	"github.com/stretchr/testify/assert"
+	"news-aggregator/aggregator/model/resource"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAtomParser_Parse(t *testing.T) {
+	path := filepath.Join("testdata/atom", "test.xml")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	mockResource, err := resource.New("Test Source", resource.ATOM, resource.Content(content))
+	if err != nil {
+		t.Fatalf("Failed to create resource: %v", err)
+	}
+
+	parser := &AtomParser{}
+
+	articles, err := parser.Parse(*mockResource)
+	assert.NoError(t, err, "Parser should not return an error")
+	assert.Equal(t, 2, len(articles), "Parser should return all entries")
+
+	assert.Equal(t, "Test Title", string(articles[0].Title()), "Article title mismatch")
+	assert.Equal(t, "Test Description", string(articles[0].Description()), "Article description mismatch")
+	assert.Equal(t, "John Doe", string(articles[0].Author()), "Article author mismatch")
+	assert.Equal(t, "http://example.com", string(articles[0].Link()), "Article link mismatch")
+	assert.Equal(t, "Test Source", string(articles[0].Source()), "Article source mismatch")
+	assert.True(t, time.Date(2024, 6, 1, 18, 30, 2, 0, time.UTC).Equal(time.Time(articles[0].Date())),
+		"Article date should be taken from the published element")
+
+	assert.Equal(t, "Second Content", string(articles[1].Description()), "Content should be used without summary")
+	assert.Equal(t, "http://example.com/second", string(articles[1].Link()), "Link without rel is alternate")
+	assert.True(t, time.Date(2024, 6, 3, 8, 0, 0, 0, time.UTC).Equal(time.Time(articles[1].Date())),
+		"Article date should fall back to the updated element")
+
+	assert.Equal(t, []string{"World", "politics"}, articles[0].Categories(), "Labels should be preferred to terms")
+	assert.Equal(t, "http://example.com/image.jpg", string(articles[0].Image()), "Image enclosure mismatch")
+	assert.True(t, time.Date(2024, 6, 2, 18, 30, 2, 0, time.UTC).Equal(time.Time(articles[0].Updated())),
+		"Article update date mismatch")
+	assert.Equal(t, "en", string(articles[0].Language()), "Language should be taken from the feed")
+	assert.Equal(t, "Test Content", string(articles[0].Content()), "Article content mismatch")
+
+	assert.Equal(t, "http://example.com/second.jpg", string(articles[1].Image()), "Thumbnail should be the image")
+	assert.True(t, articles[1].Updated().IsZero(), "Updated element used as the date is not an update date")
+	assert.Equal(t, "de", string(articles[1].Language()), "Entry language should override the feed one")
+}
+
+func TestAtomParser_Parse_InvalidFormat(t *testing.T) {
+	path := filepath.Join("testdata/atom", "invalid_format_test.xml")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	mockResource, err := resource.New("Test Source", resource.ATOM, resource.Content(content))
+	if err != nil {
+		t.Fatalf("Failed to create resource: %v", err)
+	}
+
+	parser := &AtomParser{}
+
+	_, err = parser.Parse(*mockResource)
+
+	assert.Errorf(t, err, "Parser should return an error when the content is not an Atom feed")
+}
+
+func TestAtomParser_Parse_InvalidArticles(t *testing.T) {
+	path := filepath.Join("testdata/atom", "invalid_data_test.xml")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	mockResource, err := resource.New("Test Source", resource.ATOM, resource.Content(content))
+	if err != nil {
+		t.Fatalf("Failed to create resource: %v", err)
+	}
+
+	parser := &AtomParser{}
+
+	_, err = parser.Parse(*mockResource)
+
+	assert.Errorf(t, err, "Parser should return an error when entries are invalid")
+}
+
+func TestAtomParser_Parse_CorruptedDate(t *testing.T) {
+	path := filepath.Join("testdata/atom", "corrupted_date_test.xml")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	mockResource, err := resource.New("Test Source", resource.ATOM, resource.Content(content))
+	if err != nil {
+		t.Fatalf("Failed to create resource: %v", err)
+	}
+
+	parser := &AtomParser{}
+
+	_, err = parser.Parse(*mockResource)
+
+	assert.Errorf(t, err, "Parser should return an error when entry date format is invalid or unknown")
+}
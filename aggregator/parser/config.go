@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"fmt"
+	"news-aggregator/aggregator/model/resource"
+	"time"
+)
+
+// Config describes the structure of a source that has no dedicated parser.
+// At most one of JSONMapping and HTMLSelectors is set, according to the format of the source.
+// Timezone is an IANA name of the location the source dates without a timezone are interpreted in.
+type Config struct {
+	JSONMapping   *JSONMapping   `json:"jsonMapping,omitempty"`
+	HTMLSelectors *HTMLSelectors `json:"htmlSelectors,omitempty"`
+	Timezone      string         `json:"timezone,omitempty"`
+}
+
+// IsEmpty reports whether the config describes nothing.
+func (c Config) IsEmpty() bool {
+	return c.JSONMapping == nil && c.HTMLSelectors == nil && c.Timezone == ""
+}
+
+// Validate checks that the config is complete and suits the given format.
+func (c Config) Validate(format resource.Format) error {
+	if c.JSONMapping != nil {
+		if format != resource.JSON {
+			return fmt.Errorf("json mapping is supported only for the JSON format")
+		}
+		if err := c.JSONMapping.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.HTMLSelectors != nil {
+		if format != resource.HTML {
+			return fmt.Errorf("html selectors are supported only for the HTML format")
+		}
+		if err := c.HTMLSelectors.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.Timezone != "" {
+		if format == resource.HTML && c.HTMLSelectors == nil {
+			return fmt.Errorf("timezone of an HTML source requires html selectors")
+		}
+		if _, err := time.LoadLocation(c.Timezone); err != nil {
+			return fmt.Errorf("invalid timezone: %v", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"news-aggregator/aggregator/model/resource"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      Config
+		format      resource.Format
+		expectError bool
+	}{
+		{"empty config", Config{}, resource.RSS, false},
+		{"json mapping", Config{JSONMapping: &testJSONMapping}, resource.JSON, false},
+		{"html selectors", Config{HTMLSelectors: &testHTMLSelectors}, resource.HTML, false},
+		{"json mapping for html", Config{JSONMapping: &testJSONMapping}, resource.HTML, true},
+		{"html selectors for json", Config{HTMLSelectors: &testHTMLSelectors}, resource.JSON, true},
+		{"incomplete json mapping", Config{JSONMapping: &JSONMapping{Title: "title"}}, resource.JSON, true},
+		{"incomplete html selectors", Config{HTMLSelectors: &HTMLSelectors{}}, resource.HTML, true},
+		{"timezone", Config{Timezone: "America/New_York"}, resource.RSS, false},
+		{"timezone with html selectors", Config{HTMLSelectors: &testHTMLSelectors, Timezone: "UTC"}, resource.HTML, false},
+		{"timezone without html selectors", Config{Timezone: "UTC"}, resource.HTML, true},
+		{"unknown timezone", Config{Timezone: "Mars/Olympus_Mons"}, resource.RSS, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate(tt.format)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
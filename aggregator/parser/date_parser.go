@@ -2,36 +2,125 @@ package parser
 
 import (
 	"errors"
+	"maps"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
+// yearInferenceTolerance is how far in the future relative to the reference time a date without a year may be
+// before it is considered to belong to the previous year. It covers timezone differences and clock skew.
+const yearInferenceTolerance = 48 * time.Hour
+
+// sharedTimezones is the default abbreviation table shared by all DateParser's until one of them is modified.
+var sharedTimezones = sync.OnceValue(defaultTimezones)
+
 // DateParser is a parser for date strings.
+// The registered layouts are tried one by one. Timezone abbreviations found in the date are resolved with the
+// abbreviation table, dates without a timezone are interpreted in the default location, and dates without a year
+// get the year that places them closest to the reference time without being in its future.
 type DateParser struct {
 	supportedFormats  []string
+	timezones         map[string]*time.Location
+	ownTimezones      bool
+	location          *time.Location
+	referenceTime     time.Time
 	defaultDateFormat string
 }
 
 // NewDateParser creates a new DateParser instance with predefined date formats.
+// By default dates without a timezone are interpreted in UTC and the year is inferred relative to the current time.
 func NewDateParser() *DateParser {
 	return &DateParser{
 		supportedFormats: []string{
-			time.RFC1123Z,
 			time.RFC3339,
-			"3:04 p.m. ET January 2",
+			"2006-01-02T15:04:05Z0700",
+			"2006-01-02T15:04:05",
+			"2006-01-02T15:04",
+			"2006-01-02 15:04:05Z07:00",
+			"2006-01-02 15:04:05",
+			"2006-01-02",
+			"Mon, 2 Jan 2006 15:04:05 -0700",
+			"Mon, 2 Jan 2006 15:04 -0700",
+			"Mon, 2 Jan 2006 15:04:05",
+			"Mon, 2 Jan 2006 15:04",
+			"2 Jan 2006 15:04:05 -0700",
+			"2 Jan 2006 15:04 -0700",
+			"2 Jan 2006 15:04:05",
+			"2 Jan 2006 15:04",
+			"Mon, 2 Jan 06 15:04:05 -0700",
+			"Mon, 2 Jan 06 15:04 -0700",
+			"2 Jan 06 15:04:05 -0700",
+			"2 Jan 06 15:04 -0700",
+			"2 Jan 06 15:04",
+			"Monday, January 2, 2006 3:04 PM",
+			"January 2, 2006 3:04 PM",
 			"January 2, 2006",
-			"Mon, 02 Jan 2006 15:04:05 GMT",
+			"Jan 2, 2006",
+			"3:04 PM January 2",
+			"3:04 PM Jan 2",
 		},
+		timezones:         sharedTimezones(),
+		location:          time.UTC,
 		defaultDateFormat: "2006-02-01",
 	}
 }
 
+// AddLayout registers an additional layout, which is tried after the predefined ones.
+// The layout should not contain a timezone abbreviation, as abbreviations are resolved before the layouts are tried.
+func (p *DateParser) AddLayout(layout string) {
+	p.supportedFormats = append(p.supportedFormats, layout)
+}
+
+// AddTimezone registers a timezone abbreviation or overrides the location of a predefined one.
+func (p *DateParser) AddTimezone(abbreviation string, location *time.Location) {
+	if !p.ownTimezones {
+		p.timezones = maps.Clone(p.timezones)
+		p.ownTimezones = true
+	}
+	p.timezones[abbreviation] = location
+}
+
+// SetLocation sets the location used for dates without a timezone. Nil values are ignored.
+func (p *DateParser) SetLocation(location *time.Location) {
+	if location != nil {
+		p.location = location
+	}
+}
+
+// Location returns the location used for dates without a timezone.
+func (p *DateParser) Location() *time.Location {
+	return p.location
+}
+
+// SetReferenceTime sets the time relative to which the year of dates without one is inferred,
+// usually the time the resource was fetched at. The zero value stands for the current time.
+func (p *DateParser) SetReferenceTime(referenceTime time.Time) {
+	p.referenceTime = referenceTime
+}
+
 // Parse parses the given string into a time.Time value using predefined supported date formats.
+// Unix timestamps in seconds or milliseconds are supported as well.
 func (p *DateParser) Parse(dateStr string) (time.Time, error) {
+	return p.ParseAt(dateStr, time.Time{})
+}
+
+// ParseAt parses the date the same way as Parse, inferring the year of a date without one relative to the
+// reference time, usually the time the resource was fetched at. The zero value stands for the reference time
+// set with SetReferenceTime. Unlike SetReferenceTime, it is safe to use by parsers shared between resources.
+func (p *DateParser) ParseAt(dateStr string, referenceTime time.Time) (time.Time, error) {
+	value, location := p.extractTimezone(dateStr)
+
+	if timestamp, ok := p.parseTimestamp(value); ok {
+		return timestamp, nil
+	}
+
 	for _, layout := range p.supportedFormats {
-		creationDate, err := time.Parse(layout, dateStr)
+		creationDate, err := time.ParseInLocation(layout, value, location)
 		if err == nil {
 			if creationDate.Year() == 0 {
-				creationDate = creationDate.AddDate(time.Now().Year(), 0, 0)
+				creationDate = p.inferYear(creationDate, referenceTime)
 			}
 			return creationDate, nil
 		}
@@ -44,3 +133,85 @@ func (p *DateParser) Parse(dateStr string) (time.Time, error) {
 func (p *DateParser) ParseDefaultDateFormat(dateStr string) (time.Time, error) {
 	return time.Parse(p.defaultDateFormat, dateStr)
 }
+
+// extractTimezone removes a known timezone abbreviation from the date and returns the location it stands for,
+// or the default location if there is none. It also normalizes the spaces and the "a.m."/"p.m." markers.
+func (p *DateParser) extractTimezone(dateStr string) (string, *time.Location) {
+	location := p.location
+	var tokens []string
+
+	for _, token := range strings.Fields(dateStr) {
+		switch strings.ToLower(token) {
+		case "a.m.", "am":
+			tokens = append(tokens, "AM")
+			continue
+		case "p.m.", "pm":
+			tokens = append(tokens, "PM")
+			continue
+		}
+
+		if zone, exists := p.timezones[strings.Trim(token, "()")]; exists {
+			location = zone
+			continue
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return strings.Join(tokens, " "), location
+}
+
+// parseTimestamp parses Unix timestamps in seconds (10 digits) or milliseconds (13 digits).
+func (p *DateParser) parseTimestamp(value string) (time.Time, bool) {
+	if len(value) != 10 && len(value) != 13 {
+		return time.Time{}, false
+	}
+
+	timestamp, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || timestamp < 0 {
+		return time.Time{}, false
+	}
+
+	if len(value) == 13 {
+		return time.UnixMilli(timestamp).UTC(), true
+	}
+	return time.Unix(timestamp, 0).UTC(), true
+}
+
+// inferYear sets the year of the reference time to the date, or the previous one
+// if the date would otherwise be in the future, e.g. "December 31" parsed on the 1st of January.
+// A zero reference time stands for the one set with SetReferenceTime, or the current time.
+func (p *DateParser) inferYear(date time.Time, reference time.Time) time.Time {
+	if reference.IsZero() {
+		reference = p.referenceTime
+	}
+	if reference.IsZero() {
+		reference = time.Now()
+	}
+
+	year := reference.Year()
+	candidate := date.AddDate(year, 0, 0)
+	if candidate.After(reference.Add(yearInferenceTolerance)) {
+		candidate = date.AddDate(year-1, 0, 0)
+	}
+
+	return candidate
+}
+
+// dateParsing is embedded into the parsers to let them use a configured DateParser,
+// falling back to the default one for zero-value parsers.
+type dateParsing struct {
+	dateParser *DateParser
+}
+
+// SetDateParser sets the DateParser used to parse the dates of the articles.
+func (d *dateParsing) SetDateParser(dateParser *DateParser) {
+	d.dateParser = dateParser
+}
+
+func (d *dateParsing) dates() *DateParser {
+	if d.dateParser == nil {
+		return NewDateParser()
+	}
+	return d.dateParser
+}
@@ -1,7 +1,11 @@
 package parser_test
 
 import (
+	"news-aggregator/aggregator"
+	"news-aggregator/aggregator/model/resource"
 	"news-aggregator/aggregator/parser"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -17,6 +21,11 @@ func TestNewDateParser(t *testing.T) {
 
 func TestDateParser_Parse(t *testing.T) {
 	dp := parser.NewDateParser()
+	dp.SetReferenceTime(time.Date(2024, 5, 19, 12, 0, 0, 0, time.UTC))
+
+	est := time.FixedZone("EST", -5*60*60)
+	edt := time.FixedZone("EDT", -4*60*60)
+	pdt := time.FixedZone("PDT", -7*60*60)
 
 	tests := []struct {
 		name     string
@@ -30,13 +39,50 @@ func TestDateParser_Parse(t *testing.T) {
 		{"Valid RFC3339 format", "2020-05-28T14:15:22Z",
 			time.Date(2020, 5, 28, 14, 15, 22, 0, time.UTC),
 			false},
+		{"Valid RFC3339 format with fractional seconds", "2020-05-28T14:15:22.123+02:00",
+			time.Date(2020, 5, 28, 12, 15, 22, 123000000, time.UTC),
+			false},
 		{"Valid GMT format", "Mon, 02 Jan 2006 15:04:05 GMT",
 			time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC),
 			false},
+		{"RFC1123 with EST abbreviation", "Fri, 17 May 2024 11:59:25 EST",
+			time.Date(2024, 5, 17, 11, 59, 25, 0, est),
+			false},
+		{"RFC1123 with PDT abbreviation", "Fri, 17 May 2024 11:59:25 PDT",
+			time.Date(2024, 5, 17, 11, 59, 25, 0, pdt),
+			false},
+		{"RFC1123 with single digit day", "Fri, 3 May 2024 11:59:25 EDT",
+			time.Date(2024, 5, 3, 11, 59, 25, 0, edt),
+			false},
+		{"RFC822 without seconds", "Fri, 17 May 24 11:59 -0400",
+			time.Date(2024, 5, 17, 11, 59, 0, 0, edt),
+			false},
+		{"RFC1123 without seconds and weekday", "17 May 2024 11:59 GMT",
+			time.Date(2024, 5, 17, 11, 59, 0, 0, time.UTC),
+			false},
+		{"ISO date time without zone", "2024-05-17T11:59:25",
+			time.Date(2024, 5, 17, 11, 59, 25, 0, time.UTC),
+			false},
+		{"ISO date", "2024-05-17",
+			time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC),
+			false},
+		{"Unix timestamp in seconds", "1715961565",
+			time.Date(2024, 5, 17, 15, 59, 25, 0, time.UTC),
+			false},
+		{"Unix timestamp in milliseconds", "1715961565000",
+			time.Date(2024, 5, 17, 15, 59, 25, 0, time.UTC),
+			false},
+		{"Long month name", "May 15, 2024",
+			time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC),
+			false},
 		{"Valid custom format without a year", "3:04 p.m. ET January 2",
-			time.Date(time.Now().Year(), 1, 2, 3, 4, 0, 0, time.UTC),
+			time.Date(2024, 1, 2, 20, 4, 0, 0, time.UTC),
+			false},
+		{"Date without a year in the future belongs to the previous year", "8:22 p.m. ET December 31",
+			time.Date(2024, 1, 1, 1, 22, 0, 0, time.UTC),
 			false},
 		{"Invalid date format", "invalid date", time.Time{}, true},
+		{"Empty date", "", time.Time{}, true},
 	}
 
 	for _, tt := range tests {
@@ -52,6 +98,182 @@ func TestDateParser_Parse(t *testing.T) {
 	}
 }
 
+func TestDateParser_Configuration(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("failed to load location: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		configure func(dp *parser.DateParser)
+		input     string
+		expected  time.Time
+	}{
+		{"Default location for dates without zone",
+			func(dp *parser.DateParser) { dp.SetLocation(berlin) },
+			"2024-05-17 11:59:25",
+			time.Date(2024, 5, 17, 9, 59, 25, 0, time.UTC)},
+		{"Abbreviation overrides default location",
+			func(dp *parser.DateParser) { dp.SetLocation(berlin) },
+			"Fri, 17 May 2024 11:59:25 GMT",
+			time.Date(2024, 5, 17, 11, 59, 25, 0, time.UTC)},
+		{"Custom layout",
+			func(dp *parser.DateParser) { dp.AddLayout("02.01.2006 15:04") },
+			"17.05.2024 11:59",
+			time.Date(2024, 5, 17, 11, 59, 0, 0, time.UTC)},
+		{"Custom timezone abbreviation",
+			func(dp *parser.DateParser) { dp.AddTimezone("MESZ", berlin) },
+			"Fri, 17 May 2024 11:59:25 MESZ",
+			time.Date(2024, 5, 17, 9, 59, 25, 0, time.UTC)},
+		{"Year inferred relative to reference time",
+			func(dp *parser.DateParser) { dp.SetReferenceTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) },
+			"11:00 p.m. ET December 31",
+			time.Date(2025, 1, 1, 4, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dp := parser.NewDateParser()
+			tt.configure(dp)
+
+			parsed, err := dp.Parse(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !parsed.Equal(tt.expected) {
+				t.Fatalf("expected: %v, got: %v", tt.expected, parsed)
+			}
+		})
+	}
+
+	t.Run("Custom timezone does not affect other parsers", func(t *testing.T) {
+		dp := parser.NewDateParser()
+		dp.AddTimezone("MESZ", berlin)
+
+		_, err := parser.NewDateParser().Parse("Fri, 17 May 2024 11:59:25 MESZ")
+		if err == nil {
+			t.Fatal("expected error for unknown abbreviation")
+		}
+	})
+}
+
+func TestDateParser_Parse_Resources(t *testing.T) {
+	fetchedAt := time.Date(2024, 5, 19, 23, 59, 59, 0, time.UTC)
+	earliest := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	usaTodayParser, err := parser.NewHTMLSelectorsParser(parser.USATodaySelectors)
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+
+	tests := []struct {
+		file   string
+		format resource.Format
+		parser interface {
+			aggregator.Parser
+			SetDateParser(dateParser *parser.DateParser)
+		}
+	}{
+		{"abc-news_20240518.xml", resource.RSS, &parser.RSSParser{}},
+		{"bbc-world_20240519.xml", resource.RSS, &parser.RSSParser{}},
+		{"washington-times_20240518.xml", resource.RSS, &parser.RSSParser{}},
+		{"nbc-news_20240519.json", resource.JSON, &parser.JSONParser{}},
+		{"usa-today_20240519.html", resource.HTML, usaTodayParser},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			content, err := os.ReadFile(filepath.Join("../../resources", tt.file))
+			if err != nil {
+				t.Fatalf("failed to read resource: %v", err)
+			}
+
+			res, err := resource.New("sample", tt.format, resource.Content(content))
+			if err != nil {
+				t.Fatalf("failed to create resource: %v", err)
+			}
+
+			dp := parser.NewDateParser()
+			dp.SetReferenceTime(fetchedAt)
+			tt.parser.SetDateParser(dp)
+
+			articles, err := tt.parser.Parse(*res)
+			if err != nil {
+				t.Fatalf("failed to parse resource: %v", err)
+			}
+			if len(articles) == 0 {
+				t.Fatal("expected articles to be parsed")
+			}
+
+			for _, art := range articles {
+				date := time.Time(art.Date())
+				if date.Before(earliest) || date.After(fetchedAt) {
+					t.Errorf("date %v of \"%s\" is out of the expected range", date, art.Title())
+				}
+			}
+		})
+	}
+}
+
+func TestDateParser_ParseAt(t *testing.T) {
+	// A snapshot fetched at the end of December is parsed in January.
+	fetchedAt := time.Date(2023, 12, 31, 22, 0, 0, 0, time.UTC)
+	dp := parser.NewDateParser()
+	dp.SetReferenceTime(time.Date(2024, 1, 5, 12, 0, 0, 0, time.UTC))
+
+	date, err := dp.ParseAt("9:00 AM January 3", fetchedAt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := time.Date(2023, 1, 3, 9, 0, 0, 0, time.UTC); !date.Equal(expected) {
+		t.Errorf("expected %v relative to the fetch time, got %v", expected, date)
+	}
+
+	date, err = dp.ParseAt("9:00 AM January 3", time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := time.Date(2024, 1, 3, 9, 0, 0, 0, time.UTC); !date.Equal(expected) {
+		t.Errorf("expected %v relative to the reference time, got %v", expected, date)
+	}
+}
+
+func TestParsers_FetchedAt(t *testing.T) {
+	// The parsers are shared between resources, so the year is inferred relative to the fetch time of each of them.
+	content := `<html><body><main class="gnt_cw"><div class="gnt_m_flm">` +
+		`<a class="gnt_m_flm_a" href="/story/news/1/" data-c-br="First story">First story ` +
+		`<div class="gnt_m_flm_sbt" data-c-dt="9:00 AM January 3"></div></a></div></main></body></html>`
+
+	usaTodayParser, err := parser.NewHTMLSelectorsParser(parser.USATodaySelectors)
+	if err != nil {
+		t.Fatalf("failed to create parser: %v", err)
+	}
+
+	for fetchedAt, expectedYear := range map[time.Time]int{
+		time.Date(2023, 12, 31, 22, 0, 0, 0, time.UTC): 2023,
+		time.Date(2024, 1, 4, 8, 0, 0, 0, time.UTC):    2024,
+	} {
+		res, err := resource.New("usa-today", resource.HTML, resource.Content(content))
+		if err != nil {
+			t.Fatalf("failed to create resource: %v", err)
+		}
+		res.SetFetchedAt(fetchedAt)
+
+		articles, err := usaTodayParser.Parse(*res)
+		if err != nil {
+			t.Fatalf("failed to parse resource: %v", err)
+		}
+		if len(articles) != 1 {
+			t.Fatalf("expected 1 article, got %d", len(articles))
+		}
+
+		if year := time.Time(articles[0].Date()).Year(); year != expectedYear {
+			t.Errorf("expected year %d for the fetch time %v, got %d", expectedYear, fetchedAt, year)
+		}
+	}
+}
+
 func TestDateParser_ParseDefaultDateFormat(t *testing.T) {
 	dp := parser.NewDateParser()
 
@@ -0,0 +1,15 @@
+package parser
+
+import "strings"
+
+// splitList splits a comma-separated list of categories or tags, e.g. "world, politics".
+// Blank items are dropped.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
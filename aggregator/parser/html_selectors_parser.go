@@ -0,0 +1,236 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"github.com/PuerkitoBio/goquery"
+	"net/url"
+	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/model/resource"
+	"strings"
+	"time"
+)
+
+// HTMLField describes how a single article field is extracted from an item of an HTML page.
+// Selector is a CSS selector relative to the item, an empty selector points to the item itself.
+// Attribute is the name of the attribute holding the value, an empty attribute means the element text.
+type HTMLField struct {
+	Selector  string `json:"selector,omitempty"`
+	Attribute string `json:"attribute,omitempty"`
+}
+
+// HTMLSelectors describes where the articles are located on an HTML page.
+// Item is a CSS selector matching every article on the page, the fields are extracted relatively to it.
+// Categories and Tags are taken from every element matching their selector, and are split by commas.
+// Language defaults to the lang attribute of the page.
+// DateLayout is an optional Go time layout of the date and of the update date, by default the DateParser is used.
+// BaseURL is an optional URL used to resolve relative links and images.
+type HTMLSelectors struct {
+	Item        string     `json:"item"`
+	Title       HTMLField  `json:"title"`
+	Description HTMLField  `json:"description"`
+	Date        HTMLField  `json:"date"`
+	Author      *HTMLField `json:"author,omitempty"`
+	Link        *HTMLField `json:"link,omitempty"`
+	Categories  *HTMLField `json:"categories,omitempty"`
+	Tags        *HTMLField `json:"tags,omitempty"`
+	Image       *HTMLField `json:"image,omitempty"`
+	Updated     *HTMLField `json:"updated,omitempty"`
+	Language    *HTMLField `json:"language,omitempty"`
+	Content     *HTMLField `json:"content,omitempty"`
+	DateLayout  string     `json:"dateLayout,omitempty"`
+	BaseURL     string     `json:"baseURL,omitempty"`
+}
+
+// USATodaySelectors are the HTMLSelectors for the world news page of USA Today.
+var USATodaySelectors = HTMLSelectors{
+	Item:        "main.gnt_cw div.gnt_m_flm a.gnt_m_flm_a",
+	Description: HTMLField{Attribute: "data-c-br"},
+	Date:        HTMLField{Selector: "div.gnt_m_flm_sbt", Attribute: "data-c-dt"},
+	Link:        &HTMLField{Attribute: "href"},
+	Categories:  &HTMLField{Selector: "div.gnt_m_flm_sbt", Attribute: "data-c-ms"},
+	Image:       &HTMLField{Selector: "img.gnt_m_flm_i", Attribute: "data-gl-src"},
+	BaseURL:     "https://usatoday.com",
+}
+
+// Validate checks that the selectors are sufficient to find the articles on a page.
+func (s HTMLSelectors) Validate() error {
+	if strings.TrimSpace(s.Item) == "" {
+		return errors.New("html selectors: item selector is required")
+	}
+	if s.BaseURL != "" {
+		if _, err := url.Parse(s.BaseURL); err != nil {
+			return fmt.Errorf("html selectors: invalid base URL: %v", err)
+		}
+	}
+	return nil
+}
+
+// HTMLSelectorsParser is an aggregator.Parser for HTML pages described by HTMLSelectors.
+type HTMLSelectorsParser struct {
+	dateParsing
+	selectors HTMLSelectors
+}
+
+// NewHTMLSelectorsParser creates a new HTMLSelectorsParser for the given selectors.
+func NewHTMLSelectorsParser(selectors HTMLSelectors) (*HTMLSelectorsParser, error) {
+	if err := selectors.Validate(); err != nil {
+		return nil, err
+	}
+	return &HTMLSelectorsParser{selectors: selectors}, nil
+}
+
+// Parse extracts articles from the provided HTML resource.
+// Items that can not be turned into an article are skipped.
+func (p *HTMLSelectorsParser) Parse(resource resource.Resource) ([]article.Article, error) {
+	content := string(resource.Content())
+	doc, err := p.createDocumentFromContent(content)
+	if err != nil {
+		return nil, err
+	}
+
+	articles := p.extractArticles(doc, resource)
+
+	if len(articles) == 0 {
+		return nil, errors.New("no articles found")
+	}
+
+	return articles, nil
+}
+
+func (p *HTMLSelectorsParser) createDocumentFromContent(content string) (*goquery.Document, error) {
+	reader := strings.NewReader(content)
+	return goquery.NewDocumentFromReader(reader)
+}
+
+func (p *HTMLSelectorsParser) extractArticles(doc *goquery.Document, resource resource.Resource) []article.Article {
+	var articles []article.Article
+	language := strings.TrimSpace(doc.Find("html").AttrOr("lang", ""))
+
+	doc.Find(p.selectors.Item).Each(func(i int, s *goquery.Selection) {
+		art, err := p.parseArticle(s, language, resource)
+		if err == nil {
+			articles = append(articles, art)
+		}
+	})
+
+	return articles
+}
+
+func (p *HTMLSelectorsParser) parseArticle(s *goquery.Selection, pageLanguage string,
+	resource resource.Resource) (article.Article, error) {
+	creationDate, err := p.parseDate(p.field(s, &p.selectors.Date), resource.FetchedAt())
+	if err != nil {
+		return article.Article{}, err
+	}
+
+	link, err := p.resolveLink(p.field(s, p.selectors.Link))
+	if err != nil {
+		return article.Article{}, err
+	}
+
+	builder := article.NewArticleBuilder().
+		SetTitle(article.Title(p.field(s, &p.selectors.Title))).
+		SetDescription(article.Description(p.field(s, &p.selectors.Description))).
+		SetDate(article.CreationDate(creationDate)).
+		SetSource(resource.Source()).
+		SetAuthor(article.Author(p.field(s, p.selectors.Author))).
+		SetLink(article.Link(link)).
+		SetCategories(p.list(s, p.selectors.Categories)).
+		SetTags(p.list(s, p.selectors.Tags)).
+		SetContent(article.Content(p.field(s, p.selectors.Content)))
+
+	if image, err := p.resolveLink(p.field(s, p.selectors.Image)); err == nil {
+		builder.SetImage(article.Link(image))
+	}
+
+	if updated := p.field(s, p.selectors.Updated); updated != "" {
+		if updateDate, err := p.parseDate(updated, resource.FetchedAt()); err == nil {
+			builder.SetUpdated(article.UpdateDate(updateDate))
+		}
+	}
+
+	if language := p.field(s, p.selectors.Language); language != "" {
+		builder.SetLanguage(article.Language(language))
+	} else {
+		builder.SetLanguage(article.Language(pageLanguage))
+	}
+
+	newArticle, err := builder.Build()
+	if err != nil {
+		return article.Article{}, err
+	}
+
+	return *newArticle, nil
+}
+
+// field returns the value of the field within the item, or an empty string if the field is not configured.
+func (p *HTMLSelectorsParser) field(s *goquery.Selection, field *HTMLField) string {
+	if field == nil {
+		return ""
+	}
+
+	target := s
+	if field.Selector != "" {
+		target = s.Find(field.Selector).First()
+	}
+
+	if field.Attribute == "" {
+		return strings.TrimSpace(target.Text())
+	}
+
+	attrValue, _ := target.Attr(field.Attribute)
+	return strings.TrimSpace(attrValue)
+}
+
+// list returns the values of all elements of the item matching the field, split by commas,
+// or nil if the field is not configured.
+func (p *HTMLSelectorsParser) list(s *goquery.Selection, field *HTMLField) []string {
+	if field == nil {
+		return nil
+	}
+
+	targets := s
+	if field.Selector != "" {
+		targets = s.Find(field.Selector)
+	}
+
+	var items []string
+	targets.Each(func(i int, target *goquery.Selection) {
+		value := target.Text()
+		if field.Attribute != "" {
+			value = target.AttrOr(field.Attribute, "")
+		}
+		items = append(items, splitList(value)...)
+	})
+	return items
+}
+
+// parseDate parses the date with the date layout of the selectors, if any, or with the DateParser otherwise,
+// inferring the year of a date without one relative to the time the page was fetched at.
+func (p *HTMLSelectorsParser) parseDate(dateStr string, fetchedAt time.Time) (time.Time, error) {
+	dates := p.dates()
+	if p.selectors.DateLayout == "" {
+		return dates.ParseAt(dateStr, fetchedAt)
+	}
+	return time.ParseInLocation(p.selectors.DateLayout, dateStr, dates.Location())
+}
+
+// resolveLink resolves the link against the base URL, absolute links are returned as is.
+func (p *HTMLSelectorsParser) resolveLink(link string) (string, error) {
+	if link == "" || p.selectors.BaseURL == "" {
+		return link, nil
+	}
+
+	base, err := url.Parse(p.selectors.BaseURL)
+	if err != nil {
+		return "", err
+	}
+
+	ref, err := url.Parse(link)
+	if err != nil {
+		return "", err
+	}
+
+	return base.ResolveReference(ref).String(), nil
+}
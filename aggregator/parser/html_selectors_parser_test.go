@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"news-aggregator/aggregator/model/resource"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testHTMLSelectors = HTMLSelectors{
+	Item:        "section.news article.story",
+	Title:       HTMLField{Selector: "h2.headline"},
+	Description: HTMLField{Selector: "p.summary"},
+	Date:        HTMLField{Selector: "time", Attribute: "datetime"},
+	Author:      &HTMLField{Selector: "span.byline"},
+	Link:        &HTMLField{Selector: "h2.headline a", Attribute: "href"},
+	Tags:        &HTMLField{Selector: "ul.tags li"},
+	Image:       &HTMLField{Selector: "img", Attribute: "src"},
+	DateLayout:  "02.01.2006 15:04",
+	BaseURL:     "https://example.com",
+}
+
+func TestNewHTMLSelectorsParser(t *testing.T) {
+	tests := []struct {
+		name        string
+		selectors   HTMLSelectors
+		expectError bool
+	}{
+		{"complete selectors", testHTMLSelectors, false},
+		{"usa today selectors", USATodaySelectors, false},
+		{"missing item selector", HTMLSelectors{Title: HTMLField{Selector: "h2"}}, true},
+		{"invalid base url", HTMLSelectors{Item: "article", BaseURL: "://example.com"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser, err := NewHTMLSelectorsParser(tt.selectors)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, parser)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, parser)
+			}
+		})
+	}
+}
+
+func TestHTMLSelectorsParser_Parse(t *testing.T) {
+	path := filepath.Join("testdata/html", "generic_test.html")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	mockResource, err := resource.New("Test Source", resource.HTML, resource.Content(content))
+	if err != nil {
+		t.Fatalf("Failed to create resource: %v", err)
+	}
+
+	parser, err := NewHTMLSelectorsParser(testHTMLSelectors)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	articles, err := parser.Parse(*mockResource)
+	assert.NoError(t, err, "Parser should not return an error")
+	assert.Equal(t, 2, len(articles), "Items with an invalid date should be skipped")
+
+	assert.Equal(t, "Test title", string(articles[0].Title()), "Article title mismatch")
+	assert.Equal(t, "Test description", string(articles[0].Description()), "Article description mismatch")
+	assert.Equal(t, "John Doe", string(articles[0].Author()), "Article author mismatch")
+	assert.Equal(t, "https://example.com/news/first", string(articles[0].Link()), "Relative link should be resolved")
+	assert.Equal(t, "Test Source", string(articles[0].Source()), "Article source mismatch")
+	assert.True(t, time.Date(2024, 6, 1, 18, 30, 0, 0, time.UTC).Equal(time.Time(articles[0].Date())),
+		"Article date should be parsed with the configured layout")
+
+	assert.Equal(t, "", string(articles[1].Author()), "Missing author should result in an empty field")
+	assert.Equal(t, "https://example.org/second", string(articles[1].Link()), "Absolute link should be kept")
+
+	assert.Equal(t, []string{"election", "vote"}, articles[0].Tags(), "Every matching element should be a tag")
+	assert.Equal(t, "https://example.com/images/first.jpg", string(articles[0].Image()), "Image should be resolved")
+	assert.Equal(t, "en", string(articles[0].Language()), "Language should be taken from the page")
+	assert.Nil(t, articles[1].Tags(), "Missing tags should result in no tags")
+	assert.Equal(t, "", string(articles[1].Image()), "Missing image should result in an empty field")
+}
+
+func TestHTMLSelectorsParser_Parse_USAToday(t *testing.T) {
+	path := filepath.Join("testdata/html", "usa_today_test.html")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	mockResource, err := resource.New("Test Source", resource.HTML, resource.Content(content))
+	if err != nil {
+		t.Fatalf("Failed to create resource: %v", err)
+	}
+
+	parser, err := NewHTMLSelectorsParser(USATodaySelectors)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	articles, err := parser.Parse(*mockResource)
+	assert.NoError(t, err, "Parser should not return an error")
+	assert.NotEmpty(t, articles, "Parsed articles should not be empty")
+
+	assert.Equal(t, "Test title", string(articles[0].Title()), "Article title mismatch")
+	assert.Equal(t, "Test description", string(articles[0].Description()), "Article description mismatch")
+	assert.Equal(t, "https://usatoday.com/article_url", string(articles[0].Link()), "Article link mismatch")
+	assert.Equal(t, "Test Source", string(articles[0].Source()), "Article source mismatch")
+	assert.Equal(t, []string{"WORLD"}, articles[0].Categories(), "Section should be the category")
+	assert.Equal(t, "https://usatoday.com/image_url", string(articles[0].Image()), "Article image mismatch")
+}
+
+func TestHTMLSelectorsParser_Parse_USAToday_CorruptedDate(t *testing.T) {
+	path := filepath.Join("testdata/html", "usa_today_corrupted_date_test.html")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	mockResource, err := resource.New("Test Source", resource.HTML, resource.Content(content))
+	if err != nil {
+		t.Fatalf("Failed to create resource: %v", err)
+	}
+
+	parser, err := NewHTMLSelectorsParser(USATodaySelectors)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	_, err = parser.Parse(*mockResource)
+	assert.Errorf(t, err, "Parser should return an error when the date is invalid")
+}
@@ -0,0 +1,183 @@
+package parser
+
+import (
+	"encoding/json"
+	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/model/resource"
+	"strings"
+	"time"
+)
+
+// JSONFeedParser is an aggregator.Parser for feeds in the JSON Feed format (https://jsonfeed.org).
+// It supports both 1.0 and 1.1 versions of the specification.
+type JSONFeedParser struct {
+	dateParsing
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+type jsonFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	ExternalURL   string           `json:"external_url"`
+	Title         string           `json:"title"`
+	ContentHTML   string           `json:"content_html"`
+	ContentText   string           `json:"content_text"`
+	Summary       string           `json:"summary"`
+	DatePublished string           `json:"date_published"`
+	DateModified  string           `json:"date_modified"`
+	Author        *jsonFeedAuthor  `json:"author"`
+	Authors       []jsonFeedAuthor `json:"authors"`
+	Tags          []string         `json:"tags"`
+	Image         string           `json:"image"`
+	BannerImage   string           `json:"banner_image"`
+	Language      string           `json:"language"`
+}
+
+type jsonFeed struct {
+	Version  string         `json:"version"`
+	Title    string         `json:"title"`
+	Language string         `json:"language"`
+	Items    []jsonFeedItem `json:"items"`
+}
+
+// Parse parses the JSON Feed content into a list of articles.
+func (p *JSONFeedParser) Parse(resource resource.Resource) ([]article.Article, error) {
+	byteContent := []byte(resource.Content())
+
+	feed, err := p.unmarshalJSONFeed(byteContent)
+	if err != nil {
+		return nil, err
+	}
+
+	articles, err := p.extractArticles(feed, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
+
+func (p *JSONFeedParser) unmarshalJSONFeed(content []byte) (*jsonFeed, error) {
+	var feed jsonFeed
+	err := json.Unmarshal(content, &feed)
+	if err != nil {
+		return nil, err
+	}
+	return &feed, nil
+}
+
+func (p *JSONFeedParser) extractArticles(feed *jsonFeed, resource resource.Resource) ([]article.Article, error) {
+	var articles []article.Article
+
+	for _, item := range feed.Items {
+		if item.Language == "" {
+			item.Language = feed.Language
+		}
+		art, err := p.parseArticle(item, resource)
+		if err != nil {
+			return nil, err
+		}
+		articles = append(articles, art)
+	}
+
+	return articles, nil
+}
+
+func (p *JSONFeedParser) parseArticle(item jsonFeedItem, resource resource.Resource) (article.Article, error) {
+	publishedAt, err := p.dates().ParseAt(p.itemDate(item), resource.FetchedAt())
+	if err != nil {
+		return article.Article{}, err
+	}
+
+	builder := article.NewArticleBuilder().
+		SetTitle(article.Title(strings.TrimSpace(item.Title))).
+		SetDescription(article.Description(p.itemDescription(item))).
+		SetDate(article.CreationDate(publishedAt)).
+		SetSource(resource.Source()).
+		SetAuthor(article.Author(p.itemAuthor(item))).
+		SetLink(article.Link(p.itemLink(item))).
+		SetTags(item.Tags).
+		SetImage(article.Link(p.itemImage(item))).
+		SetUpdated(article.UpdateDate(p.itemUpdated(item, resource.FetchedAt()))).
+		SetLanguage(article.Language(strings.TrimSpace(item.Language))).
+		SetContent(article.Content(p.itemContent(item)))
+
+	newArticle, err := builder.Build()
+	if err != nil {
+		return article.Article{}, err
+	}
+
+	return *newArticle, nil
+}
+
+func (p *JSONFeedParser) itemDate(item jsonFeedItem) string {
+	if published := strings.TrimSpace(item.DatePublished); published != "" {
+		return published
+	}
+	return strings.TrimSpace(item.DateModified)
+}
+
+// itemUpdated returns the modification date of the item, or the zero time if the modification date is used
+// as the publication date or cannot be parsed.
+func (p *JSONFeedParser) itemUpdated(item jsonFeedItem, fetchedAt time.Time) time.Time {
+	if strings.TrimSpace(item.DatePublished) == "" {
+		return time.Time{}
+	}
+
+	updated, err := p.dates().ParseAt(strings.TrimSpace(item.DateModified), fetchedAt)
+	if err != nil {
+		return time.Time{}
+	}
+	return updated
+}
+
+// itemDescription returns the summary of the item, falling back to its text or HTML content.
+func (p *JSONFeedParser) itemDescription(item jsonFeedItem) string {
+	for _, description := range []string{item.Summary, item.ContentText, item.ContentHTML} {
+		if description = strings.TrimSpace(description); description != "" {
+			return description
+		}
+	}
+	return ""
+}
+
+// itemAuthor returns the authors of the item.
+// The authors array was introduced in 1.1 and replaces the deprecated author object of 1.0.
+func (p *JSONFeedParser) itemAuthor(item jsonFeedItem) string {
+	var names []string
+	for _, author := range item.Authors {
+		if name := strings.TrimSpace(author.Name); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 && item.Author != nil {
+		return strings.TrimSpace(item.Author.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+func (p *JSONFeedParser) itemLink(item jsonFeedItem) string {
+	if url := strings.TrimSpace(item.URL); url != "" {
+		return url
+	}
+	return strings.TrimSpace(item.ExternalURL)
+}
+
+// itemContent returns the text content of the item, falling back to its HTML content.
+func (p *JSONFeedParser) itemContent(item jsonFeedItem) string {
+	if text := strings.TrimSpace(item.ContentText); text != "" {
+		return text
+	}
+	return strings.TrimSpace(item.ContentHTML)
+}
+
+// itemImage returns the main image of the item, falling back to its banner image.
+func (p *JSONFeedParser) itemImage(item jsonFeedItem) string {
+	if image := strings.TrimSpace(item.Image); image != "" {
+		return image
+	}
+	return strings.TrimSpace(item.BannerImage)
+}
@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"github.com/stretchr/testify/assert"
+	"news-aggregator/aggregator/model/resource"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestJSONFeedParser_Parse(t *testing.T) {
+	path := filepath.Join("testdata/jsonfeed", "test.json")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	mockResource, err := resource.New("Test Source", resource.JSONFEED, resource.Content(content))
+	if err != nil {
+		t.Fatalf("Failed to create resource: %v", err)
+	}
+
+	parser := &JSONFeedParser{}
+
+	articles, err := parser.Parse(*mockResource)
+	assert.NoError(t, err, "Parser should not return an error")
+	assert.Equal(t, 2, len(articles), "Parser should return all items")
+
+	assert.Equal(t, "Test Title", string(articles[0].Title()), "Article title mismatch")
+	assert.Equal(t, "Test Description", string(articles[0].Description()), "Article description mismatch")
+	assert.Equal(t, "John Doe", string(articles[0].Author()), "Article author mismatch")
+	assert.Equal(t, "http://example.com", string(articles[0].Link()), "Article link mismatch")
+	assert.Equal(t, "Test Source", string(articles[0].Source()), "Article source mismatch")
+	assert.True(t, time.Date(2024, 6, 1, 18, 30, 2, 0, time.UTC).Equal(time.Time(articles[0].Date())),
+		"Article date should be taken from date_published")
+
+	assert.Equal(t, "Second Content", string(articles[1].Description()), "Content should be used without summary")
+	assert.Equal(t, "Jane Doe", string(articles[1].Author()), "Deprecated author object should be supported")
+	assert.Equal(t, "http://example.com/second", string(articles[1].Link()), "External URL should be used without url")
+	assert.True(t, time.Date(2024, 6, 3, 8, 0, 0, 0, time.UTC).Equal(time.Time(articles[1].Date())),
+		"Article date should fall back to date_modified")
+}
+
+func TestJSONFeedParser_Parse_OptionalFields(t *testing.T) {
+	path := filepath.Join("testdata/jsonfeed", "test.json")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	mockResource, err := resource.New("Test Source", resource.JSONFEED, resource.Content(content))
+	if err != nil {
+		t.Fatalf("Failed to create resource: %v", err)
+	}
+
+	parser := &JSONFeedParser{}
+
+	articles, err := parser.Parse(*mockResource)
+	assert.NoError(t, err, "Parser should not return an error")
+	assert.Equal(t, 2, len(articles), "Parser should return all items")
+
+	assert.Equal(t, []string{"election", "vote"}, articles[0].Tags(), "Article tags mismatch")
+	assert.Equal(t, "http://example.com/image.jpg", string(articles[0].Image()), "Article image mismatch")
+	assert.True(t, time.Date(2024, 6, 2, 18, 30, 2, 0, time.UTC).Equal(time.Time(articles[0].Updated())),
+		"Article update date mismatch")
+	assert.Equal(t, "en", string(articles[0].Language()), "Language should be taken from the feed")
+	assert.Equal(t, "<p>Test Content</p>", string(articles[0].Content()), "HTML content should be used without text")
+
+	assert.Equal(t, "http://example.com/banner.jpg", string(articles[1].Image()), "Banner should be the image")
+	assert.True(t, articles[1].Updated().IsZero(), "Modification date used as the date is not an update date")
+	assert.Equal(t, "de", string(articles[1].Language()), "Item language should override the feed one")
+	assert.Equal(t, "Second Content", string(articles[1].Content()), "Article content mismatch")
+}
+
+func TestJSONFeedParser_Parse_InvalidFormat(t *testing.T) {
+	path := filepath.Join("testdata/jsonfeed", "invalid_format_test.json")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	mockResource, err := resource.New("Test Source", resource.JSONFEED, resource.Content(content))
+	if err != nil {
+		t.Fatalf("Failed to create resource: %v", err)
+	}
+
+	parser := &JSONFeedParser{}
+
+	_, err = parser.Parse(*mockResource)
+
+	assert.Errorf(t, err, "Parser should return an error when the file is in invalid json format")
+}
+
+func TestJSONFeedParser_Parse_InvalidArticles(t *testing.T) {
+	path := filepath.Join("testdata/jsonfeed", "invalid_data_test.json")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	mockResource, err := resource.New("Test Source", resource.JSONFEED, resource.Content(content))
+	if err != nil {
+		t.Fatalf("Failed to create resource: %v", err)
+	}
+
+	parser := &JSONFeedParser{}
+
+	_, err = parser.Parse(*mockResource)
+
+	assert.Errorf(t, err, "Parser should return an error when an item has no description")
+}
+
+func TestJSONFeedParser_Parse_CorruptedDate(t *testing.T) {
+	path := filepath.Join("testdata/jsonfeed", "corrupted_date_test.json")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	mockResource, err := resource.New("Test Source", resource.JSONFEED, resource.Content(content))
+	if err != nil {
+		t.Fatalf("Failed to create resource: %v", err)
+	}
+
+	parser := &JSONFeedParser{}
+
+	_, err = parser.Parse(*mockResource)
+
+	assert.Errorf(t, err, "Parser should return an error when the date is in invalid or unknown format")
+}
@@ -0,0 +1,223 @@
+package parser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/model/resource"
+	"strconv"
+	"strings"
+)
+
+// JSONMapping describes where the article fields are located in an arbitrary JSON document.
+// Every field is a dot-separated path, e.g. "data.posts" or "authors.0.name",
+// where numeric segments are used as array indexes.
+// Items points to the array of articles and is resolved against the document root,
+// an empty Items path means that the document itself is the array.
+// All other paths are resolved against a single item of that array.
+// Categories and Tags point either to an array or to a comma-separated string.
+type JSONMapping struct {
+	Items       string `json:"items"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Date        string `json:"date"`
+	Author      string `json:"author,omitempty"`
+	Link        string `json:"link,omitempty"`
+	Categories  string `json:"categories,omitempty"`
+	Tags        string `json:"tags,omitempty"`
+	Image       string `json:"image,omitempty"`
+	Updated     string `json:"updated,omitempty"`
+	Language    string `json:"language,omitempty"`
+	Content     string `json:"content,omitempty"`
+}
+
+// Validate checks that the mapping contains all the paths required to build an article.
+func (m JSONMapping) Validate() error {
+	if strings.TrimSpace(m.Title) == "" {
+		return errors.New("json mapping: title path is required")
+	}
+	if strings.TrimSpace(m.Description) == "" {
+		return errors.New("json mapping: description path is required")
+	}
+	if strings.TrimSpace(m.Date) == "" {
+		return errors.New("json mapping: date path is required")
+	}
+	return nil
+}
+
+// JSONMappingParser is an aggregator.Parser for JSON documents of any shape described by a JSONMapping.
+type JSONMappingParser struct {
+	dateParsing
+	mapping JSONMapping
+}
+
+// NewJSONMappingParser creates a new JSONMappingParser for the given mapping.
+func NewJSONMappingParser(mapping JSONMapping) (*JSONMappingParser, error) {
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+	return &JSONMappingParser{mapping: mapping}, nil
+}
+
+// Parse parses the JSON content into a list of articles according to the mapping.
+func (p *JSONMappingParser) Parse(resource resource.Resource) ([]article.Article, error) {
+	byteContent := []byte(resource.Content())
+
+	document, err := p.unmarshalJSON(byteContent)
+	if err != nil {
+		return nil, err
+	}
+
+	articles, err := p.extractArticles(document, resource)
+	if err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
+
+func (p *JSONMappingParser) unmarshalJSON(content []byte) (interface{}, error) {
+	var document interface{}
+	err := json.Unmarshal(content, &document)
+	if err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+func (p *JSONMappingParser) extractArticles(document interface{}, resource resource.Resource) ([]article.Article, error) {
+	value, found := lookupJSONPath(document, p.mapping.Items)
+	if !found {
+		return nil, fmt.Errorf("items path \"%s\" not found", p.mapping.Items)
+	}
+
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("items path \"%s\" does not point to an array", p.mapping.Items)
+	}
+
+	var articles []article.Article
+
+	for _, item := range items {
+		art, err := p.parseArticle(item, resource)
+		if err != nil {
+			return nil, err
+		}
+		articles = append(articles, art)
+	}
+
+	return articles, nil
+}
+
+func (p *JSONMappingParser) parseArticle(item interface{}, resource resource.Resource) (article.Article, error) {
+	publishedAt, err := p.dates().ParseAt(p.field(item, p.mapping.Date), resource.FetchedAt())
+	if err != nil {
+		return article.Article{}, err
+	}
+
+	builder := article.NewArticleBuilder().
+		SetTitle(article.Title(p.field(item, p.mapping.Title))).
+		SetDescription(article.Description(p.field(item, p.mapping.Description))).
+		SetDate(article.CreationDate(publishedAt)).
+		SetSource(resource.Source()).
+		SetAuthor(article.Author(p.field(item, p.mapping.Author))).
+		SetLink(article.Link(p.field(item, p.mapping.Link))).
+		SetCategories(p.list(item, p.mapping.Categories)).
+		SetTags(p.list(item, p.mapping.Tags)).
+		SetImage(article.Link(p.field(item, p.mapping.Image))).
+		SetLanguage(article.Language(p.field(item, p.mapping.Language))).
+		SetContent(article.Content(p.field(item, p.mapping.Content)))
+
+	if updated := p.field(item, p.mapping.Updated); updated != "" {
+		if updateDate, err := p.dates().ParseAt(updated, resource.FetchedAt()); err == nil {
+			builder.SetUpdated(article.UpdateDate(updateDate))
+		}
+	}
+
+	newArticle, err := builder.Build()
+	if err != nil {
+		return article.Article{}, err
+	}
+
+	return *newArticle, nil
+}
+
+// field returns the string representation of the value located by the path,
+// or an empty string if the path is empty or cannot be resolved.
+func (p *JSONMappingParser) field(item interface{}, path string) string {
+	if strings.TrimSpace(path) == "" {
+		return ""
+	}
+
+	value, found := lookupJSONPath(item, path)
+	if !found || value == nil {
+		return ""
+	}
+
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return ""
+	}
+}
+
+// list returns the values of the array located by the path, or the items of the comma-separated string
+// located by the path. It returns nil if the path is empty or cannot be resolved.
+func (p *JSONMappingParser) list(item interface{}, path string) []string {
+	if strings.TrimSpace(path) == "" {
+		return nil
+	}
+
+	value, found := lookupJSONPath(item, path)
+	if !found {
+		return nil
+	}
+
+	values, ok := value.([]interface{})
+	if !ok {
+		return splitList(p.field(item, path))
+	}
+
+	var items []string
+	for i := range values {
+		if v := p.field(values, strconv.Itoa(i)); v != "" {
+			items = append(items, v)
+		}
+	}
+	return items
+}
+
+// lookupJSONPath walks the decoded JSON value along the dot-separated path.
+func lookupJSONPath(value interface{}, path string) (interface{}, bool) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return value, true
+	}
+
+	for _, segment := range strings.Split(path, ".") {
+		switch node := value.(type) {
+		case map[string]interface{}:
+			next, exists := node[segment]
+			if !exists {
+				return nil, false
+			}
+			value = next
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(node) {
+				return nil, false
+			}
+			value = node[index]
+		default:
+			return nil, false
+		}
+	}
+
+	return value, true
+}
@@ -0,0 +1,156 @@
+package parser
+
+import (
+	"github.com/stretchr/testify/assert"
+	"news-aggregator/aggregator/model/resource"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var testJSONMapping = JSONMapping{
+	Items:       "data.posts",
+	Title:       "headline",
+	Description: "teaser",
+	Date:        "meta.published",
+	Author:      "authors.0.name",
+	Link:        "links.web",
+	Categories:  "section",
+	Tags:        "keywords",
+	Image:       "media.image",
+	Updated:     "meta_updated",
+	Language:    "lang",
+	Content:     "body",
+}
+
+func TestNewJSONMappingParser(t *testing.T) {
+	tests := []struct {
+		name        string
+		mapping     JSONMapping
+		expectError bool
+	}{
+		{"complete mapping", testJSONMapping, false},
+		{"optional paths omitted", JSONMapping{Title: "title", Description: "description", Date: "date"}, false},
+		{"missing title", JSONMapping{Description: "description", Date: "date"}, true},
+		{"missing description", JSONMapping{Title: "title", Date: "date"}, true},
+		{"missing date", JSONMapping{Title: "title", Description: "description"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser, err := NewJSONMappingParser(tt.mapping)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, parser)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, parser)
+			}
+		})
+	}
+}
+
+func TestJSONMappingParser_Parse(t *testing.T) {
+	path := filepath.Join("testdata/jsonmapping", "test.json")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	mockResource, err := resource.New("Test Source", resource.JSON, resource.Content(content))
+	if err != nil {
+		t.Fatalf("Failed to create resource: %v", err)
+	}
+
+	parser, err := NewJSONMappingParser(testJSONMapping)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	articles, err := parser.Parse(*mockResource)
+	assert.NoError(t, err, "Parser should not return an error")
+	assert.Equal(t, 2, len(articles), "Parser should return all items")
+
+	assert.Equal(t, "Test Title", string(articles[0].Title()), "Article title mismatch")
+	assert.Equal(t, "Test Description", string(articles[0].Description()), "Article description mismatch")
+	assert.Equal(t, "John Doe", string(articles[0].Author()), "Article author mismatch")
+	assert.Equal(t, "http://example.com", string(articles[0].Link()), "Article link mismatch")
+	assert.Equal(t, "Test Source", string(articles[0].Source()), "Article source mismatch")
+	assert.True(t, time.Date(2024, 6, 1, 18, 30, 2, 0, time.UTC).Equal(time.Time(articles[0].Date())),
+		"Article date mismatch")
+
+	assert.Equal(t, "", string(articles[1].Author()), "Unresolved optional path should result in an empty field")
+
+	assert.Equal(t, []string{"World"}, articles[0].Categories(), "String should be split into categories")
+	assert.Equal(t, []string{"election", "vote"}, articles[0].Tags(), "Array should be used as tags")
+	assert.Equal(t, "http://example.com/image.jpg", string(articles[0].Image()), "Article image mismatch")
+	assert.True(t, time.Date(2024, 6, 2, 18, 30, 2, 0, time.UTC).Equal(time.Time(articles[0].Updated())),
+		"Article update date mismatch")
+	assert.Equal(t, "en", string(articles[0].Language()), "Article language mismatch")
+	assert.Equal(t, "Test Content", string(articles[0].Content()), "Article content mismatch")
+
+	assert.Nil(t, articles[1].Tags(), "Unresolved optional path should result in no tags")
+	assert.True(t, articles[1].Updated().IsZero(), "Unresolved optional path should result in no update date")
+}
+
+func TestJSONMappingParser_Parse_RootArray(t *testing.T) {
+	path := filepath.Join("testdata/jsonmapping", "root_array_test.json")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	mockResource, err := resource.New("Test Source", resource.JSON, resource.Content(content))
+	if err != nil {
+		t.Fatalf("Failed to create resource: %v", err)
+	}
+
+	mapping := testJSONMapping
+	mapping.Items = ""
+	parser, err := NewJSONMappingParser(mapping)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	articles, err := parser.Parse(*mockResource)
+	assert.NoError(t, err, "Parser should not return an error")
+	assert.Equal(t, 1, len(articles), "Empty items path should point to the document root")
+}
+
+func TestJSONMappingParser_Parse_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		mapping JSONMapping
+	}{
+		{"invalid json", "testdata/json/invalid_format_test.json", testJSONMapping},
+		{"items path not found", "testdata/json/test.json", testJSONMapping},
+		{"items path is not an array", "testdata/jsonmapping/test.json",
+			JSONMapping{Items: "data", Title: "headline", Description: "teaser", Date: "meta.published"}},
+		{"missing description", "testdata/jsonmapping/invalid_data_test.json", testJSONMapping},
+		{"corrupted date", "testdata/jsonmapping/corrupted_date_test.json", testJSONMapping},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := os.ReadFile(tt.file)
+			if err != nil {
+				t.Fatalf("Failed to read test data: %v", err)
+			}
+
+			mockResource, err := resource.New("Test Source", resource.JSON, resource.Content(content))
+			if err != nil {
+				t.Fatalf("Failed to create resource: %v", err)
+			}
+
+			parser, err := NewJSONMappingParser(tt.mapping)
+			if err != nil {
+				t.Fatalf("Failed to create parser: %v", err)
+			}
+
+			_, err = parser.Parse(*mockResource)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -8,7 +8,9 @@ import (
 )
 
 // JSONParser is an aggregator.Parser that parses JSON data.
-type JSONParser struct{}
+type JSONParser struct {
+	dateParsing
+}
 
 type jsonArticle struct {
 	Source struct {
@@ -19,6 +21,8 @@ type jsonArticle struct {
 	Description string `json:"description"`
 	PublishedAt string `json:"publishedAt"`
 	Link        string `json:"url"`
+	Image       string `json:"urlToImage"`
+	Content     string `json:"content"`
 }
 
 type jsonResponse struct {
@@ -42,6 +46,31 @@ func (p *JSONParser) Parse(resource resource.Resource) ([]article.Article, error
 	return articles, nil
 }
 
+// ParseTolerant parses the JSON content like Parse, but skips the items that can not be turned into an article.
+// Every skipped item is reported as a warning, an error is returned only if the content itself is malformed.
+func (p *JSONParser) ParseTolerant(resource resource.Resource) ([]article.Article, []ItemWarning, error) {
+	byteContent := []byte(resource.Content())
+
+	response, err := p.unmarshalJSON(byteContent)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var articles []article.Article
+	var warnings []ItemWarning
+
+	for i, a := range response.Articles {
+		art, err := p.parseArticle(a, resource)
+		if err != nil {
+			warnings = append(warnings, newItemWarning(i, err))
+			continue
+		}
+		articles = append(articles, art)
+	}
+
+	return articles, warnings, nil
+}
+
 func (p *JSONParser) unmarshalJSON(content []byte) (*jsonResponse, error) {
 	var response jsonResponse
 	err := json.Unmarshal(content, &response)
@@ -66,18 +95,30 @@ func (p *JSONParser) extractArticles(response *jsonResponse, resource resource.R
 }
 
 func (p *JSONParser) parseArticle(a jsonArticle, resource resource.Resource) (article.Article, error) {
-	publishedAt, err := NewDateParser().Parse(a.PublishedAt)
-	if err != nil {
+	title := strings.TrimSpace(a.Title)
+	if err := requireField("title", title); err != nil {
 		return article.Article{}, err
 	}
 
+	description := strings.TrimSpace(a.Description)
+	if err := requireField("description", description); err != nil {
+		return article.Article{}, err
+	}
+
+	publishedAt, err := p.dates().ParseAt(a.PublishedAt, resource.FetchedAt())
+	if err != nil {
+		return article.Article{}, &FieldError{Field: "date", Err: err}
+	}
+
 	builder := article.NewArticleBuilder().
-		SetTitle(article.Title(strings.TrimSpace(a.Title))).
-		SetDescription(article.Description(strings.TrimSpace(a.Description))).
+		SetTitle(article.Title(title)).
+		SetDescription(article.Description(description)).
 		SetDate(article.CreationDate(publishedAt)).
 		SetSource(resource.Source()).
 		SetAuthor(article.Author(strings.TrimSpace(a.Author))).
-		SetLink(article.Link(strings.TrimSpace(a.Link)))
+		SetLink(article.Link(strings.TrimSpace(a.Link))).
+		SetImage(article.Link(strings.TrimSpace(a.Image))).
+		SetContent(article.Content(strings.TrimSpace(a.Content)))
 
 	newArticle, err := builder.Build()
 	if err != nil {
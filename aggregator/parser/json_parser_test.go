@@ -37,6 +37,9 @@ func TestJSONParser_Parse(t *testing.T) {
 	assert.Equal(t, expectedAuthor, string(articles[0].Author()), "Article author mismatch")
 	assert.Equal(t, expectedLink, string(articles[0].Link()), "Article link mismatch")
 	assert.Equal(t, expectedSource, string(articles[0].Source()), "Article source mismatch")
+
+	assert.Equal(t, "http://example.com/image.jpg", string(articles[0].Image()), "Article image mismatch")
+	assert.Equal(t, "Test Content", string(articles[0].Content()), "Article content mismatch")
 }
 
 func TestJSONParser_Parse_EmptyArticles(t *testing.T) {
@@ -96,3 +99,50 @@ func TestJSONParser_Parse_InvalidFormat(t *testing.T) {
 
 	assert.Errorf(t, err, "Parser should return an error when the file is in invalid or unknown json format")
 }
+
+func TestJSONParser_ParseTolerant(t *testing.T) {
+	path := filepath.Join("testdata/json", "partially_invalid_test.json")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	mockResource, err := resource.New("Test Source", resource.JSON, resource.Content(content))
+	if err != nil {
+		t.Fatalf("Failed to create resource: %v", err)
+	}
+
+	parser := &JSONParser{}
+
+	_, err = parser.Parse(*mockResource)
+	assert.Error(t, err, "Strict parsing should fail on the first invalid item")
+
+	articles, warnings, err := parser.ParseTolerant(*mockResource)
+	assert.NoError(t, err, "Tolerant parsing should not fail on invalid items")
+	assert.Equal(t, 1, len(articles), "Invalid items should be skipped")
+	assert.Equal(t, "Test Title", string(articles[0].Title()), "Article title mismatch")
+
+	assert.Equal(t, 2, len(warnings), "Every skipped item should be reported")
+	assert.Equal(t, 1, warnings[0].Index)
+	assert.Equal(t, "date", warnings[0].Field)
+	assert.Equal(t, 2, warnings[1].Index)
+	assert.Equal(t, "description", warnings[1].Field)
+}
+
+func TestJSONParser_ParseTolerant_InvalidFormat(t *testing.T) {
+	path := filepath.Join("testdata/json", "invalid_format_test.json")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	mockResource, err := resource.New("Test Source", resource.JSON, resource.Content(content))
+	if err != nil {
+		t.Fatalf("Failed to create resource: %v", err)
+	}
+
+	parser := &JSONParser{}
+
+	_, _, err = parser.ParseTolerant(*mockResource)
+	assert.Error(t, err, "Tolerant parsing should fail when the content itself is malformed")
+}
@@ -8,7 +8,9 @@ import (
 )
 
 // RSSParser is the aggregator.Parser for parsing RSS 2.0 feeds.
-type RSSParser struct{}
+type RSSParser struct {
+	dateParsing
+}
 
 type mediaThumbnail struct {
 	URL    string `xml:"url,attr"`
@@ -16,20 +18,36 @@ type mediaThumbnail struct {
 	Height int    `xml:"height,attr"`
 }
 
+type mediaContent struct {
+	URL    string `xml:"url,attr"`
+	Medium string `xml:"medium,attr"`
+	Type   string `xml:"type,attr"`
+	Width  int    `xml:"width,attr"`
+}
+
+type rssEnclosure struct {
+	URL  string `xml:"url,attr"`
+	Type string `xml:"type,attr"`
+}
+
 type rssItem struct {
-	Title       string           `xml:"title"`
-	Link        string           `xml:"link"`
-	PubDate     string           `xml:"pubDate"`
-	Description string           `xml:"description"`
-	MediaThumbs []mediaThumbnail `xml:"thumbnail"`
-	Keywords    string           `xml:"keywords"`
-	LinkedVideo string           `xml:"LinkedVideo"`
-	Category    string           `xml:"category"`
-	Creator     string           `xml:"creator" xml:"http://purl.org/dc/elements/1.1/creator"`
+	Title         string           `xml:"title"`
+	Link          string           `xml:"link"`
+	PubDate       string           `xml:"pubDate"`
+	Description   string           `xml:"description"`
+	MediaThumbs   []mediaThumbnail `xml:"thumbnail"`
+	MediaContents []mediaContent   `xml:"content"`
+	Enclosures    []rssEnclosure   `xml:"enclosure"`
+	Keywords      string           `xml:"keywords"`
+	LinkedVideo   string           `xml:"LinkedVideo"`
+	Categories    []string         `xml:"category"`
+	Creator       string           `xml:"creator" xml:"http://purl.org/dc/elements/1.1/creator"`
+	Encoded       string           `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
 }
 
 type rssChannel struct {
-	Items []rssItem `xml:"channel>item"`
+	Language string    `xml:"channel>language"`
+	Items    []rssItem `xml:"channel>item"`
 }
 
 // Parse parses the RSS feed from the provided content and returns a list of articles.
@@ -49,6 +67,31 @@ func (p *RSSParser) Parse(resource resource.Resource) ([]article.Article, error)
 	return articles, nil
 }
 
+// ParseTolerant parses the RSS feed like Parse, but skips the items that can not be turned into an article.
+// Every skipped item is reported as a warning, an error is returned only if the content itself is malformed.
+func (p *RSSParser) ParseTolerant(resource resource.Resource) ([]article.Article, []ItemWarning, error) {
+	byteContent := []byte(resource.Content())
+
+	rssChannel, err := p.unmarshalRSS(byteContent)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var articles []article.Article
+	var warnings []ItemWarning
+
+	for i, item := range rssChannel.Items {
+		art, err := p.parseArticle(item, rssChannel.Language, resource)
+		if err != nil {
+			warnings = append(warnings, newItemWarning(i, err))
+			continue
+		}
+		articles = append(articles, art)
+	}
+
+	return articles, warnings, nil
+}
+
 func (p *RSSParser) unmarshalRSS(content []byte) (*rssChannel, error) {
 	var channel rssChannel
 	err := xml.Unmarshal(content, &channel)
@@ -62,7 +105,7 @@ func (p *RSSParser) extractArticles(channel *rssChannel, resource resource.Resou
 	var articles []article.Article
 
 	for _, item := range channel.Items {
-		art, err := p.parseArticle(item, resource)
+		art, err := p.parseArticle(item, channel.Language, resource)
 		if err != nil {
 			return nil, err
 		}
@@ -72,19 +115,34 @@ func (p *RSSParser) extractArticles(channel *rssChannel, resource resource.Resou
 	return articles, nil
 }
 
-func (p *RSSParser) parseArticle(item rssItem, resource resource.Resource) (article.Article, error) {
-	creationDate, err := NewDateParser().Parse(item.PubDate)
-	if err != nil {
+func (p *RSSParser) parseArticle(item rssItem, language string, resource resource.Resource) (article.Article, error) {
+	title := strings.TrimSpace(item.Title)
+	if err := requireField("title", title); err != nil {
+		return article.Article{}, err
+	}
+
+	description := strings.TrimSpace(item.Description)
+	if err := requireField("description", description); err != nil {
 		return article.Article{}, err
 	}
 
+	creationDate, err := p.dates().ParseAt(item.PubDate, resource.FetchedAt())
+	if err != nil {
+		return article.Article{}, &FieldError{Field: "date", Err: err}
+	}
+
 	builder := article.NewArticleBuilder().
-		SetTitle(article.Title(strings.TrimSpace(item.Title))).
-		SetDescription(article.Description(strings.TrimSpace(item.Description))).
+		SetTitle(article.Title(title)).
+		SetDescription(article.Description(description)).
 		SetDate(article.CreationDate(creationDate)).
 		SetSource(resource.Source()).
 		SetAuthor(article.Author(strings.TrimSpace(item.Creator))).
-		SetLink(article.Link(strings.TrimSpace(item.Link)))
+		SetLink(article.Link(strings.TrimSpace(item.Link))).
+		SetCategories(item.Categories).
+		SetTags(splitList(item.Keywords)).
+		SetImage(article.Link(p.itemImage(item))).
+		SetLanguage(article.Language(strings.TrimSpace(language))).
+		SetContent(article.Content(strings.TrimSpace(item.Encoded)))
 
 	newArticle, err := builder.Build()
 	if err != nil {
@@ -93,3 +151,34 @@ func (p *RSSParser) parseArticle(item rssItem, resource resource.Resource) (arti
 
 	return *newArticle, nil
 }
+
+// itemImage returns the largest media thumbnail of the item, falling back to the largest image
+// among the media contents and then to an image enclosure.
+func (p *RSSParser) itemImage(item rssItem) string {
+	image, width := "", -1
+	for _, thumb := range item.MediaThumbs {
+		if url := strings.TrimSpace(thumb.URL); url != "" && thumb.Width > width {
+			image, width = url, thumb.Width
+		}
+	}
+	if image != "" {
+		return image
+	}
+
+	for _, media := range item.MediaContents {
+		isImage := media.Medium == "image" || strings.HasPrefix(media.Type, "image/")
+		if url := strings.TrimSpace(media.URL); url != "" && isImage && media.Width > width {
+			image, width = url, media.Width
+		}
+	}
+	if image != "" {
+		return image
+	}
+
+	for _, enclosure := range item.Enclosures {
+		if url := strings.TrimSpace(enclosure.URL); url != "" && strings.HasPrefix(enclosure.Type, "image/") {
+			return url
+		}
+	}
+	return ""
+}
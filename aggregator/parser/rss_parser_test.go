@@ -37,6 +37,14 @@ func TestRSSParser_Parse(t *testing.T) {
 	assert.Equal(t, expectedAuthor, string(articles[0].Author()), "Article author mismatch")
 	assert.Equal(t, expectedLink, string(articles[0].Link()), "Article link mismatch")
 	assert.Equal(t, expectedSource, string(articles[0].Source()), "Article source mismatch")
+
+	assert.Equal(t, []string{"World", "Politics"}, articles[0].Categories(), "Article categories mismatch")
+	assert.Equal(t, []string{"election", "vote"}, articles[0].Tags(), "Tags should be taken from the keywords")
+	assert.Equal(t, "http://example.com/large.jpg", string(articles[0].Image()), "The largest thumbnail is the image")
+	assert.Equal(t, "en-us", string(articles[0].Language()), "Language should be taken from the channel")
+	assert.Equal(t, "<p>Test Content</p>", string(articles[0].Content()), "Article content mismatch")
+	assert.True(t, articles[0].Updated().IsZero(), "RSS items have no update date")
+	assert.NotEmpty(t, articles[0].ID(), "Article ID should be set")
 }
 
 func TestRSSParser_Parse_InvalidFormat(t *testing.T) {
@@ -98,3 +106,50 @@ func TestRSSParser_Parse_CorruptedDate(t *testing.T) {
 
 	assert.Errorf(t, err, "Parser should return an error when article creation date format is invalid or unknown")
 }
+
+func TestRSSParser_ParseTolerant(t *testing.T) {
+	path := filepath.Join("testdata/rss", "partially_invalid_test.xml")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	mockResource, err := resource.New("Test Source", resource.RSS, resource.Content(content))
+	if err != nil {
+		t.Fatalf("Failed to create resource: %v", err)
+	}
+
+	parser := &RSSParser{}
+
+	_, err = parser.Parse(*mockResource)
+	assert.Error(t, err, "Strict parsing should fail on the first invalid item")
+
+	articles, warnings, err := parser.ParseTolerant(*mockResource)
+	assert.NoError(t, err, "Tolerant parsing should not fail on invalid items")
+	assert.Equal(t, 1, len(articles), "Invalid items should be skipped")
+	assert.Equal(t, "Test Title", string(articles[0].Title()), "Article title mismatch")
+
+	assert.Equal(t, 2, len(warnings), "Every skipped item should be reported")
+	assert.Equal(t, 1, warnings[0].Index)
+	assert.Equal(t, "date", warnings[0].Field)
+	assert.Equal(t, 2, warnings[1].Index)
+	assert.Equal(t, "description", warnings[1].Field)
+}
+
+func TestRSSParser_ParseTolerant_InvalidFormat(t *testing.T) {
+	path := filepath.Join("testdata/rss", "invalid_format_test.xml")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read test data: %v", err)
+	}
+
+	mockResource, err := resource.New("Test Source", resource.RSS, resource.Content(content))
+	if err != nil {
+		t.Fatalf("Failed to create resource: %v", err)
+	}
+
+	parser := &RSSParser{}
+
+	_, _, err = parser.ParseTolerant(*mockResource)
+	assert.Error(t, err, "Tolerant parsing should fail when the content itself is malformed")
+}
@@ -0,0 +1,23 @@
+package parser
+
+import (
+	"encoding/json"
+	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/model/resource"
+)
+
+// StoredParser is an aggregator.Parser for resources of the resource.STORED format,
+// holding a JSON array of articles that were parsed before and kept by a storage.
+type StoredParser struct{}
+
+// Parse decodes the stored articles. Their source is kept as it was stored.
+func (p *StoredParser) Parse(resource resource.Resource) ([]article.Article, error) {
+	var articles []article.Article
+
+	err := json.Unmarshal([]byte(resource.Content()), &articles)
+	if err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
@@ -0,0 +1,46 @@
+package parser
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/model/resource"
+	"testing"
+	"time"
+)
+
+func TestStoredParser_Parse(t *testing.T) {
+	art, err := article.NewArticleBuilder().
+		SetTitle("Test Title").
+		SetDescription("Test Description").
+		SetDate(article.CreationDate(time.Date(2024, 6, 1, 18, 30, 2, 0, time.UTC))).
+		SetSource("Test Source").
+		SetLink("http://example.com").
+		SetTags([]string{"election"}).
+		Build()
+	assert.NoError(t, err)
+
+	content, err := json.Marshal([]article.Article{*art})
+	assert.NoError(t, err)
+
+	mockResource, err := resource.New("Test Source", resource.STORED, resource.Content(content))
+	assert.NoError(t, err)
+
+	parser := &StoredParser{}
+
+	articles, err := parser.Parse(*mockResource)
+	assert.NoError(t, err, "Parser should not return an error")
+	assert.Equal(t, []article.Article{*art}, articles, "Stored articles should be restored")
+}
+
+func TestStoredParser_Parse_Invalid(t *testing.T) {
+	parser := &StoredParser{}
+
+	for _, content := range []string{"invalid", `[{"title":"Test Title"}]`} {
+		mockResource, err := resource.New("Test Source", resource.STORED, resource.Content(content))
+		assert.NoError(t, err)
+
+		_, err = parser.Parse(*mockResource)
+		assert.Error(t, err, "Parser should return an error for %q", content)
+	}
+}
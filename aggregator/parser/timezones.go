@@ -0,0 +1,73 @@
+package parser
+
+import (
+	"time"
+	// The generic North American abbreviations are resolved with the IANA database,
+	// which is embedded to not depend on the zoneinfo files of the host.
+	_ "time/tzdata"
+)
+
+// fixedTimezones maps the timezone abbreviations commonly found in feeds to their UTC offsets in hours.
+var fixedTimezones = map[string]float64{
+	"UT":   0,
+	"UTC":  0,
+	"GMT":  0,
+	"Z":    0,
+	"WET":  0,
+	"BST":  1,
+	"CET":  1,
+	"WEST": 1,
+	"CEST": 2,
+	"EET":  2,
+	"EEST": 3,
+	"MSK":  3,
+	"IST":  5.5,
+	"SGT":  8,
+	"HKT":  8,
+	"JST":  9,
+	"KST":  9,
+	"AEST": 10,
+	"AEDT": 11,
+	"NZST": 12,
+	"NZDT": 13,
+	"AST":  -4,
+	"ADT":  -3,
+	"EST":  -5,
+	"EDT":  -4,
+	"CST":  -6,
+	"CDT":  -5,
+	"MST":  -7,
+	"MDT":  -6,
+	"PST":  -8,
+	"PDT":  -7,
+	"AKST": -9,
+	"AKDT": -8,
+	"HST":  -10,
+}
+
+// genericTimezones maps the abbreviations that do not specify daylight saving time to IANA locations.
+var genericTimezones = map[string]string{
+	"ET": "America/New_York",
+	"CT": "America/Chicago",
+	"MT": "America/Denver",
+	"PT": "America/Los_Angeles",
+}
+
+// defaultTimezones returns the default abbreviation table of a DateParser.
+func defaultTimezones() map[string]*time.Location {
+	zones := make(map[string]*time.Location, len(fixedTimezones)+len(genericTimezones))
+
+	for abbreviation, offset := range fixedTimezones {
+		zones[abbreviation] = time.FixedZone(abbreviation, int(offset*float64(time.Hour/time.Second)))
+	}
+
+	for abbreviation, name := range genericTimezones {
+		location, err := time.LoadLocation(name)
+		if err != nil {
+			continue
+		}
+		zones[abbreviation] = location
+	}
+
+	return zones
+}
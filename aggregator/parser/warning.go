@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ItemWarning describes a feed item that was skipped by a tolerant parser.
+type ItemWarning struct {
+	Index  int
+	Field  string
+	Reason string
+}
+
+// String returns a human-readable description of the warning.
+func (w ItemWarning) String() string {
+	return fmt.Sprintf("item %d skipped, %s: %s", w.Index, w.Field, w.Reason)
+}
+
+// FieldError is an error caused by a single field of a feed item.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+// Error returns a human-readable description of the failure.
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// newItemWarning creates an ItemWarning for the item with the given index from the error of its parsing.
+func newItemWarning(index int, err error) ItemWarning {
+	var fieldErr *FieldError
+	if errors.As(err, &fieldErr) {
+		return ItemWarning{Index: index, Field: fieldErr.Field, Reason: fieldErr.Err.Error()}
+	}
+	return ItemWarning{Index: index, Field: "item", Reason: err.Error()}
+}
+
+// requireField returns a FieldError if the value of the required field is empty.
+func requireField(name, value string) error {
+	if value == "" {
+		return &FieldError{Field: name, Err: errors.New("cannot be empty")}
+	}
+	return nil
+}
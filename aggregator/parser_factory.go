@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"news-aggregator/aggregator/model/resource"
 	"news-aggregator/aggregator/parser"
+	"sync"
+	"time"
 )
 
 type Factory interface {
@@ -18,19 +20,25 @@ type parserProperties struct {
 }
 
 // ParserFactory is a parser's selector, according to the resource format and publisher.
+// It is safe for concurrent use.
 type ParserFactory struct {
+	mu      sync.RWMutex
 	parsers map[parserProperties]Parser
 }
 
 // NewParserFactory creates a new factory with predefined default parsers.
+// The USA Today parser is an HTMLSelectorsParser configured with parser.USATodaySelectors.
 func NewParserFactory() *ParserFactory {
+	// The selectors are static and covered by tests, so the validation error can be safely ignored.
+	usaTodayParser, _ := parser.NewHTMLSelectorsParser(parser.USATodaySelectors)
+
 	return &ParserFactory{
 		parsers: map[parserProperties]Parser{
 			{format: resource.JSON, publisher: "nbc-news"}:        &parser.JSONParser{},
 			{format: resource.RSS, publisher: "abc-news"}:         &parser.RSSParser{},
 			{format: resource.RSS, publisher: "washington-times"}: &parser.RSSParser{},
 			{format: resource.RSS, publisher: "bbc-world"}:        &parser.RSSParser{},
-			{format: resource.HTML, publisher: "usa-today"}:       &parser.USATodayHTMLParser{},
+			{format: resource.HTML, publisher: "usa-today"}:       usaTodayParser,
 		},
 	}
 }
@@ -38,23 +46,94 @@ func NewParserFactory() *ParserFactory {
 // AddNewParser registers a parser with a specific format and publisher.
 func (f *ParserFactory) AddNewParser(format resource.Format, publisher resource.Source, parser Parser) {
 	key := parserProperties{format: format, publisher: publisher}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
 	f.parsers[key] = parser
 }
 
 // GetParser returns a parser for the given resource.
-// If the format is RSS, always returns the RSS parser as it is the default one for this format.
+// A parser registered for the format and publisher takes precedence over the universal ones.
+// If the format is RSS, returns the RSS parser as it is the default one for this format.
 // RSSParser is a universal parser for all RSS feeds.
 // It supports all RSS versions (0.91, 0.92, 1.0, 2.0).
+// The same applies to the ATOM format, which is handled by the universal AtomParser,
+// and to the JSONFEED format, which is handled by the universal JSONFeedParser.
+// Articles of the STORED format were parsed before, they are decoded by the StoredParser.
 func (f *ParserFactory) GetParser(format resource.Format, publisher resource.Source) (Parser, error) {
 
-	if format == resource.RSS {
+	key := parserProperties{format: format, publisher: publisher}
+
+	f.mu.RLock()
+	p, exists := f.parsers[key]
+	f.mu.RUnlock()
+
+	if exists {
+		return p, nil
+	}
+
+	switch format {
+	case resource.RSS:
 		return &parser.RSSParser{}, nil
+	case resource.ATOM:
+		return &parser.AtomParser{}, nil
+	case resource.JSONFEED:
+		return &parser.JSONFeedParser{}, nil
+	case resource.STORED:
+		return &parser.StoredParser{}, nil
 	}
 
-	key := parserProperties{format: format, publisher: publisher}
-	p, exists := f.parsers[key]
-	if !exists {
-		return nil, fmt.Errorf("no parser found for format: %d and publisher: %s", format, publisher)
+	return nil, fmt.Errorf("no parser found for format: %d and publisher: %s", format, publisher)
+}
+
+// NewConfiguredParser creates a parser for a source of the given format described by the parser.Config.
+// Without a JSON mapping or HTML selectors the universal parser of the format is configured.
+func NewConfiguredParser(format resource.Format, config parser.Config) (Parser, error) {
+	if err := config.Validate(format); err != nil {
+		return nil, err
+	}
+
+	var p interface {
+		Parser
+		SetDateParser(dateParser *parser.DateParser)
+	}
+
+	switch {
+	case config.JSONMapping != nil:
+		mappingParser, err := parser.NewJSONMappingParser(*config.JSONMapping)
+		if err != nil {
+			return nil, err
+		}
+		p = mappingParser
+	case config.HTMLSelectors != nil:
+		selectorsParser, err := parser.NewHTMLSelectorsParser(*config.HTMLSelectors)
+		if err != nil {
+			return nil, err
+		}
+		p = selectorsParser
+	case format == resource.RSS:
+		p = &parser.RSSParser{}
+	case format == resource.ATOM:
+		p = &parser.AtomParser{}
+	case format == resource.JSONFEED:
+		p = &parser.JSONFeedParser{}
+	case format == resource.JSON:
+		p = &parser.JSONParser{}
+	default:
+		return nil, fmt.Errorf("no parser can be configured for format: %s", resource.FormatToString(format))
 	}
+
+	if config.Timezone != "" {
+		location, err := time.LoadLocation(config.Timezone)
+		if err != nil {
+			return nil, err
+		}
+
+		dateParser := parser.NewDateParser()
+		dateParser.SetLocation(location)
+		p.SetDateParser(dateParser)
+	}
+
 	return p, nil
 }
@@ -5,6 +5,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"news-aggregator/aggregator/model/article"
 	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/aggregator/parser"
 	"testing"
 )
 
@@ -61,6 +62,10 @@ func TestGetParser(t *testing.T) {
 		{resource.RSS, "washington-times", true},
 		{resource.RSS, "bbc-world", true},
 		{resource.HTML, "usa-today", true},
+		{resource.ATOM, "any-atom-publisher", true},
+		{resource.JSONFEED, "any-json-feed-publisher", true},
+		{resource.STORED, "any-stored-publisher", true},
+		{resource.JSON, "non-existing", false},
 		{resource.HTML, "non-existing", false},
 	}
 
@@ -75,3 +80,51 @@ func TestGetParser(t *testing.T) {
 		})
 	}
 }
+
+func TestGetParser_RegisteredParserTakesPrecedence(t *testing.T) {
+	factory := NewParserFactory()
+	mockParser := &mockParser{}
+	factory.AddNewParser(resource.ATOM, "configured-publisher", mockParser)
+
+	p, err := factory.GetParser(resource.ATOM, "configured-publisher")
+	assert.NoError(t, err)
+	assert.Equal(t, mockParser, p)
+
+	p, err = factory.GetParser(resource.ATOM, "other-publisher")
+	assert.NoError(t, err)
+	assert.IsType(t, &parser.AtomParser{}, p)
+}
+
+func TestNewConfiguredParser(t *testing.T) {
+	mapping := &parser.JSONMapping{Title: "title", Description: "description", Date: "date"}
+	selectors := &parser.HTMLSelectors{Item: "article"}
+
+	tests := []struct {
+		name        string
+		format      resource.Format
+		config      parser.Config
+		expected    Parser
+		expectError bool
+	}{
+		{"json mapping", resource.JSON, parser.Config{JSONMapping: mapping}, &parser.JSONMappingParser{}, false},
+		{"html selectors", resource.HTML, parser.Config{HTMLSelectors: selectors}, &parser.HTMLSelectorsParser{}, false},
+		{"rss with timezone", resource.RSS, parser.Config{Timezone: "America/New_York"}, &parser.RSSParser{}, false},
+		{"atom with timezone", resource.ATOM, parser.Config{Timezone: "Europe/London"}, &parser.AtomParser{}, false},
+		{"json feed with timezone", resource.JSONFEED, parser.Config{Timezone: "UTC"}, &parser.JSONFeedParser{}, false},
+		{"json with timezone", resource.JSON, parser.Config{Timezone: "UTC"}, &parser.JSONParser{}, false},
+		{"invalid config", resource.RSS, parser.Config{JSONMapping: mapping}, nil, true},
+		{"unknown format", resource.UNKNOWN, parser.Config{}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewConfiguredParser(tt.format, tt.config)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.IsType(t, tt.expected, p)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package query
+
+import (
+	"fmt"
+	"news-aggregator/aggregator/analysis"
+	"news-aggregator/aggregator/model/article"
+)
+
+// Field is an article field a term can be scoped to.
+type Field string
+
+// The fields supported by the query language. AnyField stands for the title and the description.
+const (
+	AnyField         Field = ""
+	TitleField       Field = "title"
+	DescriptionField Field = "description"
+	SourceField      Field = "source"
+	AuthorField      Field = "author"
+)
+
+// Node is a node of the query syntax tree.
+type Node interface {
+
+	// Match reports whether the article satisfies the node.
+	Match(a article.Article) bool
+
+	// String returns the canonical representation of the node.
+	String() string
+}
+
+// And is a Node matching the articles that satisfy both operands.
+type And struct {
+	Left, Right Node
+}
+
+// Match reports whether the article satisfies both operands.
+func (n And) Match(a article.Article) bool {
+	return n.Left.Match(a) && n.Right.Match(a)
+}
+
+func (n And) String() string {
+	return fmt.Sprintf("(%s AND %s)", n.Left, n.Right)
+}
+
+// Or is a Node matching the articles that satisfy any of the operands.
+type Or struct {
+	Left, Right Node
+}
+
+// Match reports whether the article satisfies any of the operands.
+func (n Or) Match(a article.Article) bool {
+	return n.Left.Match(a) || n.Right.Match(a)
+}
+
+func (n Or) String() string {
+	return fmt.Sprintf("(%s OR %s)", n.Left, n.Right)
+}
+
+// Not is a Node matching the articles that do not satisfy the operand.
+type Not struct {
+	Operand Node
+}
+
+// Match reports whether the article does not satisfy the operand.
+func (n Not) Match(a article.Article) bool {
+	return !n.Operand.Match(a)
+}
+
+func (n Not) String() string {
+	return fmt.Sprintf("NOT %s", n.Operand)
+}
+
+// Term is a Node matching the articles containing a word or a phrase.
+// Words are compared by their stems the same way as by the keyword filter, and stopwords match any article.
+// Phrases are matched word by word, ignoring the case and the punctuation.
+type Term struct {
+	Field  Field
+	Value  string
+	Phrase bool
+}
+
+// Match reports whether the scoped fields of the article contain the term.
+func (n Term) Match(a article.Article) bool {
+	analyzer := analysis.Default()
+
+	for _, text := range n.fields(a) {
+		if n.Phrase && analyzer.ContainsPhrase(text, n.Value) {
+			return true
+		}
+		if !n.Phrase && analyzer.Contains(text, n.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n Term) String() string {
+	value := n.Value
+	if n.Phrase {
+		value = fmt.Sprintf("%q", value)
+	}
+	if n.Field != AnyField {
+		return fmt.Sprintf("%s:%s", n.Field, value)
+	}
+	return value
+}
+
+func (n Term) fields(a article.Article) []string {
+	switch n.Field {
+	case TitleField:
+		return []string{a.TitleStr()}
+	case DescriptionField:
+		return []string{a.DescriptionStr()}
+	case SourceField:
+		return []string{string(a.Source())}
+	case AuthorField:
+		return []string{string(a.Author())}
+	default:
+		return []string{a.TitleStr(), a.DescriptionStr()}
+	}
+}
+
+// withoutStopwords returns the node without the terms consisting of stopwords only,
+// or nil if nothing else remains. Phrases are matched with their stopwords and are always kept.
+func withoutStopwords(node Node) Node {
+	switch n := node.(type) {
+	case And:
+		return join(withoutStopwords(n.Left), withoutStopwords(n.Right), func(left, right Node) Node {
+			return And{Left: left, Right: right}
+		})
+	case Or:
+		return join(withoutStopwords(n.Left), withoutStopwords(n.Right), func(left, right Node) Node {
+			return Or{Left: left, Right: right}
+		})
+	case Term:
+		if !n.Phrase && len(analysis.Default().Terms(n.Value)) == 0 {
+			return nil
+		}
+		return n
+	default:
+		return node
+	}
+}
+
+// join combines the operands that are not nil, returning the remaining one if the other is nil.
+func join(left, right Node, combine func(left, right Node) Node) Node {
+	switch {
+	case left == nil:
+		return right
+	case right == nil:
+		return left
+	default:
+		return combine(left, right)
+	}
+}
+
+// PositiveTerms returns the values of the terms an article is searched for, skipping the negated ones.
+func PositiveTerms(node Node) []string {
+	var terms []string
+	collectTerms(node, false, &terms)
+	return terms
+}
+
+func collectTerms(node Node, negated bool, terms *[]string) {
+	switch n := node.(type) {
+	case And:
+		collectTerms(n.Left, negated, terms)
+		collectTerms(n.Right, negated, terms)
+	case Or:
+		collectTerms(n.Left, negated, terms)
+		collectTerms(n.Right, negated, terms)
+	case Not:
+		collectTerms(n.Operand, !negated, terms)
+	case Term:
+		if !negated {
+			*terms = append(*terms, n.Value)
+		}
+	}
+}
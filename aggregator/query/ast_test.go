@@ -0,0 +1,59 @@
+package query_test
+
+import (
+	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/query"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNode_Match(t *testing.T) {
+	art, err := article.NewArticleBuilder().
+		SetTitle("Peace talks resume in Geneva").
+		SetDescription("Delegations discussed grain exports from Ukraine").
+		SetDate(article.CreationDate(time.Now())).
+		SetSource("bbc-world").
+		SetAuthor("John Smith").
+		Build()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		query    string
+		expected bool
+	}{
+		{"Stemmed word", "delegation", true},
+		{"Part of a word", "port", false},
+		{"Stopword", "the", true},
+		{"Word in the title", "geneva", true},
+		{"Title scope excludes description", "title:ukraine", false},
+		{"Description scope", "description:ukraine", true},
+		{"Source scope", "source:bbc", true},
+		{"Author scope", "author:smith", true},
+		{"Phrase ignores the case", "\"PEACE TALKS\"", true},
+		{"Phrase requires adjacent words", "\"peace resume\"", false},
+		{"AND requires both", "geneva AND wheat", false},
+		{"OR requires any", "geneva OR wheat", true},
+		{"NOT negates", "-geneva", false},
+		{"Negated stopword is ignored", "-the", true},
+		{"Negated stopword with a term", "geneva NOT the", true},
+		{"Negated group keeps its other terms", "-(the OR geneva)", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := query.Parse(tt.query)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, node.Match(*art))
+		})
+	}
+}
+
+func TestPositiveTerms(t *testing.T) {
+	node, err := query.Parse("ukraine AND (grain OR wheat) -opinion NOT -title:\"peace talks\"")
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"ukraine", "grain", "wheat", "peace talks"}, query.PositiveTerms(node))
+}
@@ -0,0 +1,13 @@
+// Package query provides a boolean query language for selecting article.Article's.
+//
+// A query consists of terms combined with the AND, OR and NOT operators, where AND binds tighter than OR
+// and adjacent terms are joined with an implicit AND. A term prefixed with a minus is excluded,
+// a quoted term is matched as an exact phrase, and a field prefix scopes a term or a parenthesized group
+// to a single article field:
+//
+//	ukraine AND (grain OR wheat) -opinion title:"peace talks"
+//
+// The supported fields are title, description, source and author. Terms without a field
+// are matched against the title and the description. Words are compared by their stems
+// and stopwords are ignored, even when they are negated, see the analysis package.
+package query
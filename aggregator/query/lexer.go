@@ -0,0 +1,109 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenPhrase
+	tokenField
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenMinus
+	tokenLeftParen
+	tokenRightParen
+)
+
+// token is a lexical unit of a query, position is the byte offset of the token in the query.
+type token struct {
+	kind     tokenKind
+	value    string
+	position int
+}
+
+// tokenize splits the query into tokens. The operators are recognized only in upper case,
+// so that "and" or "or" written in lower case are searched as regular words.
+func tokenize(input string) ([]token, error) {
+	var tokens []token
+	runes := []rune(input)
+	offsets := runeOffsets(input)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		position := offsets[i]
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, value: "(", position: position})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, value: ")", position: position})
+			i++
+		case r == '-':
+			tokens = append(tokens, token{kind: tokenMinus, value: "-", position: position})
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated phrase at position %d", position)
+			}
+			tokens = append(tokens, token{kind: tokenPhrase, value: string(runes[i+1 : end]), position: position})
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && !isDelimiter(runes[end]) {
+				end++
+			}
+			word := string(runes[i:end])
+
+			if end < len(runes) && runes[end] == ':' {
+				tokens = append(tokens, token{kind: tokenField, value: strings.ToLower(word), position: position})
+				i = end + 1
+				continue
+			}
+
+			tokens = append(tokens, token{kind: keywordKind(word), value: word, position: position})
+			i = end
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, position: len(input)}), nil
+}
+
+func isDelimiter(r rune) bool {
+	return unicode.IsSpace(r) || r == '(' || r == ')' || r == '"' || r == ':'
+}
+
+func keywordKind(word string) tokenKind {
+	switch word {
+	case "AND":
+		return tokenAnd
+	case "OR":
+		return tokenOr
+	case "NOT":
+		return tokenNot
+	default:
+		return tokenWord
+	}
+}
+
+// runeOffsets returns the byte offset of every rune of the input.
+func runeOffsets(input string) []int {
+	offsets := make([]int, 0, len(input))
+	for offset := range input {
+		offsets = append(offsets, offset)
+	}
+	return offsets
+}
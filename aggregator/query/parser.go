@@ -0,0 +1,167 @@
+package query
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Parse parses the query into a syntax tree.
+//
+// The grammar of the query language is:
+//
+//	query   = or
+//	or      = and { "OR" and }
+//	and     = unary { [ "AND" ] unary }
+//	unary   = ( "NOT" | "-" ) unary | primary
+//	primary = [ field ":" ] ( word | phrase | "(" or ")" )
+func Parse(input string) (Node, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, errors.New("query cannot be empty")
+	}
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	node, err := p.parseOr(AnyField)
+	if err != nil {
+		return nil, err
+	}
+
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, fmt.Errorf("unexpected \"%s\" at position %d", next.value, next.position)
+	}
+
+	return node, nil
+}
+
+// parser is a recursive descent parser of the query tokens.
+type parser struct {
+	tokens  []token
+	current int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.current]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.current]
+	if t.kind != tokenEOF {
+		p.current++
+	}
+	return t
+}
+
+func (p *parser) parseOr(field Field) (Node, error) {
+	left, err := p.parseAnd(field)
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, err := p.parseAnd(field)
+		if err != nil {
+			return nil, err
+		}
+		left = Or{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd(field Field) (Node, error) {
+	left, err := p.parseUnary(field)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		switch p.peek().kind {
+		case tokenAnd:
+			p.next()
+		case tokenWord, tokenPhrase, tokenField, tokenNot, tokenMinus, tokenLeftParen:
+		default:
+			return left, nil
+		}
+
+		right, err := p.parseUnary(field)
+		if err != nil {
+			return nil, err
+		}
+		left = And{Left: left, Right: right}
+	}
+}
+
+func (p *parser) parseUnary(field Field) (Node, error) {
+	switch p.peek().kind {
+	case tokenNot, tokenMinus:
+		p.next()
+		operand, err := p.parseUnary(field)
+		if err != nil {
+			return nil, err
+		}
+		// Negating stopwords would exclude every article, so they are removed from the negated operand.
+		// The negation is dropped if only stopwords remain, which are ignored as when they are not negated.
+		pruned := withoutStopwords(operand)
+		if pruned == nil {
+			return operand, nil
+		}
+		return Not{Operand: pruned}, nil
+	default:
+		return p.parsePrimary(field)
+	}
+}
+
+func (p *parser) parsePrimary(field Field) (Node, error) {
+	t := p.next()
+
+	switch t.kind {
+	case tokenField:
+		if field != AnyField {
+			return nil, fmt.Errorf("nested field \"%s\" at position %d", t.value, t.position)
+		}
+		scoped, err := parseField(t)
+		if err != nil {
+			return nil, err
+		}
+		if next := p.peek().kind; next != tokenWord && next != tokenPhrase && next != tokenLeftParen {
+			return nil, fmt.Errorf("missing term after field \"%s\" at position %d", t.value, t.position)
+		}
+		return p.parsePrimary(scoped)
+	case tokenWord:
+		return Term{Field: field, Value: t.value}, nil
+	case tokenPhrase:
+		if strings.TrimSpace(t.value) == "" {
+			return nil, fmt.Errorf("empty phrase at position %d", t.position)
+		}
+		return Term{Field: field, Value: strings.Join(strings.Fields(t.value), " "), Phrase: true}, nil
+	case tokenLeftParen:
+		node, err := p.parseOr(field)
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRightParen {
+			return nil, fmt.Errorf("missing closing parenthesis for position %d", t.position)
+		}
+		return node, nil
+	case tokenEOF:
+		return nil, fmt.Errorf("unexpected end of query at position %d", t.position)
+	default:
+		return nil, fmt.Errorf("unexpected \"%s\" at position %d", t.value, t.position)
+	}
+}
+
+func parseField(t token) (Field, error) {
+	switch field := Field(t.value); field {
+	case TitleField, DescriptionField, SourceField, AuthorField:
+		return field, nil
+	default:
+		return AnyField, fmt.Errorf("unknown field \"%s\" at position %d", t.value, t.position)
+	}
+}
@@ -0,0 +1,70 @@
+package query_test
+
+import (
+	"news-aggregator/aggregator/query"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Single word", "ukraine", "ukraine"},
+		{"Implicit AND", "ukraine grain", "(ukraine AND grain)"},
+		{"Explicit AND", "ukraine AND grain", "(ukraine AND grain)"},
+		{"AND binds tighter than OR", "a OR b AND c", "(a OR (b AND c))"},
+		{"Parentheses", "(a OR b) c", "((a OR b) AND c)"},
+		{"Minus excludes", "ukraine -opinion", "(ukraine AND NOT opinion)"},
+		{"NOT operator", "NOT opinion", "NOT opinion"},
+		{"Negated stopword is ignored", "ukraine -the", "(ukraine AND the)"},
+		{"Negated stopword is removed from a group", "NOT (the OR opinion)", "NOT opinion"},
+		{"Negated stopwords only are ignored", "ukraine -(the AND a)", "(ukraine AND (the AND a))"},
+		{"Nested negated stopword is removed", "-(war AND (the OR peace))", "NOT (war AND peace)"},
+		{"Negated stopword phrase is kept", "-\"the\"", "NOT \"the\""},
+		{"Lower case operators are words", "war and peace", "((war AND and) AND peace)"},
+		{"Hyphenated word", "last-minute", "last-minute"},
+		{"Phrase", "\"peace  talks\"", "\"peace talks\""},
+		{"Field scope", "title:\"peace talks\"", "title:\"peace talks\""},
+		{"Field scope of a group", "Title:(grain OR wheat)", "(title:grain OR title:wheat)"},
+		{"Full query", "ukraine AND (grain OR wheat) -opinion title:\"peace talks\"",
+			"(((ukraine AND (grain OR wheat)) AND NOT opinion) AND title:\"peace talks\")"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := query.Parse(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, node.String())
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		error string
+	}{
+		{"Empty query", "  ", "query cannot be empty"},
+		{"Unterminated phrase", "\"peace talks", "unterminated phrase at position 0"},
+		{"Missing closing parenthesis", "(a OR b", "missing closing parenthesis for position 0"},
+		{"Unexpected closing parenthesis", "a)", "unexpected \")\" at position 1"},
+		{"Missing operand", "a OR", "unexpected end of query at position 4"},
+		{"Dangling operator", "AND a", "unexpected \"AND\" at position 0"},
+		{"Unknown field", "body:war", "unknown field \"body\" at position 0"},
+		{"Missing term after field", "title: OR a", "missing term after field \"title\" at position 0"},
+		{"Nested field", "title:(author:smith)", "nested field \"author\" at position 7"},
+		{"Empty phrase", "\" \"", "empty phrase at position 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := query.Parse(tt.input)
+			assert.EqualError(t, err, tt.error)
+		})
+	}
+}
@@ -0,0 +1,6 @@
+// Package ranking provides the relevance ranking of article.Article's for a search.
+//
+// Articles are scored with BM25 over their titles and descriptions, treated as a single field
+// where every title word counts as several description words. The score is then decayed by the age
+// of the article, halving every half-life, so that fresh articles outrank old ones of similar relevance.
+package ranking
@@ -0,0 +1,194 @@
+package ranking
+
+import (
+	"math"
+	"news-aggregator/aggregator/analysis"
+	"news-aggregator/aggregator/model/article"
+	"sort"
+	"time"
+)
+
+// Default parameters of a Scorer.
+const (
+	// DefaultK1 controls how quickly repeated occurrences of a term stop increasing the score.
+	DefaultK1 = 1.2
+	// DefaultB controls how much longer articles are penalized.
+	DefaultB = 0.75
+	// DefaultTitleBoost is the number of description words a title word counts as.
+	DefaultTitleBoost = 2.0
+	// DefaultHalfLife is the age at which the score of an article is halved.
+	DefaultHalfLife = 7 * 24 * time.Hour
+)
+
+// ScoredArticle is an article.Article with its relevance score.
+type ScoredArticle struct {
+	Article article.Article
+	Score   float64
+}
+
+// Scorer computes the relevance of articles for a set of search terms.
+type Scorer struct {
+	terms      []string
+	analyzer   *analysis.Analyzer
+	k1         float64
+	b          float64
+	titleBoost float64
+	halfLife   time.Duration
+	now        time.Time
+}
+
+// NewScorer creates a new Scorer for the given keywords or phrases. Their words are compared by stems
+// and the stopwords are ignored, the same way as by the keyword filter.
+func NewScorer(queries []string) *Scorer {
+	analyzer := analysis.Default()
+
+	var terms []string
+	seen := make(map[string]struct{})
+	for _, q := range queries {
+		for _, term := range analyzer.Terms(q) {
+			if _, exists := seen[term]; !exists {
+				seen[term] = struct{}{}
+				terms = append(terms, term)
+			}
+		}
+	}
+
+	return &Scorer{
+		terms:      terms,
+		analyzer:   analyzer,
+		k1:         DefaultK1,
+		b:          DefaultB,
+		titleBoost: DefaultTitleBoost,
+		halfLife:   DefaultHalfLife,
+	}
+}
+
+// SetTitleBoost sets the number of description words a title word counts as. Values lower than one are ignored.
+func (s *Scorer) SetTitleBoost(boost float64) {
+	if boost >= 1 {
+		s.titleBoost = boost
+	}
+}
+
+// SetHalfLife sets the age at which the score of an article is halved. Zero disables the recency decay.
+func (s *Scorer) SetHalfLife(halfLife time.Duration) {
+	if halfLife >= 0 {
+		s.halfLife = halfLife
+	}
+}
+
+// SetNow sets the time the age of the articles is measured from. The zero value stands for the current time.
+func (s *Scorer) SetNow(now time.Time) {
+	s.now = now
+}
+
+// Score returns the articles with their scores in the given order.
+// The articles form the collection the rarity of the terms is measured in.
+func (s *Scorer) Score(articles []article.Article) []ScoredArticle {
+	documents := make([]document, len(articles))
+	totalLength := 0.0
+	for i, a := range articles {
+		documents[i] = s.newDocument(a)
+		totalLength += documents[i].length
+	}
+
+	averageLength := 1.0
+	if len(documents) > 0 && totalLength > 0 {
+		averageLength = totalLength / float64(len(documents))
+	}
+
+	idf := s.inverseDocumentFrequencies(documents)
+
+	now := s.now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	scored := make([]ScoredArticle, len(articles))
+	for i, a := range articles {
+		score := s.bm25(documents[i], idf, averageLength) * s.decay(a, now)
+		scored[i] = ScoredArticle{Article: a, Score: score}
+	}
+
+	return scored
+}
+
+// Rank returns the articles with their scores ordered from the most relevant to the least relevant one.
+// Articles with equal scores are ordered from the newest to the oldest one.
+func (s *Scorer) Rank(articles []article.Article) []ScoredArticle {
+	scored := s.Score(articles)
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].Score != scored[j].Score {
+			return scored[i].Score > scored[j].Score
+		}
+		return time.Time(scored[i].Article.Date()).After(time.Time(scored[j].Article.Date()))
+	})
+
+	return scored
+}
+
+// document is the weighted term frequencies of an article.
+type document struct {
+	frequencies map[string]float64
+	length      float64
+}
+
+func (s *Scorer) newDocument(a article.Article) document {
+	doc := document{frequencies: make(map[string]float64)}
+
+	for _, term := range s.analyzer.Terms(a.TitleStr()) {
+		doc.frequencies[term] += s.titleBoost
+		doc.length += s.titleBoost
+	}
+	for _, term := range s.analyzer.Terms(a.DescriptionStr()) {
+		doc.frequencies[term]++
+		doc.length++
+	}
+
+	return doc
+}
+
+func (s *Scorer) inverseDocumentFrequencies(documents []document) map[string]float64 {
+	idf := make(map[string]float64, len(s.terms))
+	total := float64(len(documents))
+
+	for _, term := range s.terms {
+		containing := 0.0
+		for _, doc := range documents {
+			if doc.frequencies[term] > 0 {
+				containing++
+			}
+		}
+		idf[term] = math.Log(1 + (total-containing+0.5)/(containing+0.5))
+	}
+
+	return idf
+}
+
+func (s *Scorer) bm25(doc document, idf map[string]float64, averageLength float64) float64 {
+	score := 0.0
+	for _, term := range s.terms {
+		frequency := doc.frequencies[term]
+		if frequency == 0 {
+			continue
+		}
+		normalization := s.k1 * (1 - s.b + s.b*doc.length/averageLength)
+		score += idf[term] * frequency * (s.k1 + 1) / (frequency + normalization)
+	}
+	return score
+}
+
+// decay returns the recency factor of the article, from 1 for the articles of the future down to 0.
+func (s *Scorer) decay(a article.Article, now time.Time) float64 {
+	if s.halfLife == 0 {
+		return 1
+	}
+
+	age := now.Sub(time.Time(a.Date()))
+	if age <= 0 {
+		return 1
+	}
+
+	return math.Pow(0.5, float64(age)/float64(s.halfLife))
+}
@@ -0,0 +1,112 @@
+package ranking_test
+
+import (
+	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/ranking"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2024, 6, 20, 12, 0, 0, 0, time.UTC)
+
+func createArticle(title, description string, age time.Duration) article.Article {
+	art, err := article.NewArticleBuilder().
+		SetTitle(article.Title(title)).
+		SetDescription(article.Description(description)).
+		SetDate(article.CreationDate(now.Add(-age))).
+		SetSource("Source").
+		Build()
+	if err != nil {
+		panic(err)
+	}
+	return *art
+}
+
+func titles(scored []ranking.ScoredArticle) []string {
+	var result []string
+	for _, s := range scored {
+		result = append(result, s.Article.TitleStr())
+	}
+	return result
+}
+
+func TestScorer_Rank(t *testing.T) {
+	tests := []struct {
+		name     string
+		queries  []string
+		articles []article.Article
+		expected []string
+	}{
+		{"Title matches outrank description matches", []string{"grain"},
+			[]article.Article{
+				createArticle("Markets", "Grain prices rise", time.Hour),
+				createArticle("Grain exports", "Ships leave port", time.Hour),
+			},
+			[]string{"Grain exports", "Markets"}},
+		{"Rare terms outrank common ones", []string{"ukraine", "wheat"},
+			[]article.Article{
+				createArticle("Ukraine news", "Ukraine today", time.Hour),
+				createArticle("Wheat news", "Ukraine today", time.Hour),
+				createArticle("Other news", "Ukraine today", time.Hour),
+			},
+			[]string{"Wheat news", "Ukraine news", "Other news"}},
+		{"Recent articles outrank old ones of equal relevance", []string{"grain"},
+			[]article.Article{
+				createArticle("Grain old", "Exports", 30*24*time.Hour),
+				createArticle("Grain new", "Exports", time.Hour),
+			},
+			[]string{"Grain new", "Grain old"}},
+		{"Unmatched articles are ordered by date", []string{"grain"},
+			[]article.Article{
+				createArticle("Old", "News", 48*time.Hour),
+				createArticle("New", "News", time.Hour),
+				createArticle("Grain", "News", 72*time.Hour),
+			},
+			[]string{"Grain", "New", "Old"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scorer := ranking.NewScorer(tt.queries)
+			scorer.SetNow(now)
+
+			assert.Equal(t, tt.expected, titles(scorer.Rank(tt.articles)))
+		})
+	}
+}
+
+func TestScorer_Score(t *testing.T) {
+	articles := []article.Article{
+		createArticle("Grain exports", "Grain", 0),
+		createArticle("Markets", "Stocks", 0),
+		createArticle("Grain exports", "Grain", ranking.DefaultHalfLife),
+	}
+
+	scorer := ranking.NewScorer([]string{"the grain"})
+	scorer.SetNow(now)
+	scored := scorer.Score(articles)
+
+	assert.Len(t, scored, 3)
+	assert.Greater(t, scored[0].Score, 0.0)
+	assert.Equal(t, 0.0, scored[1].Score)
+	assert.InDelta(t, scored[0].Score/2, scored[2].Score, 1e-9)
+
+	scorer.SetHalfLife(0)
+	scored = scorer.Score(articles)
+	assert.InDelta(t, scored[0].Score, scored[2].Score, 1e-9)
+}
+
+func TestScorer_SetTitleBoost(t *testing.T) {
+	articles := []article.Article{
+		createArticle("Grain", "Markets", time.Hour),
+		createArticle("Markets", "Grain grain", time.Hour),
+	}
+
+	scorer := ranking.NewScorer([]string{"grain"})
+	scorer.SetNow(now)
+	scorer.SetTitleBoost(1)
+
+	assert.Equal(t, []string{"Markets", "Grain"}, titles(scorer.Rank(articles)))
+}
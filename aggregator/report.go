@@ -0,0 +1,52 @@
+package aggregator
+
+import (
+	"fmt"
+	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/aggregator/parser"
+)
+
+// SourceError describes a resource.Resource that could not be aggregated.
+type SourceError struct {
+	Source resource.Source
+	Format resource.Format
+	Err    error
+}
+
+// Error returns a human-readable description of the failure.
+func (e SourceError) Error() string {
+	return fmt.Sprintf("source \"%s\" (%s): %v", e.Source, resource.FormatToString(e.Format), e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e SourceError) Unwrap() error {
+	return e.Err
+}
+
+// SourceWarning describes an item of a resource.Resource that was skipped during tolerant aggregation.
+type SourceWarning struct {
+	Source resource.Source
+	Format resource.Format
+	parser.ItemWarning
+}
+
+// String returns a human-readable description of the warning.
+func (w SourceWarning) String() string {
+	return fmt.Sprintf("source \"%s\" (%s): %s", w.Source, resource.FormatToString(w.Format), w.ItemWarning)
+}
+
+// Report contains the problems encountered while aggregating multiple resource.Resource's.
+type Report struct {
+	Errors   []SourceError
+	Warnings []SourceWarning
+}
+
+// HasErrors reports whether at least one resource.Resource failed to be aggregated.
+func (r Report) HasErrors() bool {
+	return len(r.Errors) > 0
+}
+
+// HasWarnings reports whether at least one item was skipped during tolerant aggregation.
+func (r Report) HasWarnings() bool {
+	return len(r.Warnings) > 0
+}
@@ -0,0 +1,81 @@
+package trend
+
+import (
+	"news-aggregator/aggregator/analysis"
+	"news-aggregator/aggregator/model/article"
+	"time"
+)
+
+// day returns the start of the UTC day of the time.
+func day(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// counter counts the articles mentioning every term per day, and the words the terms are written with.
+type counter struct {
+	analyzer *analysis.Analyzer
+	counts   map[string]map[time.Time]int
+	forms    map[string]map[string]int
+}
+
+func newCounter(analyzer *analysis.Analyzer) *counter {
+	return &counter{
+		analyzer: analyzer,
+		counts:   make(map[string]map[time.Time]int),
+		forms:    make(map[string]map[string]int),
+	}
+}
+
+// add counts the terms and the bigrams of the article once on the day of the article.
+func (c *counter) add(a article.Article) {
+	date := day(time.Time(a.Date()))
+	seen := make(map[string]struct{})
+
+	for _, text := range []string{a.TitleStr(), a.DescriptionStr()} {
+		var previous *analysis.Token
+
+		for _, token := range c.analyzer.Tokens(text) {
+			if token.Stopword || len(token.Term) < 2 {
+				previous = nil
+				continue
+			}
+
+			c.count(token.Term, token.Word, date, seen)
+			if previous != nil {
+				c.count(previous.Term+" "+token.Term, previous.Word+" "+token.Word, date, seen)
+			}
+
+			current := token
+			previous = &current
+		}
+	}
+}
+
+func (c *counter) count(term, form string, date time.Time, seen map[string]struct{}) {
+	if _, exists := c.forms[term]; !exists {
+		c.forms[term] = make(map[string]int)
+	}
+	c.forms[term][form]++
+
+	if _, exists := seen[term]; exists {
+		return
+	}
+	seen[term] = struct{}{}
+
+	if _, exists := c.counts[term]; !exists {
+		c.counts[term] = make(map[time.Time]int)
+	}
+	c.counts[term][date]++
+}
+
+// form returns the most frequent way the term is written in, the first one in lexical order for a tie.
+func (c *counter) form(term string) string {
+	best, bestCount := term, 0
+	for form, count := range c.forms[term] {
+		if count > bestCount || (count == bestCount && form < best) {
+			best, bestCount = form, count
+		}
+	}
+	return best
+}
@@ -0,0 +1,210 @@
+package trend
+
+import (
+	"math"
+	"news-aggregator/aggregator/analysis"
+	"news-aggregator/aggregator/model/article"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Default parameters of a Detector.
+const (
+	// DefaultWindow is the period the trending terms are detected in.
+	DefaultWindow = 7 * 24 * time.Hour
+	// DefaultBaseline is the period preceding every day the count of the day is compared with.
+	DefaultBaseline = 7 * 24 * time.Hour
+	// DefaultMinCount is the smallest number of articles mentioning a term on a day for it to trend.
+	DefaultMinCount = 2
+	// DefaultLimit is the largest number of trending terms detected.
+	DefaultLimit = 20
+)
+
+// DailyCount is the number of articles mentioning a term on a day.
+type DailyCount struct {
+	// Date is the start of the UTC day.
+	Date  time.Time
+	Count int
+}
+
+// Trend is a trending term or bigram.
+type Trend struct {
+	// Term is the term the way it is written the most often, e.g. "elections" or "prime minister".
+	Term string
+	// Score is the largest spike of the term in the window, see Detector.Detect.
+	Score float64
+	// Total is the number of articles mentioning the term in the window.
+	Total int
+	// Counts are the daily counts of the term for every day of the window, from the oldest to the newest one.
+	Counts []DailyCount
+}
+
+// Detector detects the trending terms of articles.
+type Detector struct {
+	analyzer *analysis.Analyzer
+	window   time.Duration
+	baseline time.Duration
+	minCount int
+	limit    int
+	now      time.Time
+}
+
+// New creates a new Detector with the default parameters.
+func New() *Detector {
+	return &Detector{
+		analyzer: analysis.Default(),
+		window:   DefaultWindow,
+		baseline: DefaultBaseline,
+		minCount: DefaultMinCount,
+		limit:    DefaultLimit,
+	}
+}
+
+// SetWindow sets the period ending today the trending terms are detected in, rounded up to whole days.
+// Values that are not positive are ignored.
+func (d *Detector) SetWindow(window time.Duration) {
+	if window > 0 {
+		d.window = window
+	}
+}
+
+// SetBaseline sets the period preceding every day the count of the day is compared with, rounded up to whole days.
+// Values that are not positive are ignored.
+func (d *Detector) SetBaseline(baseline time.Duration) {
+	if baseline > 0 {
+		d.baseline = baseline
+	}
+}
+
+// SetMinCount sets the smallest number of articles mentioning a term on a day for it to trend.
+// Values lower than one are ignored.
+func (d *Detector) SetMinCount(minCount int) {
+	if minCount >= 1 {
+		d.minCount = minCount
+	}
+}
+
+// SetLimit sets the largest number of trending terms detected. Zero disables the limit, negative values are ignored.
+func (d *Detector) SetLimit(limit int) {
+	if limit >= 0 {
+		d.limit = limit
+	}
+}
+
+// SetNow sets the time the window ends at. The zero value stands for the current time.
+func (d *Detector) SetNow(now time.Time) {
+	d.now = now
+}
+
+// Detect returns the trending terms of the articles, from the most to the least trending one.
+//
+// The spike of a term on a day is (count - mean) / sqrt(mean + 1), where mean is the average count of the term
+// over the baseline preceding the day. It is the z-score of the count for a Poisson distributed one,
+// smoothed so that terms without a history are ranked by their count. The score of a term is its largest spike
+// over the days of the window it is mentioned in at least the minimal count of articles. A term is not trending
+// if its score is not positive, and a term is left out if it is trending only as a part of a trending bigram.
+func (d *Detector) Detect(articles []article.Article) []Trend {
+	end := day(d.currentTime())
+	windowDays := days(d.window)
+	baselineDays := days(d.baseline)
+	windowStart := end.AddDate(0, 0, 1-windowDays)
+	baselineStart := windowStart.AddDate(0, 0, -baselineDays)
+
+	c := newCounter(d.analyzer)
+	for _, a := range articles {
+		date := time.Time(a.Date())
+		if !date.Before(baselineStart) && date.Before(end.AddDate(0, 0, 1)) {
+			c.add(a)
+		}
+	}
+
+	scores := make(map[string]float64)
+	totals := make(map[string]int)
+
+	for term, counts := range c.counts {
+		score, total, trending := 0.0, 0, false
+
+		for date := windowStart; !date.After(end); date = date.AddDate(0, 0, 1) {
+			count := counts[date]
+			total += count
+			if count < d.minCount {
+				continue
+			}
+
+			sum := 0
+			for i := 1; i <= baselineDays; i++ {
+				sum += counts[date.AddDate(0, 0, -i)]
+			}
+			mean := float64(sum) / float64(baselineDays)
+
+			spike := (float64(count) - mean) / math.Sqrt(mean+1)
+			if !trending || spike > score {
+				score, trending = spike, true
+			}
+		}
+
+		if trending && score > 0 {
+			scores[term] = score
+			totals[term] = total
+		}
+	}
+
+	for term := range scores {
+		words := strings.Split(term, " ")
+		if len(words) != 2 {
+			continue
+		}
+		for _, word := range words {
+			if _, exists := scores[word]; exists && totals[word] == totals[term] {
+				delete(scores, word)
+			}
+		}
+	}
+
+	terms := make([]string, 0, len(scores))
+	for term := range scores {
+		terms = append(terms, term)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if scores[terms[i]] != scores[terms[j]] {
+			return scores[terms[i]] > scores[terms[j]]
+		}
+		if totals[terms[i]] != totals[terms[j]] {
+			return totals[terms[i]] > totals[terms[j]]
+		}
+		return terms[i] < terms[j]
+	})
+
+	if d.limit > 0 && len(terms) > d.limit {
+		terms = terms[:d.limit]
+	}
+
+	trends := make([]Trend, 0, len(terms))
+	for _, term := range terms {
+		trend := Trend{
+			Term:  c.form(term),
+			Score: scores[term],
+			Total: totals[term],
+		}
+		for date := windowStart; !date.After(end); date = date.AddDate(0, 0, 1) {
+			trend.Counts = append(trend.Counts, DailyCount{Date: date, Count: c.counts[term][date]})
+		}
+		trends = append(trends, trend)
+	}
+
+	return trends
+}
+
+func (d *Detector) currentTime() time.Time {
+	if d.now.IsZero() {
+		return time.Now()
+	}
+	return d.now
+}
+
+// days returns the number of days in the duration, rounded up.
+func days(duration time.Duration) int {
+	const fullDay = 24 * time.Hour
+	return int((duration + fullDay - 1) / fullDay)
+}
@@ -0,0 +1,145 @@
+package trend_test
+
+import (
+	"math"
+	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/aggregator/trend"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2024, 5, 19, 18, 0, 0, 0, time.UTC)
+
+func createArticle(title, description string, daysAgo int) article.Article {
+	art, err := article.NewArticleBuilder().
+		SetTitle(article.Title(title)).
+		SetDescription(article.Description(description)).
+		SetDate(article.CreationDate(now.AddDate(0, 0, -daysAgo).Add(-time.Hour))).
+		SetSource(resource.Source("test-source")).
+		Build()
+	if err != nil {
+		panic(err)
+	}
+	return *art
+}
+
+// repeat returns the article written the given number of times on the day.
+func repeat(count int, title, description string, daysAgo int) []article.Article {
+	var articles []article.Article
+	for i := 0; i < count; i++ {
+		articles = append(articles, createArticle(title, description, daysAgo))
+	}
+	return articles
+}
+
+func newDetector() *trend.Detector {
+	detector := trend.New()
+	detector.SetNow(now)
+	detector.SetWindow(2 * 24 * time.Hour)
+	detector.SetBaseline(3 * 24 * time.Hour)
+	return detector
+}
+
+func terms(trends []trend.Trend) []string {
+	var result []string
+	for _, t := range trends {
+		result = append(result, t.Term)
+	}
+	return result
+}
+
+func TestDetector_Detect(t *testing.T) {
+	var articles []article.Article
+	for daysAgo := 0; daysAgo < 5; daysAgo++ {
+		articles = append(articles, repeat(2, "Weather report", "Markets closed", daysAgo)...)
+	}
+	articles = append(articles, repeat(4, "Volcano erupts", "Lava reached the town", 0)...)
+	articles = append(articles, createArticle("Volcanoes of Iceland", ".", 1))
+
+	trends := newDetector().Detect(articles)
+
+	assert.Equal(t, []string{"lava reached", "town", "volcano erupts", "volcano"}, terms(trends))
+
+	eruption := trends[2]
+	assert.Equal(t, 4, eruption.Total)
+	assert.InDelta(t, 4.0, eruption.Score, 1e-9)
+	assert.Equal(t, []trend.DailyCount{
+		{Date: time.Date(2024, 5, 18, 0, 0, 0, 0, time.UTC), Count: 0},
+		{Date: time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC), Count: 4},
+	}, eruption.Counts)
+
+	volcano := trends[3]
+	assert.Equal(t, 5, volcano.Total)
+	assert.InDelta(t, (4-1.0/3)/math.Sqrt(1+1.0/3), volcano.Score, 1e-9)
+}
+
+func TestDetector_Detect_Stemming(t *testing.T) {
+	articles := []article.Article{
+		createArticle("Elections held", ".", 0),
+		createArticle("Elections results", ".", 0),
+		createArticle("Election of a mayor", ".", 0),
+	}
+
+	trends := newDetector().Detect(articles)
+
+	assert.Contains(t, terms(trends), "elections")
+	for _, tr := range trends {
+		if tr.Term == "elections" {
+			assert.Equal(t, 3, tr.Total)
+		}
+	}
+}
+
+func TestDetector_Detect_Window(t *testing.T) {
+	articles := append(repeat(3, "Eclipse", ".", 3), repeat(3, "Derby", ".", 0)...)
+
+	tests := []struct {
+		name     string
+		window   time.Duration
+		expected []string
+	}{
+		{"Default window of the test", 0, []string{"derby"}},
+		{"Window is rounded up to whole days", 73 * time.Hour, []string{"derby", "eclipse"}},
+		{"Window of the last day", time.Hour, []string{"derby"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detector := newDetector()
+			detector.SetWindow(tt.window)
+
+			assert.Equal(t, tt.expected, terms(detector.Detect(articles)))
+		})
+	}
+}
+
+func TestDetector_SetMinCount(t *testing.T) {
+	articles := append(repeat(2, "Eclipse", ".", 0), repeat(3, "Derby", ".", 0)...)
+
+	detector := newDetector()
+	assert.Equal(t, []string{"derby", "eclipse"}, terms(detector.Detect(articles)))
+
+	detector.SetMinCount(3)
+	assert.Equal(t, []string{"derby"}, terms(detector.Detect(articles)))
+
+	detector.SetMinCount(0)
+	assert.Equal(t, []string{"derby"}, terms(detector.Detect(articles)))
+}
+
+func TestDetector_SetLimit(t *testing.T) {
+	articles := append(repeat(2, "Eclipse", ".", 0), repeat(3, "Derby", ".", 0)...)
+
+	detector := newDetector()
+	detector.SetLimit(1)
+	assert.Equal(t, []string{"derby"}, terms(detector.Detect(articles)))
+
+	detector.SetLimit(0)
+	assert.Len(t, detector.Detect(articles), 2)
+}
+
+func TestDetector_Detect_Empty(t *testing.T) {
+	assert.Empty(t, newDetector().Detect(nil))
+}
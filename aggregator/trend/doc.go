@@ -0,0 +1,6 @@
+// Package trend provides the detection of trending terms in article.Article's.
+//
+// The titles and descriptions of the articles are split into terms and bigrams of adjacent terms, see the analysis
+// package, and the number of articles mentioning every term is counted per day. A term trends on a day of the window
+// when its count spikes against the rolling baseline: the average count over the days preceding that day.
+package trend
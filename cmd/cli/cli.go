@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"log"
 	"news-aggregator/aggregator"
+	"news-aggregator/aggregator/cluster"
+	"news-aggregator/aggregator/dedup"
 	"news-aggregator/aggregator/filter"
 	"news-aggregator/aggregator/model/article"
 	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/aggregator/trend"
 	"news-aggregator/manager"
 	"news-aggregator/print"
 	"os"
@@ -15,13 +18,30 @@ import (
 	"strings"
 )
 
+// TrendsCommand is the command printing the trending terms of the articles instead of the articles themselves.
+const TrendsCommand = "trends"
+
+// DiscoverCommand is the command printing the feeds found at a website instead of the articles.
+const DiscoverCommand = "discover"
+
 // CLI is the command line interface for the news aggregator.
 type CLI struct {
 	sourceArg       string
 	keywordsArg     string
+	queryArg        string
 	startDateArg    string
 	endDateArg      string
+	sinceArg        string
+	legacyDatesArg  bool
 	sortOrderArg    string
+	dedupArg        string
+	collapseArg     bool
+	clusterArg      bool
+	trendsCommand   bool
+	windowArg       string
+	limitArg        int
+	discoverCommand bool
+	urlArg          string
 	parserFactory   *aggregator.ParserFactory
 	aggregator      *aggregator.Aggregator
 	resourceManager *manager.ResourceManager
@@ -35,6 +55,7 @@ func New(managerPath, storagePath string) (*CLI, error) {
 	if err != nil {
 		return nil, err
 	}
+	a.SetTolerant(true)
 
 	basePath, err := os.Getwd()
 	if err != nil {
@@ -50,6 +71,11 @@ func New(managerPath, storagePath string) (*CLI, error) {
 		return nil, err
 	}
 
+	err = m.RegisterParsers(parserPool)
+	if err != nil {
+		return nil, err
+	}
+
 	return &CLI{
 		parserFactory:   parserPool,
 		aggregator:      a,
@@ -62,19 +88,82 @@ func New(managerPath, storagePath string) (*CLI, error) {
 // Use cases include filtering news by specific sources, topics, or timeframes.
 // Errors may occur due to incorrect date formats, unrecognized sources, or unknown arguments.
 func (cli *CLI) ParseFlags() {
+	if len(os.Args) > 1 && os.Args[1] == TrendsCommand {
+		cli.parseTrendsFlags(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == DiscoverCommand {
+		cli.parseDiscoverFlags(os.Args[2:])
+		return
+	}
+
 	flag.StringVar(&cli.sourceArg, "sources", "", "Comma-separated list of news sources\n"+
 		"Available sources: "+cli.resourceManager.AvailableSources())
 	flag.StringVar(&cli.keywordsArg, "keywords", "", "Comma-separated list of keywords to filter news articles")
-	flag.StringVar(&cli.startDateArg, "date-start", "", "Start date for filtering news articles (format: yyyy-dd-mm)")
-	flag.StringVar(&cli.endDateArg, "date-end", "", "End date for filtering news articles (format: yyyy-dd-mm)")
-	flag.StringVar(&cli.sortOrderArg, "sort-order", "asc", "Sort order for articles by date (asc/desc)")
+	flag.StringVar(&cli.queryArg, "query", "", "Boolean query to filter news articles\n"+
+		"e.g. 'ukraine AND (grain OR wheat) -opinion title:\"peace talks\"'")
+	flag.StringVar(&cli.startDateArg, "date-start", "", "Start date for filtering news articles\n"+
+		"(YYYY-MM-DD, RFC3339, now, today, yesterday, last-week, last-month or a duration like -24h or 7d)")
+	flag.StringVar(&cli.endDateArg, "date-end", "", "End date for filtering news articles, inclusive\n"+
+		"(same formats as date-start)")
+	flag.StringVar(&cli.sinceArg, "since", "", "Show news articles published within a duration, e.g. 24h, 7d or 2w")
+	flag.BoolVar(&cli.legacyDatesArg, "legacy-dates", false, "Read calendar dates in the legacy yyyy-dd-mm layout")
+	flag.StringVar(&cli.sortOrderArg, "sort-order", "asc", "Sort order for articles by date (asc/desc)\n"+
+		"or by relevance for the keywords and the query (relevance)")
+	flag.StringVar(&cli.dedupArg, "dedup", dedup.ModeOff, "Duplicate articles removal (off/exact/near)")
+	flag.BoolVar(&cli.collapseArg, "collapse", false, "List all sources that carried a duplicate article")
+	flag.BoolVar(&cli.clusterArg, "cluster", false, "Group news articles about the same event into stories")
 	flag.Usage = cli.printUsage
 	flag.Parse()
 }
 
+// parseTrendsFlags parses the command line flags of the trends command.
+func (cli *CLI) parseTrendsFlags(args []string) {
+	cli.trendsCommand = true
+
+	flags := flag.NewFlagSet(TrendsCommand, flag.ExitOnError)
+	flags.StringVar(&cli.sourceArg, "sources", "", "Comma-separated list of news sources\n"+
+		"Available sources: "+cli.resourceManager.AvailableSources())
+	flags.StringVar(&cli.windowArg, "window", "7d", "Period the terms trend in, e.g. 24h, 7d or 2w")
+	flags.IntVar(&cli.limitArg, "limit", trend.DefaultLimit, "Largest number of trending terms, 0 for all of them")
+	flags.Usage = func() {
+		fmt.Println("Usage: NewsAggregator trends [options]")
+		fmt.Println("Prints the terms whose daily mentions spike against the preceding days.")
+		fmt.Println("\nOptions:")
+		flags.PrintDefaults()
+	}
+
+	_ = flags.Parse(args)
+}
+
+// parseDiscoverFlags parses the command line flags of the discover command,
+// the URL may be given as the flag or as the argument.
+func (cli *CLI) parseDiscoverFlags(args []string) {
+	cli.discoverCommand = true
+
+	flags := flag.NewFlagSet(DiscoverCommand, flag.ExitOnError)
+	flags.StringVar(&cli.urlArg, "url", "", "URL of the website to find the feeds of, e.g. https://www.bbc.com")
+	flags.Usage = func() {
+		fmt.Println("Usage: NewsAggregator discover [options] [url]")
+		fmt.Println("Prints the feeds advertised by the website or served at the common feed paths,")
+		fmt.Println("from the most to the least suitable one.")
+		fmt.Println("\nOptions:")
+		flags.PrintDefaults()
+	}
+
+	_ = flags.Parse(args)
+	if cli.urlArg == "" {
+		cli.urlArg = flags.Arg(0)
+	}
+}
+
 // Run executes the CLI application.
 // This CLI will print the articles to the console based on the provided flags.
 func (cli *CLI) Run() error {
+	if cli.discoverCommand {
+		return cli.showFeeds()
+	}
+
 	if cli.checkAvailableSources() {
 		return fmt.Errorf("no sources available")
 	}
@@ -85,7 +174,14 @@ func (cli *CLI) Run() error {
 		return fmt.Errorf("too many flags provided")
 	}
 
-	if flagCount == 0 {
+	err := cli.setDeduplicator()
+	if err != nil {
+		return err
+	}
+
+	if cli.trendsCommand {
+		cli.showTrends()
+	} else if flagCount == 0 {
 		cli.showAllArticles()
 	} else {
 		cli.showFilteredArticles()
@@ -94,6 +190,27 @@ func (cli *CLI) Run() error {
 	return nil
 }
 
+// setDeduplicator sets the deduplicator of the requested mode to the aggregator,
+// duplicates are kept by default.
+func (cli *CLI) setDeduplicator() error {
+	mode := cli.dedupArg
+	if mode == "" {
+		mode = dedup.ModeOff
+	}
+
+	deduplicator, err := dedup.NewForMode(mode)
+	if err != nil {
+		return err
+	}
+
+	if deduplicator != nil {
+		deduplicator.SetCollapse(cli.collapseArg)
+		cli.aggregator.SetDeduplicator(deduplicator)
+	}
+
+	return nil
+}
+
 func (cli *CLI) checkAvailableSources() bool {
 	if cli.resourceManager.AvailableSources() == "" {
 		cli.printer.Warn("No sources available")
@@ -116,10 +233,8 @@ func (cli *CLI) showAllArticles() {
 		cli.printer.Error(err.Error())
 	}
 
-	articles, err := cli.aggregator.AggregateMultiple(resources)
-	if err != nil {
-		cli.printer.Error(err.Error())
-	}
+	articles, report := cli.aggregator.AggregateConcurrently(resources)
+	cli.printReport(report)
 
 	articles = cli.sortArticles(articles)
 	cli.printArticles(articles)
@@ -134,15 +249,56 @@ func (cli *CLI) showFilteredArticles() {
 		return
 	}
 
-	filteredArticles, err := cli.aggregator.AggregateMultiple(resources)
+	filteredArticles, report := cli.aggregator.AggregateConcurrently(resources)
+	cli.printReport(report)
+
+	filteredArticles = cli.sortArticles(filteredArticles)
+	cli.printArticles(filteredArticles)
+}
+
+func (cli *CLI) showTrends() {
+	window, err := filter.ParseDuration(cli.windowArg)
+	if err != nil || window == 0 {
+		cli.printer.Error(fmt.Sprintf("invalid window \"%s\"", cli.windowArg))
+		return
+	}
+
+	detector := trend.New()
+	detector.SetWindow(window)
+	detector.SetLimit(cli.limitArg)
+
+	articles, report := cli.aggregator.AggregateConcurrently(cli.getResources())
+	cli.printReport(report)
+
+	params := print.FilterParams{
+		SourceArg:    cli.sourceArg,
+		StartDateArg: "-" + strings.TrimPrefix(cli.windowArg, "-"),
+		OrderArg:     TrendsCommand,
+	}
+
+	err = cli.printer.PrintTrends(detector.Detect(articles), params)
+	if err != nil {
+		cli.printer.Error(err.Error())
+	}
+}
 
+// showFeeds prints the feeds found at the website of the URL argument.
+func (cli *CLI) showFeeds() error {
+	feeds, err := cli.resourceManager.DiscoverFeeds(cli.urlArg)
 	if err != nil {
 		cli.printer.Error(err.Error())
-		return
+		return err
 	}
 
-	filteredArticles = cli.sortArticles(filteredArticles)
-	cli.printArticles(filteredArticles)
+	if len(feeds) == 0 {
+		cli.printer.Warn("No feeds found at " + cli.urlArg)
+		return nil
+	}
+
+	for i, feed := range feeds {
+		cli.printer.Log(fmt.Sprintf("%d. %s", i+1, feed))
+	}
+	return nil
 }
 
 func (cli *CLI) sortArticles(articles []article.Article) []article.Article {
@@ -151,12 +307,37 @@ func (cli *CLI) sortArticles(articles []article.Article) []article.Article {
 		return aggregator.SortArticlesByDateAsc(articles)
 	} else if cli.sortOrderArg == "desc" {
 		return aggregator.SortArticlesByDateDesc(articles)
+	} else if cli.sortOrderArg == "relevance" {
+		scored := aggregator.SortArticlesByRelevance(articles, cli.searchTerms())
+		sortedArticles := make([]article.Article, len(scored))
+		for i, s := range scored {
+			sortedArticles[i] = s.Article
+		}
+		return sortedArticles
 	} else {
 		cli.printer.Error("Unknown sort order")
 		return articles
 	}
 }
 
+// searchTerms returns the keywords and the terms of the query the articles are searched for.
+func (cli *CLI) searchTerms() []string {
+	var terms []string
+
+	if cli.keywordsArg != "" {
+		terms = append(terms, strings.Split(cli.keywordsArg, ",")...)
+	}
+
+	if cli.queryArg != "" {
+		queryFilter, err := filter.NewQueryFilter(cli.queryArg)
+		if err == nil {
+			terms = append(terms, queryFilter.Terms()...)
+		}
+	}
+
+	return terms
+}
+
 func (cli *CLI) getResources() []resource.Resource {
 	if cli.sourceArg == "" {
 		resources, err := cli.resourceManager.GetAllResources()
@@ -177,24 +358,41 @@ func (cli *CLI) getResources() []resource.Resource {
 }
 
 func (cli *CLI) applyFilters() error {
+	dateParser := filter.NewDateExpressionParser()
+	dateParser.SetLegacyLayout(cli.legacyDatesArg)
+
+	if cli.startDateArg != "" && cli.sinceArg != "" {
+		return fmt.Errorf("date-start and since cannot be used together")
+	}
+
 	if cli.startDateArg != "" {
-		startDateFilter, err := filter.NewStartDateFilter(cli.startDateArg)
+		startDate, err := dateParser.ParseStart(cli.startDateArg)
 
 		if err != nil {
 			return err
 		}
 
-		cli.aggregator.AddFilter(startDateFilter)
+		cli.aggregator.AddFilter(filter.NewStartDateFilterFromTime(startDate))
+	}
+
+	if cli.sinceArg != "" {
+		startDate, err := dateParser.ParseSince(cli.sinceArg)
+
+		if err != nil {
+			return err
+		}
+
+		cli.aggregator.AddFilter(filter.NewStartDateFilterFromTime(startDate))
 	}
 
 	if cli.endDateArg != "" {
-		endDateFilter, err := filter.NewEndDateFilter(cli.endDateArg)
+		endDate, err := dateParser.ParseEnd(cli.endDateArg)
 
 		if err != nil {
 			return err
 		}
 
-		cli.aggregator.AddFilter(endDateFilter)
+		cli.aggregator.AddFilter(filter.NewEndDateFilterFromTime(endDate))
 	}
 
 	if cli.keywordsArg != "" {
@@ -202,20 +400,41 @@ func (cli *CLI) applyFilters() error {
 		cli.aggregator.AddFilter(filter.NewKeywordFilter(keywords))
 	}
 
+	if cli.queryArg != "" {
+		queryFilter, err := filter.NewQueryFilter(cli.queryArg)
+
+		if err != nil {
+			return err
+		}
+
+		cli.aggregator.AddFilter(queryFilter)
+	}
+
 	return nil
 }
 
 func (cli *CLI) printArticles(articles []article.Article) {
 
+	startDateArg := cli.startDateArg
+	if cli.sinceArg != "" {
+		startDateArg = "-" + strings.TrimPrefix(cli.sinceArg, "-")
+	}
+
 	params := print.FilterParams{
 		SourceArg:    cli.sourceArg,
 		KeywordsArg:  cli.keywordsArg,
-		StartDateArg: cli.startDateArg,
+		QueryArg:     cli.queryArg,
+		StartDateArg: startDateArg,
 		EndDateArg:   cli.endDateArg,
 		OrderArg:     cli.sortOrderArg,
 	}
 
-	err := cli.printer.PrintArticles(articles, params)
+	var err error
+	if cli.clusterArg {
+		err = cli.printer.PrintClusters(cluster.New().Cluster(articles), params)
+	} else {
+		err = cli.printer.PrintArticles(articles, params)
+	}
 
 	if err != nil {
 		cli.printer.Error(err.Error())
@@ -223,6 +442,15 @@ func (cli *CLI) printArticles(articles []article.Article) {
 	}
 }
 
+func (cli *CLI) printReport(report aggregator.Report) {
+	for _, sourceErr := range report.Errors {
+		cli.printer.Warn("Failed to aggregate " + sourceErr.Error())
+	}
+	for _, warning := range report.Warnings {
+		cli.printer.Warn("Skipped invalid item of " + warning.String())
+	}
+}
+
 func (cli *CLI) printUsage() {
 	fmt.Println("Usage: NewsAggregator [options]")
 	fmt.Println("If no options are provided, all available articles will be printed.")
@@ -232,6 +460,14 @@ func (cli *CLI) printUsage() {
 	fmt.Println("\nYou can use multiple flags in any order. Example usage:")
 	fmt.Println("  NewsAggregator -sources=source1,source2 -keywords=keyword1,keyword2 -date-start=2024-01-01")
 	fmt.Println("  NewsAggregator -keywords=keyword1,keyword2")
+	fmt.Println("  NewsAggregator -query='ukraine AND (grain OR wheat) -opinion'")
 	fmt.Println("  NewsAggregator -date-start=2024-01-01 -date-end=2024-12-31")
+	fmt.Println("  NewsAggregator -date-start=last-week -date-end=yesterday")
+	fmt.Println("  NewsAggregator -since=24h")
 	fmt.Println("  NewsAggregator -sort-order=asc")
+	fmt.Println("  NewsAggregator -keywords=ukraine -sort-order=relevance")
+	fmt.Println("  NewsAggregator -dedup=near -collapse")
+	fmt.Println("  NewsAggregator -since=24h -cluster")
+	fmt.Println("  NewsAggregator trends -window=7d -sources=source1,source2")
+	fmt.Println("  NewsAggregator discover https://www.bbc.com")
 }
@@ -2,6 +2,9 @@ package cli
 
 import (
 	"flag"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
@@ -128,7 +131,7 @@ func TestRunWithParams(t *testing.T) {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	os.Args = []string{"-sources=source", "-keywords=keyword", "-date-start=2024-01-01", "-date-end=2024-31-12", "-sort-order=asc"}
+	os.Args = []string{"-sources=source", "-keywords=keyword", "-date-start=2024-01-01", "-date-end=2024-12-31", "-sort-order=asc"}
 	cli.ParseFlags()
 
 	err = cli.Run()
@@ -139,3 +142,132 @@ func TestRunWithParams(t *testing.T) {
 		t.Fatalf("Failed to return to test directory")
 	}
 }
+
+// TestParseFlags_Trends checks if the flags of the trends command are parsed correctly and the command runs.
+// This test runs in the project root directory to test the relative paths.
+func TestParseFlags_Trends(t *testing.T) {
+	resetFlags()
+	if err := changeToProjectRoot(); err != nil {
+		t.Fatalf("Failed to change to project root: %v", err)
+	}
+	cli, err := New("config/feeds_dictionary.json", "resources")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	os.Args = []string{"cmd", TrendsCommand, "-sources=bbc-world", "-window=2d", "-limit=3"}
+	cli.ParseFlags()
+
+	if !cli.trendsCommand {
+		t.Errorf("Expected trendsCommand to be set")
+	}
+	if cli.sourceArg != "bbc-world" {
+		t.Errorf("Expected sourceArg to be 'bbc-world', got '%v'", cli.sourceArg)
+	}
+	if cli.windowArg != "2d" {
+		t.Errorf("Expected windowArg to be '2d', got '%v'", cli.windowArg)
+	}
+	if cli.limitArg != 3 {
+		t.Errorf("Expected limitArg to be 3, got '%v'", cli.limitArg)
+	}
+
+	err = cli.Run()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if returnToTestDir() != nil {
+		t.Fatalf("Failed to return to test directory")
+	}
+}
+
+// TestParseFlags_Discover checks if the URL of the discover command is parsed and the feeds are discovered.
+// This test runs in the project root directory to test the relative paths.
+func TestParseFlags_Discover(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			_, _ = w.Write([]byte(`<html><head><link rel="alternate" type="application/feed+json" ` +
+				`href="/feed.json"></head></html>`))
+		case "/feed.json":
+			_, _ = w.Write([]byte(`{"version": "https://jsonfeed.org/version/1.1", "title": "Test Feed", ` +
+				`"items": [{"id": "1", "title": "Test Title", "summary": "Test Description", ` +
+				`"url": "http://example.com", "date_published": "2024-05-19T10:00:00Z"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resetFlags()
+	if err := changeToProjectRoot(); err != nil {
+		t.Fatalf("Failed to change to project root: %v", err)
+	}
+	defer func() {
+		if returnToTestDir() != nil {
+			t.Fatalf("Failed to return to test directory")
+		}
+	}()
+
+	feed := "[Log] 1. JSONFEED \"Test Feed\" " + server.URL + "/feed.json (1 articles)\n"
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+		wantErr  bool
+	}{
+		{"url argument", []string{"cmd", DiscoverCommand, server.URL + "/"}, feed, false},
+		{"url flag", []string{"cmd", DiscoverCommand, "-url=" + server.URL + "/"}, feed, false},
+		{"missing page", []string{"cmd", DiscoverCommand, server.URL + "/missing"},
+			"[Error] error fetching resource from link: status code 404\n", true},
+		{"missing url", []string{"cmd", DiscoverCommand}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli, err := New("config/feeds_dictionary.json", "resources")
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			os.Args = tt.args
+			cli.ParseFlags()
+
+			if !cli.discoverCommand {
+				t.Errorf("Expected discoverCommand to be set")
+			}
+
+			output := captureOutput(t, func() {
+				err = cli.Run()
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.expected != "" && output != tt.expected {
+				t.Errorf("Expected output '%v', got '%v'", tt.expected, output)
+			}
+		})
+	}
+}
+
+// captureOutput returns what the function prints to the standard output.
+func captureOutput(t *testing.T, f func()) string {
+	old := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	os.Stdout = w
+	defer func() { os.Stdout = old }()
+
+	f()
+
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to close pipe: %v", err)
+	}
+	output, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	return string(output)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"news-aggregator/manager"
+	"news-aggregator/storage"
+)
+
+const (
+	defaultResourcesPath = "./resources"
+	defaultFeedsConfig   = "./config/feeds_dictionary.json"
+	defaultDBPath        = "./resources/articles.db"
+)
+
+func main() {
+	feedsConfig := flag.String("feeds-config", defaultFeedsConfig, "[Optional] Path to the feeds config file")
+	resourcesPath := flag.String("resources-path", defaultResourcesPath, "[Optional] Path to the resources directory")
+	dbPath := flag.String("db", defaultDBPath, "[Optional] Path to the database to import the resources into")
+	flag.Usage = printUsage
+	flag.Parse()
+
+	db, err := storage.NewBolt(*dbPath)
+	if err != nil {
+		log.Fatalf("Error of storage creation: %v", err)
+	}
+	defer func(db *storage.BoltStorage) {
+		err := db.Close()
+		if err != nil {
+			log.Printf("Error of storage closing: %v", err)
+		}
+	}(db)
+
+	m, err := manager.NewWithStorage(db, *feedsConfig)
+	if err != nil {
+		log.Printf("Error of resource manager creation: %v", err)
+		return
+	}
+
+	results, err := m.Import(storage.New(*resourcesPath))
+
+	imported := 0
+	for _, result := range results {
+		imported += result.Imported
+		switch {
+		case result.Skipped:
+			log.Printf("Skipped source %s without stored resources", result.Source)
+		case result.Err != nil:
+			log.Printf("Failed to import source %s: %v", result.Source, result.Err)
+		}
+	}
+
+	log.Printf("Imported %d resources into %s", imported, *dbPath)
+
+	if err != nil {
+		log.Printf("Error of resources import, run the migration again to retry the failed sources: %v", err)
+	}
+}
+
+func printUsage() {
+	log.Println("Usage: migrate [options]")
+	log.Println("Imports the resources of the registered sources into the database of the bolt storage backend.")
+	log.Println("Options:")
+	flag.PrintDefaults()
+	log.Println("Example: migrate -feeds-config=feeds.json -resources-path=./resources -db=./resources/articles.db")
+}
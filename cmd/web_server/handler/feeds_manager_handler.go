@@ -2,8 +2,12 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/aggregator/parser"
+	"news-aggregator/manager"
+	"news-aggregator/schedule"
 )
 
 // FeedsManagerHandler handles requests for managing news sources.
@@ -46,11 +50,18 @@ func (ch *FeedsManagerHandler) GetSources(w http.ResponseWriter) {
 }
 
 // AddSource handles POST /sources to add a new source.
+// The source may be accompanied by the jsonMapping or htmlSelectors describing its structure
+// and by the schedule of its updates.
+// The link of the source is probed before the registration and the source is rejected with
+// 422 Unprocessable Entity and the probe report if no articles are parsed from its content.
+// With the dryRun query parameter set to true the source is only probed, not registered.
 func (ch *FeedsManagerHandler) AddSource(w http.ResponseWriter, r *http.Request) {
 	var source struct {
-		Name   string `json:"name"`
-		URL    string `json:"url"`
-		Format string `json:"format"`
+		Name     string `json:"name"`
+		URL      string `json:"url"`
+		Format   string `json:"format"`
+		Schedule string `json:"schedule"`
+		parser.Config
 	}
 	if err := json.NewDecoder(r.Body).Decode(&source); err != nil {
 		http.Error(w, "Invalid request payload", http.StatusBadRequest)
@@ -64,21 +75,62 @@ func (ch *FeedsManagerHandler) AddSource(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	err = ch.manager.RegisterSource(resource.Source(source.Name), source.URL, format)
+	err = source.Config.Validate(format)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !validSchedule(w, source.Schedule) {
+		return
+	}
+
+	name := resource.Source(source.Name)
+	err = manager.ValidateSource(name, source.URL)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if ch.manager.IsSourceSupported(name) {
+		http.Error(w, "Source already exists", http.StatusConflict)
+		return
+	}
+
+	report, err := ch.manager.ProbeSource(name, source.URL, format, source.Config)
+	if err != nil {
+		writeProbeReport(w, http.StatusUnprocessableEntity, report, err)
+		return
+	}
+
+	if r.URL.Query().Get("dryRun") == "true" {
+		writeProbeReport(w, http.StatusOK, report, nil)
+		return
+	}
+
+	err = ch.manager.RegisterScheduledSource(name, source.URL, format, source.Config, source.Schedule)
+	if errors.Is(err, manager.ErrSourceExists) {
+		http.Error(w, "Source already exists", http.StatusConflict)
+		return
+	}
 	if err != nil {
 		http.Error(w, "Failed to add source", http.StatusInternalServerError)
 		return
 	}
 
-	w.WriteHeader(http.StatusCreated)
+	writeProbeReport(w, http.StatusCreated, report, nil)
 }
 
 // UpdateSource handles PUT /sources to update an existing source.
+// The schedule of the source is kept unless a new one is given.
+// The new link and format are probed before the update as when adding a source, see AddSource,
+// and with the dryRun query parameter set to true the source is only probed, not updated.
 func (ch *FeedsManagerHandler) UpdateSource(w http.ResponseWriter, r *http.Request) {
 	var source struct {
-		Name   string `json:"name"`
-		URL    string `json:"url"`
-		Format string `json:"format"`
+		Name     string `json:"name"`
+		URL      string `json:"url"`
+		Format   string `json:"format"`
+		Schedule string `json:"schedule"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&source); err != nil {
@@ -93,14 +145,43 @@ func (ch *FeedsManagerHandler) UpdateSource(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	err = ch.manager.UpdateSource(resource.Source(source.Name), source.URL, format)
+	if !validSchedule(w, source.Schedule) {
+		return
+	}
+
+	name := resource.Source(source.Name)
+	err = manager.ValidateSource(name, source.URL)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := ch.manager.ProbeSourceUpdate(name, source.URL, format)
+	if errors.Is(err, manager.ErrSourceNotFound) {
+		http.Error(w, "Source not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		writeProbeReport(w, http.StatusUnprocessableEntity, report, err)
+		return
+	}
 
+	if r.URL.Query().Get("dryRun") == "true" {
+		writeProbeReport(w, http.StatusOK, report, nil)
+		return
+	}
+
+	err = ch.manager.UpdateScheduledSource(name, source.URL, format, source.Schedule)
+	if errors.Is(err, manager.ErrSourceNotFound) {
+		http.Error(w, "Source not found", http.StatusNotFound)
+		return
+	}
 	if err != nil {
 		http.Error(w, "Failed to update source", http.StatusInternalServerError)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	writeProbeReport(w, http.StatusOK, report, nil)
 }
 
 // DeleteSource handles DELETE /sources to delete a source.
@@ -113,9 +194,54 @@ func (ch *FeedsManagerHandler) DeleteSource(w http.ResponseWriter, r *http.Reque
 		return
 	}
 	err := ch.manager.DeleteSource(resource.Source(source.Name))
+	if errors.Is(err, manager.ErrSourceNotFound) {
+		http.Error(w, "Source not found", http.StatusNotFound)
+		return
+	}
 	if err != nil {
 		http.Error(w, "Failed to delete source", http.StatusInternalServerError)
 		return
 	}
 	w.WriteHeader(http.StatusOK)
 }
+
+// validSchedule reports whether the schedule is empty or valid, responding with 400 Bad Request otherwise.
+func validSchedule(w http.ResponseWriter, spec string) bool {
+	if spec == "" {
+		return true
+	}
+
+	if _, err := schedule.Parse(spec); err != nil {
+		http.Error(w, "Invalid schedule: "+err.Error(), http.StatusBadRequest)
+		return false
+	}
+	return true
+}
+
+// writeProbeReport writes the report of probing a source along with the error of the probe, if any.
+func writeProbeReport(w http.ResponseWriter, status int, report manager.ProbeReport, probeErr error) {
+	warnings := report.Warnings
+	if warnings == nil {
+		warnings = []string{}
+	}
+
+	values := map[string]interface{}{
+		"url":            report.URL,
+		"statusCode":     report.StatusCode,
+		"contentType":    report.ContentType,
+		"detectedFormat": resource.FormatToString(report.DetectedFormat),
+		"format":         resource.FormatToString(report.Format),
+		"bytesFetched":   report.BytesFetched,
+		"articlesParsed": report.ArticlesParsed,
+		"warnings":       warnings,
+	}
+	if probeErr != nil {
+		values["error"] = probeErr.Error()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(values); err != nil {
+		http.Error(w, "Failed to encode probe report", http.StatusInternalServerError)
+	}
+}
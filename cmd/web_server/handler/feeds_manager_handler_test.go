@@ -8,7 +8,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/aggregator/parser"
 	"news-aggregator/cmd/web_server/handler/mocks"
+	"news-aggregator/manager"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -51,8 +53,12 @@ func TestControlHandler_AddSource(t *testing.T) {
 
 	t.Run("success", func(t *testing.T) {
 		mockManager := mocks.NewMockResourceManager(ctrl)
+		mockManager.EXPECT().IsSourceSupported(resource.Source("source1")).Return(false)
 		mockManager.EXPECT().
-			RegisterSource(resource.Source("source1"), "http://example.com", resource.Format(3)).
+			ProbeSource(resource.Source("source1"), "http://example.com", resource.Format(3), parser.Config{}).
+			Return(manager.ProbeReport{ArticlesParsed: 1}, nil)
+		mockManager.EXPECT().
+			RegisterScheduledSource(resource.Source("source1"), "http://example.com", resource.Format(3), parser.Config{}, "").
 			Return(nil)
 
 		handler := NewFeedsManagerHandler(mockManager)
@@ -127,10 +133,78 @@ func TestControlHandler_AddSource(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 	})
 
+	t.Run("success with html selectors", func(t *testing.T) {
+		selectors := &parser.HTMLSelectors{
+			Item:  "article",
+			Title: parser.HTMLField{Selector: "h2"},
+			Date:  parser.HTMLField{Selector: "time", Attribute: "datetime"},
+			Link:  &parser.HTMLField{Selector: "a", Attribute: "href"},
+		}
+
+		mockManager := mocks.NewMockResourceManager(ctrl)
+		mockManager.EXPECT().IsSourceSupported(resource.Source("source1")).Return(false)
+		mockManager.EXPECT().
+			ProbeSource(resource.Source("source1"), "http://example.com", resource.Format(resource.HTML),
+				parser.Config{HTMLSelectors: selectors}).
+			Return(manager.ProbeReport{ArticlesParsed: 1}, nil)
+		mockManager.EXPECT().
+			RegisterScheduledSource(resource.Source("source1"), "http://example.com", resource.Format(resource.HTML),
+				parser.Config{HTMLSelectors: selectors}, "").
+			Return(nil)
+
+		handler := NewFeedsManagerHandler(mockManager)
+
+		body := []byte(`{"name": "source1", "url": "http://example.com", "format": "html", "htmlSelectors": {
+			"item": "article", "title": {"selector": "h2"}, "date": {"selector": "time", "attribute": "datetime"},
+			"link": {"selector": "a", "attribute": "href"}}}`)
+
+		req := httptest.NewRequest(http.MethodPost, "/sources", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.Handle(w, req)
+
+		resp := w.Result()
+		defer func(Body io.ReadCloser) {
+			err := Body.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+		}(resp.Body)
+
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	})
+
+	t.Run("invalid parser config", func(t *testing.T) {
+		mockManager := mocks.NewMockResourceManager(ctrl)
+		handler := NewFeedsManagerHandler(mockManager)
+
+		body := []byte(`{"name": "source1", "url": "http://example.com", "format": "rss",
+			"jsonMapping": {"title": "title", "description": "description", "date": "date"}}`)
+
+		req := httptest.NewRequest(http.MethodPost, "/sources", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.Handle(w, req)
+
+		resp := w.Result()
+		defer func(Body io.ReadCloser) {
+			err := Body.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+		}(resp.Body)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
 	t.Run("registration error", func(t *testing.T) {
 		mockManager := mocks.NewMockResourceManager(ctrl)
+		mockManager.EXPECT().IsSourceSupported(resource.Source("source1")).Return(false)
+		mockManager.EXPECT().
+			ProbeSource(resource.Source("source1"), "http://example.com", resource.Format(3), parser.Config{}).
+			Return(manager.ProbeReport{ArticlesParsed: 1}, nil)
 		mockManager.EXPECT().
-			RegisterSource(resource.Source("source1"), "http://example.com", resource.Format(3)).
+			RegisterScheduledSource(resource.Source("source1"), "http://example.com", resource.Format(3), parser.Config{}, "").
 			Return(fmt.Errorf("registration error"))
 
 		handler := NewFeedsManagerHandler(mockManager)
@@ -159,6 +233,143 @@ func TestControlHandler_AddSource(t *testing.T) {
 	})
 }
 
+func TestControlHandler_AddSource_Probe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	report := manager.ProbeReport{
+		URL:            "http://example.com",
+		StatusCode:     http.StatusOK,
+		ContentType:    "application/rss+xml",
+		DetectedFormat: resource.RSS,
+		Format:         resource.RSS,
+		BytesFetched:   512,
+		ArticlesParsed: 2,
+	}
+	emptyReport := report
+	emptyReport.ArticlesParsed = 0
+	emptyReport.Warnings = []string{"item 1: missing title"}
+
+	tests := []struct {
+		name           string
+		target         string
+		sourceName     string
+		url            string
+		mockSetup      func(m *mocks.MockResourceManager)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+	}{
+		{"created with report", "/sources", "source1", "http://example.com", func(m *mocks.MockResourceManager) {
+			m.EXPECT().IsSourceSupported(resource.Source("source1")).Return(false)
+			m.EXPECT().ProbeSource(resource.Source("source1"), "http://example.com", resource.Format(resource.RSS),
+				parser.Config{}).Return(report, nil)
+			m.EXPECT().RegisterScheduledSource(resource.Source("source1"), "http://example.com",
+				resource.Format(resource.RSS), parser.Config{}, "").Return(nil)
+		}, http.StatusCreated, map[string]interface{}{
+			"url": "http://example.com", "statusCode": float64(200), "contentType": "application/rss+xml",
+			"detectedFormat": "RSS", "format": "RSS", "bytesFetched": float64(512), "articlesParsed": float64(2),
+			"warnings": []interface{}{},
+		}},
+		{"dry run", "/sources?dryRun=true", "source1", "http://example.com", func(m *mocks.MockResourceManager) {
+			m.EXPECT().IsSourceSupported(resource.Source("source1")).Return(false)
+			m.EXPECT().ProbeSource(resource.Source("source1"), "http://example.com", resource.Format(resource.RSS),
+				parser.Config{}).Return(report, nil)
+		}, http.StatusOK, map[string]interface{}{
+			"url": "http://example.com", "statusCode": float64(200), "contentType": "application/rss+xml",
+			"detectedFormat": "RSS", "format": "RSS", "bytesFetched": float64(512), "articlesParsed": float64(2),
+			"warnings": []interface{}{},
+		}},
+		{"no articles parsed", "/sources", "source1", "http://example.com", func(m *mocks.MockResourceManager) {
+			m.EXPECT().IsSourceSupported(resource.Source("source1")).Return(false)
+			m.EXPECT().ProbeSource(resource.Source("source1"), "http://example.com", resource.Format(resource.RSS),
+				parser.Config{}).Return(emptyReport, fmt.Errorf("no articles parsed from resource content as RSS"))
+		}, http.StatusUnprocessableEntity, map[string]interface{}{
+			"url": "http://example.com", "statusCode": float64(200), "contentType": "application/rss+xml",
+			"detectedFormat": "RSS", "format": "RSS", "bytesFetched": float64(512), "articlesParsed": float64(0),
+			"warnings": []interface{}{"item 1: missing title"},
+			"error":    "no articles parsed from resource content as RSS",
+		}},
+		{"duplicate name", "/sources", "source1", "http://example.com", func(m *mocks.MockResourceManager) {
+			m.EXPECT().IsSourceSupported(resource.Source("source1")).Return(true)
+		}, http.StatusConflict, nil},
+		{"duplicate name on registration", "/sources", "source1", "http://example.com",
+			func(m *mocks.MockResourceManager) {
+				m.EXPECT().IsSourceSupported(resource.Source("source1")).Return(false)
+				m.EXPECT().ProbeSource(resource.Source("source1"), "http://example.com", resource.Format(resource.RSS),
+					parser.Config{}).Return(report, nil)
+				m.EXPECT().RegisterScheduledSource(resource.Source("source1"), "http://example.com",
+					resource.Format(resource.RSS), parser.Config{}, "").
+					Return(fmt.Errorf("%w: source1", manager.ErrSourceExists))
+			}, http.StatusConflict, nil},
+		{"invalid name", "/sources", "../source1", "http://example.com", func(m *mocks.MockResourceManager) {},
+			http.StatusBadRequest, nil},
+		{"invalid url", "/sources", "source1", "ftp://example.com", func(m *mocks.MockResourceManager) {},
+			http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockManager := mocks.NewMockResourceManager(ctrl)
+			tt.mockSetup(mockManager)
+			handler := NewFeedsManagerHandler(mockManager)
+
+			body, _ := json.Marshal(map[string]string{
+				"name":   tt.sourceName,
+				"url":    tt.url,
+				"format": "rss",
+			})
+
+			req := httptest.NewRequest(http.MethodPost, tt.target, bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				var values map[string]interface{}
+				assert.NoError(t, json.NewDecoder(w.Body).Decode(&values))
+				assert.Equal(t, tt.expectedBody, values)
+			}
+		})
+	}
+}
+
+func TestControlHandler_UnknownSource(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	notFound := fmt.Errorf("%w: source1", manager.ErrSourceNotFound)
+
+	t.Run("update", func(t *testing.T) {
+		mockManager := mocks.NewMockResourceManager(ctrl)
+		mockManager.EXPECT().
+			ProbeSourceUpdate(resource.Source("source1"), "http://example.com", resource.Format(resource.RSS)).
+			Return(manager.ProbeReport{}, notFound)
+		handler := NewFeedsManagerHandler(mockManager)
+
+		body := []byte(`{"name": "source1", "url": "http://example.com", "format": "rss"}`)
+		req := httptest.NewRequest(http.MethodPut, "/sources", bytes.NewReader(body))
+		w := httptest.NewRecorder()
+
+		handler.Handle(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		mockManager := mocks.NewMockResourceManager(ctrl)
+		mockManager.EXPECT().DeleteSource(resource.Source("source1")).Return(notFound)
+		handler := NewFeedsManagerHandler(mockManager)
+
+		req := httptest.NewRequest(http.MethodDelete, "/sources", bytes.NewReader([]byte(`{"name": "source1"}`)))
+		w := httptest.NewRecorder()
+
+		handler.Handle(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
 func TestControlHandler_UpdateSource(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -166,7 +377,10 @@ func TestControlHandler_UpdateSource(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		mockManager := mocks.NewMockResourceManager(ctrl)
 		mockManager.EXPECT().
-			UpdateSource(resource.Source("source1"), "http://example.com", resource.Format(3)).
+			ProbeSourceUpdate(resource.Source("source1"), "http://example.com", resource.Format(3)).
+			Return(manager.ProbeReport{ArticlesParsed: 1}, nil)
+		mockManager.EXPECT().
+			UpdateScheduledSource(resource.Source("source1"), "http://example.com", resource.Format(3), "").
 			Return(nil)
 
 		handler := NewFeedsManagerHandler(mockManager)
@@ -244,7 +458,10 @@ func TestControlHandler_UpdateSource(t *testing.T) {
 	t.Run("update error", func(t *testing.T) {
 		mockManager := mocks.NewMockResourceManager(ctrl)
 		mockManager.EXPECT().
-			UpdateSource(resource.Source("source1"), "http://example.com", resource.Format(3)).
+			ProbeSourceUpdate(resource.Source("source1"), "http://example.com", resource.Format(3)).
+			Return(manager.ProbeReport{ArticlesParsed: 1}, nil)
+		mockManager.EXPECT().
+			UpdateScheduledSource(resource.Source("source1"), "http://example.com", resource.Format(3), "").
 			Return(fmt.Errorf("update error"))
 
 		handler := NewFeedsManagerHandler(mockManager)
@@ -273,6 +490,151 @@ func TestControlHandler_UpdateSource(t *testing.T) {
 	})
 }
 
+func TestControlHandler_UpdateSource_Probe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	report := manager.ProbeReport{
+		URL:            "http://example.com",
+		StatusCode:     http.StatusOK,
+		ContentType:    "application/rss+xml",
+		DetectedFormat: resource.RSS,
+		Format:         resource.RSS,
+		BytesFetched:   512,
+		ArticlesParsed: 2,
+	}
+	reportBody := map[string]interface{}{
+		"url": "http://example.com", "statusCode": float64(200), "contentType": "application/rss+xml",
+		"detectedFormat": "RSS", "format": "RSS", "bytesFetched": float64(512), "articlesParsed": float64(2),
+		"warnings": []interface{}{},
+	}
+
+	tests := []struct {
+		name           string
+		target         string
+		sourceName     string
+		url            string
+		mockSetup      func(m *mocks.MockResourceManager)
+		expectedStatus int
+		expectedBody   map[string]interface{}
+	}{
+		{"updated with report", "/sources", "source1", "http://example.com", func(m *mocks.MockResourceManager) {
+			m.EXPECT().ProbeSourceUpdate(resource.Source("source1"), "http://example.com",
+				resource.Format(resource.RSS)).Return(report, nil)
+			m.EXPECT().UpdateScheduledSource(resource.Source("source1"), "http://example.com",
+				resource.Format(resource.RSS), "").Return(nil)
+		}, http.StatusOK, reportBody},
+		{"dry run", "/sources?dryRun=true", "source1", "http://example.com", func(m *mocks.MockResourceManager) {
+			m.EXPECT().ProbeSourceUpdate(resource.Source("source1"), "http://example.com",
+				resource.Format(resource.RSS)).Return(report, nil)
+		}, http.StatusOK, reportBody},
+		{"probe failed", "/sources", "source1", "http://example.com", func(m *mocks.MockResourceManager) {
+			m.EXPECT().ProbeSourceUpdate(resource.Source("source1"), "http://example.com",
+				resource.Format(resource.RSS)).Return(report, fmt.Errorf("status code 404"))
+		}, http.StatusUnprocessableEntity, map[string]interface{}{
+			"url": "http://example.com", "statusCode": float64(200), "contentType": "application/rss+xml",
+			"detectedFormat": "RSS", "format": "RSS", "bytesFetched": float64(512), "articlesParsed": float64(2),
+			"warnings": []interface{}{}, "error": "status code 404",
+		}},
+		{"deleted before the update", "/sources", "source1", "http://example.com",
+			func(m *mocks.MockResourceManager) {
+				m.EXPECT().ProbeSourceUpdate(resource.Source("source1"), "http://example.com",
+					resource.Format(resource.RSS)).Return(report, nil)
+				m.EXPECT().UpdateScheduledSource(resource.Source("source1"), "http://example.com",
+					resource.Format(resource.RSS), "").Return(fmt.Errorf("%w: source1", manager.ErrSourceNotFound))
+			}, http.StatusNotFound, nil},
+		{"invalid name", "/sources", "../source1", "http://example.com", func(m *mocks.MockResourceManager) {},
+			http.StatusBadRequest, nil},
+		{"invalid url", "/sources", "source1", "ftp://example.com", func(m *mocks.MockResourceManager) {},
+			http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockManager := mocks.NewMockResourceManager(ctrl)
+			tt.mockSetup(mockManager)
+			handler := NewFeedsManagerHandler(mockManager)
+
+			body, _ := json.Marshal(map[string]string{
+				"name":   tt.sourceName,
+				"url":    tt.url,
+				"format": "rss",
+			})
+
+			req := httptest.NewRequest(http.MethodPut, tt.target, bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != nil {
+				var values map[string]interface{}
+				assert.NoError(t, json.NewDecoder(w.Body).Decode(&values))
+				assert.Equal(t, tt.expectedBody, values)
+			}
+		})
+	}
+}
+
+func TestControlHandler_SourceSchedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name           string
+		method         string
+		schedule       string
+		mockSetup      func(m *mocks.MockResourceManager)
+		expectedStatus int
+	}{
+		{"add with schedule", http.MethodPost, "*/5 * * * *", func(m *mocks.MockResourceManager) {
+			m.EXPECT().IsSourceSupported(resource.Source("source1")).Return(false)
+			m.EXPECT().ProbeSource(resource.Source("source1"), "http://example.com", resource.Format(resource.RSS),
+				parser.Config{}).Return(manager.ProbeReport{ArticlesParsed: 1}, nil)
+			m.EXPECT().RegisterScheduledSource(resource.Source("source1"), "http://example.com", resource.Format(resource.RSS),
+				parser.Config{}, "*/5 * * * *").Return(nil)
+		}, http.StatusCreated},
+		{"update with schedule", http.MethodPut, "5m", func(m *mocks.MockResourceManager) {
+			m.EXPECT().ProbeSourceUpdate(resource.Source("source1"), "http://example.com", resource.Format(resource.RSS)).
+				Return(manager.ProbeReport{ArticlesParsed: 1}, nil)
+			m.EXPECT().UpdateScheduledSource(resource.Source("source1"), "http://example.com", resource.Format(resource.RSS),
+				"5m").Return(nil)
+		}, http.StatusOK},
+		{"add with invalid schedule", http.MethodPost, "hourly", func(m *mocks.MockResourceManager) {},
+			http.StatusBadRequest},
+		{"update with invalid schedule", http.MethodPut, "61 * * * *", func(m *mocks.MockResourceManager) {},
+			http.StatusBadRequest},
+		{"schedule error", http.MethodPut, "5m", func(m *mocks.MockResourceManager) {
+			m.EXPECT().ProbeSourceUpdate(resource.Source("source1"), "http://example.com", resource.Format(resource.RSS)).
+				Return(manager.ProbeReport{ArticlesParsed: 1}, nil)
+			m.EXPECT().UpdateScheduledSource(resource.Source("source1"), "http://example.com", resource.Format(resource.RSS),
+				"5m").Return(fmt.Errorf("write error"))
+		}, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockManager := mocks.NewMockResourceManager(ctrl)
+			tt.mockSetup(mockManager)
+			handler := NewFeedsManagerHandler(mockManager)
+
+			body, _ := json.Marshal(map[string]string{
+				"name":     "source1",
+				"url":      "http://example.com",
+				"format":   "rss",
+				"schedule": tt.schedule,
+			})
+
+			req := httptest.NewRequest(tt.method, "/sources", bytes.NewReader(body))
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Result().StatusCode)
+		})
+	}
+}
+
 func TestControlHandler_DeleteSource(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
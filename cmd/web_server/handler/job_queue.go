@@ -0,0 +1,16 @@
+package handler
+
+import (
+	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/jobs"
+)
+
+// JobQueue is a queue of the asynchronous jobs refreshing the sources.
+//
+//go:generate mockgen -source=job_queue.go -destination=mocks/mock_job_queue.go -package=mocks
+type JobQueue interface {
+	// Enqueue adds a job refreshing the sources, or all sources if none are given.
+	Enqueue(sources []resource.Source) (jobs.Job, error)
+	// Job returns the job with the ID and whether it is known.
+	Job(id string) (jobs.Job, bool)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: job_queue.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	resource "news-aggregator/aggregator/model/resource"
+	jobs "news-aggregator/jobs"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockJobQueue is a mock of JobQueue interface.
+type MockJobQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobQueueMockRecorder
+}
+
+// MockJobQueueMockRecorder is the mock recorder for MockJobQueue.
+type MockJobQueueMockRecorder struct {
+	mock *MockJobQueue
+}
+
+// NewMockJobQueue creates a new mock instance.
+func NewMockJobQueue(ctrl *gomock.Controller) *MockJobQueue {
+	mock := &MockJobQueue{ctrl: ctrl}
+	mock.recorder = &MockJobQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobQueue) EXPECT() *MockJobQueueMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockJobQueue) Enqueue(sources []resource.Source) (jobs.Job, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enqueue", sources)
+	ret0, _ := ret[0].(jobs.Job)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockJobQueueMockRecorder) Enqueue(sources interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockJobQueue)(nil).Enqueue), sources)
+}
+
+// Job mocks base method.
+func (m *MockJobQueue) Job(id string) (jobs.Job, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Job", id)
+	ret0, _ := ret[0].(jobs.Job)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Job indicates an expected call of Job.
+func (mr *MockJobQueueMockRecorder) Job(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Job", reflect.TypeOf((*MockJobQueue)(nil).Job), id)
+}
@@ -5,7 +5,11 @@
 package mocks
 
 import (
+	aggregator "news-aggregator/aggregator"
 	resource "news-aggregator/aggregator/model/resource"
+	parser "news-aggregator/aggregator/parser"
+	fetcher "news-aggregator/fetcher"
+	manager "news-aggregator/manager"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -76,6 +80,21 @@ func (mr *MockResourceManagerMockRecorder) DeleteSource(name interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSource", reflect.TypeOf((*MockResourceManager)(nil).DeleteSource), name)
 }
 
+// DiscoverFeeds mocks base method.
+func (m *MockResourceManager) DiscoverFeeds(pageURL string) ([]manager.FeedCandidate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscoverFeeds", pageURL)
+	ret0, _ := ret[0].([]manager.FeedCandidate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DiscoverFeeds indicates an expected call of DiscoverFeeds.
+func (mr *MockResourceManagerMockRecorder) DiscoverFeeds(pageURL interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscoverFeeds", reflect.TypeOf((*MockResourceManager)(nil).DiscoverFeeds), pageURL)
+}
+
 // GetAllResources mocks base method.
 func (m *MockResourceManager) GetAllResources() ([]resource.Resource, error) {
 	m.ctrl.T.Helper()
@@ -120,6 +139,64 @@ func (mr *MockResourceManagerMockRecorder) IsSourceSupported(source interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSourceSupported", reflect.TypeOf((*MockResourceManager)(nil).IsSourceSupported), source)
 }
 
+// ParserFactory mocks base method.
+func (m *MockResourceManager) ParserFactory(fallback aggregator.Factory) aggregator.Factory {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParserFactory", fallback)
+	ret0, _ := ret[0].(aggregator.Factory)
+	return ret0
+}
+
+// ParserFactory indicates an expected call of ParserFactory.
+func (mr *MockResourceManagerMockRecorder) ParserFactory(fallback interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParserFactory", reflect.TypeOf((*MockResourceManager)(nil).ParserFactory), fallback)
+}
+
+// ProbeSource mocks base method.
+func (m *MockResourceManager) ProbeSource(name resource.Source, url string, format resource.Format, config parser.Config) (manager.ProbeReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProbeSource", name, url, format, config)
+	ret0, _ := ret[0].(manager.ProbeReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProbeSource indicates an expected call of ProbeSource.
+func (mr *MockResourceManagerMockRecorder) ProbeSource(name, url, format, config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProbeSource", reflect.TypeOf((*MockResourceManager)(nil).ProbeSource), name, url, format, config)
+}
+
+// ProbeSourceUpdate mocks base method.
+func (m *MockResourceManager) ProbeSourceUpdate(name resource.Source, url string, format resource.Format) (manager.ProbeReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProbeSourceUpdate", name, url, format)
+	ret0, _ := ret[0].(manager.ProbeReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProbeSourceUpdate indicates an expected call of ProbeSourceUpdate.
+func (mr *MockResourceManagerMockRecorder) ProbeSourceUpdate(name, url, format interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProbeSourceUpdate", reflect.TypeOf((*MockResourceManager)(nil).ProbeSourceUpdate), name, url, format)
+}
+
+// RegisterScheduledSource mocks base method.
+func (m *MockResourceManager) RegisterScheduledSource(name resource.Source, url string, format resource.Format, config parser.Config, spec string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterScheduledSource", name, url, format, config, spec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegisterScheduledSource indicates an expected call of RegisterScheduledSource.
+func (mr *MockResourceManagerMockRecorder) RegisterScheduledSource(name, url, format, config, spec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterScheduledSource", reflect.TypeOf((*MockResourceManager)(nil).RegisterScheduledSource), name, url, format, config, spec)
+}
+
 // RegisterSource mocks base method.
 func (m *MockResourceManager) RegisterSource(name resource.Source, url string, format resource.Format) error {
 	m.ctrl.T.Helper()
@@ -134,6 +211,20 @@ func (mr *MockResourceManagerMockRecorder) RegisterSource(name, url, format inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterSource", reflect.TypeOf((*MockResourceManager)(nil).RegisterSource), name, url, format)
 }
 
+// SourcesHealth mocks base method.
+func (m *MockResourceManager) SourcesHealth() map[resource.Source]fetcher.Health {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SourcesHealth")
+	ret0, _ := ret[0].(map[resource.Source]fetcher.Health)
+	return ret0
+}
+
+// SourcesHealth indicates an expected call of SourcesHealth.
+func (mr *MockResourceManagerMockRecorder) SourcesHealth() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SourcesHealth", reflect.TypeOf((*MockResourceManager)(nil).SourcesHealth))
+}
+
 // UpdateResource mocks base method.
 func (m *MockResourceManager) UpdateResource(source resource.Source) error {
 	m.ctrl.T.Helper()
@@ -148,16 +239,16 @@ func (mr *MockResourceManagerMockRecorder) UpdateResource(source interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateResource", reflect.TypeOf((*MockResourceManager)(nil).UpdateResource), source)
 }
 
-// UpdateSource mocks base method.
-func (m *MockResourceManager) UpdateSource(name resource.Source, url string, format resource.Format) error {
+// UpdateScheduledSource mocks base method.
+func (m *MockResourceManager) UpdateScheduledSource(name resource.Source, url string, format resource.Format, spec string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateSource", name, url, format)
+	ret := m.ctrl.Call(m, "UpdateScheduledSource", name, url, format, spec)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateSource indicates an expected call of UpdateSource.
-func (mr *MockResourceManagerMockRecorder) UpdateSource(name, url, format interface{}) *gomock.Call {
+// UpdateScheduledSource indicates an expected call of UpdateScheduledSource.
+func (mr *MockResourceManagerMockRecorder) UpdateScheduledSource(name, url, format, spec interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSource", reflect.TypeOf((*MockResourceManager)(nil).UpdateSource), name, url, format)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduledSource", reflect.TypeOf((*MockResourceManager)(nil).UpdateScheduledSource), name, url, format, spec)
 }
@@ -6,16 +6,61 @@ import (
 	"log"
 	"net/http"
 	"news-aggregator/aggregator"
+	"news-aggregator/aggregator/cache"
+	"news-aggregator/aggregator/cluster"
+	"news-aggregator/aggregator/dedup"
 	"news-aggregator/aggregator/filter"
 	"news-aggregator/aggregator/model/article"
 	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/aggregator/trend"
+	"news-aggregator/manager"
+	"strconv"
 	"strings"
+	"time"
 )
 
+// AggregationErrorsHeader is the response header listing the sources that failed to be aggregated.
+// Its value is a JSON array of objects with "source", "format" and "error" fields.
+const AggregationErrorsHeader = "X-Aggregation-Errors"
+
+// AggregationWarningsHeader is the response header listing the items skipped because they could not be parsed.
+// Its value is a JSON array of objects with "source", "format", "index", "field" and "reason" fields,
+// up to MaxHeaderWarnings of them. All the warnings are written to the body on request.
+const AggregationWarningsHeader = "X-Aggregation-Warnings"
+
+// AggregationWarningsCountHeader is the response header with the number of all the skipped items.
+const AggregationWarningsCountHeader = "X-Aggregation-Warnings-Count"
+
+// MaxHeaderWarnings is the largest number of the warnings listed in AggregationWarningsHeader,
+// so that a broken source cannot exceed the header size limits of the servers and proxies.
+const MaxHeaderWarnings = 10
+
+// LegacyDateFormat is the value of the "date-format" query parameter that makes calendar dates
+// of "date-start" and "date-end" to be read in the yyyy-dd-mm layout of the first versions of the API.
+const LegacyDateFormat = "yyyy-dd-mm"
+
+// aggregation is the result of aggregating the articles requested by the query parameters.
+type aggregation struct {
+	// articles are the filtered and sorted articles.
+	articles []article.Article
+	// scores are the relevance scores of the articles, nil unless they are sorted by relevance.
+	scores []float64
+	// collapsed tells whether the duplicates are collapsed into articles listing all their sources.
+	collapsed bool
+	// report is the report of the sources that failed to be aggregated.
+	report aggregator.Report
+	// withReport tells whether the report is written to the response body along with the results.
+	withReport bool
+}
+
 // NewsAggregatorHandler a Handler for aggregating news by provided filters and arguments.
 type NewsAggregatorHandler struct {
-	resourceManager ResourceManager
-	parserPool      *aggregator.ParserFactory
+	resourceManager  ResourceManager
+	parserPool       *aggregator.ParserFactory
+	cache            *cache.Cache
+	legacyDateLayout bool
+	// now is the time the trends window ends at, the zero value stands for the current time.
+	now time.Time
 }
 
 // NewNewsHandler creates a new NewsAggregatorHandler instance.
@@ -26,129 +71,519 @@ func NewNewsHandler(resourceManager ResourceManager) *NewsAggregatorHandler {
 	}
 }
 
+// SetLegacyDateLayout makes calendar dates to be read in the legacy yyyy-dd-mm layout by default,
+// for the clients that do not send the "date-format" query parameter.
+func (h *NewsAggregatorHandler) SetLegacyDateLayout(legacy bool) {
+	h.legacyDateLayout = legacy
+}
+
+// SetCache sets the cache of the parsed articles shared by all requests, nil disables caching.
+func (h *NewsAggregatorHandler) SetCache(c *cache.Cache) {
+	h.cache = c
+}
+
 // Handle is responsible for handling the request and response for the news aggregator.
 func (h *NewsAggregatorHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	result, ok := h.aggregate(w, r)
+	if !ok {
+		return
+	}
+
+	h.sendArticles(w, result)
+}
+
+// HandleClusters is responsible for handling the request for the articles grouped into stories.
+// It accepts the same filters as Handle and an optional limit of the number of stories.
+func (h *NewsAggregatorHandler) HandleClusters(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+	}
+
+	result, ok := h.aggregate(w, r)
+	if !ok {
+		return
+	}
+
+	clusters := cluster.New().Cluster(result.articles)
+	if limit > 0 && len(clusters) > limit {
+		clusters = clusters[:limit]
+	}
+
+	h.sendClusters(w, clusters, result)
+}
+
+// HandleTrends is responsible for handling the request for the trending terms of the articles.
+// It accepts the same filters as Handle, the window the terms trend in and an optional limit of the number of terms.
+func (h *NewsAggregatorHandler) HandleTrends(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	detector := trend.New()
+	detector.SetNow(h.now)
+
+	if windowStr := query.Get("window"); windowStr != "" {
+		window, err := filter.ParseDuration(windowStr)
+		if err != nil || window == 0 {
+			http.Error(w, "Invalid window", http.StatusBadRequest)
+			return
+		}
+		detector.SetWindow(window)
+	}
+
+	if limitStr := query.Get("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		detector.SetLimit(limit)
+	}
+
+	result, ok := h.aggregate(w, r)
+	if !ok {
+		return
+	}
+
+	h.sendTrends(w, detector.Detect(result.articles), result)
+}
+
+// aggregate aggregates the articles requested by the query parameters and sorts them.
+// It reports whether the articles are aggregated, otherwise the error is already written to the response.
+func (h *NewsAggregatorHandler) aggregate(w http.ResponseWriter, r *http.Request) (result aggregation, ok bool) {
 
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
+		return aggregation{}, false
 	}
 
 	query := r.URL.Query()
 
 	sources := query.Get("sources")
 	keywords := query.Get("keywords")
+	queryStr := query.Get("q")
 	startDate := query.Get("date-start")
 	endDate := query.Get("date-end")
+	since := query.Get("since")
 	sortOrder := query.Get("sort-order")
+	dedupMode := query.Get("dedup")
+	collapse := query.Get("collapse")
+
+	withReport := false
+	if reportStr := query.Get("report"); reportStr != "" {
+		var err error
+		withReport, err = strconv.ParseBool(reportStr)
+		if err != nil {
+			http.Error(w, "Invalid report value", http.StatusBadRequest)
+			return aggregation{}, false
+		}
+	}
+
+	dateParser, err := h.dateParser(query.Get("date-format"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return aggregation{}, false
+	}
+
+	if since != "" && startDate != "" {
+		http.Error(w, "date-start and since cannot be used together", http.StatusBadRequest)
+		return aggregation{}, false
+	}
 
-	a, err := aggregator.New(h.parserPool)
+	a, err := aggregator.New(h.resourceManager.ParserFactory(h.parserPool))
 	if err != nil {
 		log.Fatalf("failed to create aggregator: %v", err)
 	}
+	a.SetTolerant(true)
+	a.SetCache(h.cache)
 
-	resources, err := h.getResources(sources)
+	collapsed, err := h.setDeduplicator(a, dedupMode, collapse)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+		return aggregation{}, false
 	}
 
-	err = h.applyFilters(a, keywords, startDate, endDate)
+	resources, sourceErrors, err := h.getResources(sources)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
+		return aggregation{}, false
 	}
 
-	articles, err := a.AggregateMultiple(resources)
+	searchTerms, err := h.applyFilters(a, dateParser, keywords, queryStr, startDate, endDate, since)
+	if errors.Is(err, filter.ErrAmbiguousDate) {
+		http.Error(w, err.Error()+", set date-format to iso or "+LegacyDateFormat, http.StatusBadRequest)
+		return aggregation{}, false
+	}
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return aggregation{}, false
 	}
 
+	articles, report := a.AggregateConcurrently(resources)
+	report.Errors = append(sourceErrors, report.Errors...)
+	if report.HasErrors() {
+		h.setAggregationErrors(w, report)
+	}
+	if report.HasWarnings() {
+		h.setAggregationWarnings(w, report)
+	}
+
+	result = aggregation{articles: articles, collapsed: collapsed, report: report, withReport: withReport}
 	if sortOrder != "" {
-		articles, err = h.sortArticles(articles, sortOrder)
+		result.articles, result.scores, err = h.sortArticles(articles, sortOrder, searchTerms)
 		if err != nil {
 			http.Error(w, "Invalid sort order", http.StatusBadRequest)
-			return
+			return aggregation{}, false
 		}
 	}
 
-	h.sendArticles(w, articles)
+	return result, true
 }
 
-func (h *NewsAggregatorHandler) getResources(sources string) ([]resource.Resource, error) {
+// getResources returns the resources of the requested sources, or of all sources if none are requested.
+// The sources of all that cannot be read are skipped and returned as the errors of the aggregation.
+func (h *NewsAggregatorHandler) getResources(sources string) ([]resource.Resource, []aggregator.SourceError,
+	error) {
+
 	if sources == "" {
 		resources, err := h.resourceManager.GetAllResources()
+		var resourceErr *manager.ResourceError
+		if errors.As(err, &resourceErr) {
+			return resources, resourceErr.Errors, nil
+		}
 		if err != nil {
-			return resources, err
+			return resources, nil, err
 		}
-		return resources, nil
+		return resources, nil, nil
 	}
 
 	sourceList := strings.Split(sources, ",")
 	resources, err := h.resourceManager.GetSelectedResources(sourceList)
 	if err != nil {
-		return resources, err
+		return resources, nil, err
 	}
 
-	return resources, nil
+	return resources, nil, nil
 }
 
-func (h *NewsAggregatorHandler) applyFilters(a *aggregator.Aggregator, keywords, startDate, endDate string) error {
+// dateParser returns the parser of the date filters for the requested date format.
+// Without a format, the calendar dates are read in the default layout, and the ones standing for different days
+// in the standard and the legacy layouts are rejected, see filter.DateExpressionParser.SetRejectAmbiguous.
+func (h *NewsAggregatorHandler) dateParser(dateFormat string) (*filter.DateExpressionParser, error) {
+	dateParser := filter.NewDateExpressionParser()
+
+	switch dateFormat {
+	case "":
+		// Until the legacy layout is dropped, the dates reading differently in both layouts need the format.
+		dateParser.SetLegacyLayout(h.legacyDateLayout)
+		dateParser.SetRejectAmbiguous(true)
+	case "iso":
+		dateParser.SetLegacyLayout(false)
+	case LegacyDateFormat:
+		dateParser.SetLegacyLayout(true)
+	default:
+		return nil, errors.New("invalid date format")
+	}
+
+	return dateParser, nil
+}
+
+// setDeduplicator sets the deduplicator of the requested mode, duplicates are kept by default.
+// It reports whether the duplicates are collapsed into articles listing all their sources.
+func (h *NewsAggregatorHandler) setDeduplicator(a *aggregator.Aggregator, mode, collapse string) (bool, error) {
+	if mode == "" {
+		mode = dedup.ModeOff
+	}
+
+	deduplicator, err := dedup.NewForMode(mode)
+	if err != nil {
+		return false, err
+	}
+
+	collapsed := false
+	if collapse != "" {
+		collapsed, err = strconv.ParseBool(collapse)
+		if err != nil {
+			return false, errors.New("invalid collapse value")
+		}
+	}
+
+	if deduplicator == nil {
+		if collapsed {
+			return false, errors.New("collapse requires deduplication")
+		}
+		return false, nil
+	}
+
+	deduplicator.SetCollapse(collapsed)
+	a.SetDeduplicator(deduplicator)
+	return collapsed, nil
+}
+
+// applyFilters adds the requested filters to the aggregator and returns the terms the articles are searched for.
+func (h *NewsAggregatorHandler) applyFilters(a *aggregator.Aggregator, dateParser *filter.DateExpressionParser,
+	keywords, queryStr, startDate, endDate, since string) ([]string, error) {
+	var searchTerms []string
+
 	if startDate != "" {
-		startDateFilter, err := filter.NewStartDateFilter(startDate)
+		start, err := dateParser.ParseStart(startDate)
+		if err != nil {
+			return nil, err
+		}
+		a.AddFilter(filter.NewStartDateFilterFromTime(start))
+	}
+
+	if since != "" {
+		start, err := dateParser.ParseSince(since)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		a.AddFilter(startDateFilter)
+		a.AddFilter(filter.NewStartDateFilterFromTime(start))
 	}
 
 	if endDate != "" {
-		endDateFilter, err := filter.NewEndDateFilter(endDate)
+		end, err := dateParser.ParseEnd(endDate)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		a.AddFilter(endDateFilter)
+		a.AddFilter(filter.NewEndDateFilterFromTime(end))
 	}
 
 	if keywords != "" {
 		keywordList := strings.Split(keywords, ",")
 		a.AddFilter(filter.NewKeywordFilter(keywordList))
+		searchTerms = append(searchTerms, keywordList...)
+	}
+
+	if queryStr != "" {
+		queryFilter, err := filter.NewQueryFilter(queryStr)
+		if err != nil {
+			return nil, err
+		}
+		a.AddFilter(queryFilter)
+		searchTerms = append(searchTerms, queryFilter.Terms()...)
 	}
 
-	return nil
+	return searchTerms, nil
 }
 
-func (h *NewsAggregatorHandler) sortArticles(articles []article.Article, sortOrder string) ([]article.Article, error) {
+// sortArticles sorts the articles in the given order. The relevance order also returns the scores of the articles.
+func (h *NewsAggregatorHandler) sortArticles(articles []article.Article, sortOrder string,
+	searchTerms []string) ([]article.Article, []float64, error) {
 	switch sortOrder {
 	case "asc":
-		return aggregator.SortArticlesByDateAsc(articles), nil
+		return aggregator.SortArticlesByDateAsc(articles), nil, nil
 	case "desc":
-		return aggregator.SortArticlesByDateDesc(articles), nil
+		return aggregator.SortArticlesByDateDesc(articles), nil, nil
+	case "relevance":
+		scored := aggregator.SortArticlesByRelevance(articles, searchTerms)
+		sorted := make([]article.Article, len(scored))
+		scores := make([]float64, len(scored))
+		for i, s := range scored {
+			sorted[i] = s.Article
+			scores[i] = s.Score
+		}
+		return sorted, scores, nil
 	default:
-		return nil, errors.New("invalid sort order")
+		return nil, nil, errors.New("invalid sort order")
+	}
+}
+
+func (h *NewsAggregatorHandler) setAggregationErrors(w http.ResponseWriter, report aggregator.Report) {
+	for _, sourceErr := range report.Errors {
+		log.Printf("failed to aggregate resource: %v", sourceErr)
+	}
+
+	headerJSON, err := json.Marshal(aggregationErrorsJSON(report))
+	if err != nil {
+		return
+	}
+
+	w.Header().Set(AggregationErrorsHeader, string(headerJSON))
+}
+
+func (h *NewsAggregatorHandler) setAggregationWarnings(w http.ResponseWriter, report aggregator.Report) {
+	for _, warning := range report.Warnings {
+		log.Printf("skipped invalid item: %v", warning)
+	}
+
+	warningsJSON := aggregationWarningsJSON(report)
+	if len(warningsJSON) > MaxHeaderWarnings {
+		warningsJSON = warningsJSON[:MaxHeaderWarnings]
+	}
+
+	headerJSON, err := json.Marshal(warningsJSON)
+	if err != nil {
+		return
+	}
+
+	w.Header().Set(AggregationWarningsHeader, string(headerJSON))
+	w.Header().Set(AggregationWarningsCountHeader, strconv.Itoa(len(report.Warnings)))
+}
+
+// aggregationErrorsJSON returns the sources that failed to be aggregated
+// as objects with "source", "format" and "error" fields.
+func aggregationErrorsJSON(report aggregator.Report) []map[string]string {
+	errorsJSON := make([]map[string]string, 0, len(report.Errors))
+
+	for _, sourceErr := range report.Errors {
+		errorsJSON = append(errorsJSON, map[string]string{
+			"source": string(sourceErr.Source),
+			"format": resource.FormatToString(sourceErr.Format),
+			"error":  sourceErr.Err.Error(),
+		})
+	}
+
+	return errorsJSON
+}
+
+// aggregationWarningsJSON returns the items skipped because they could not be parsed
+// as objects with "source", "format", "index", "field" and "reason" fields.
+func aggregationWarningsJSON(report aggregator.Report) []map[string]interface{} {
+	warningsJSON := make([]map[string]interface{}, 0, len(report.Warnings))
+
+	for _, warning := range report.Warnings {
+		warningsJSON = append(warningsJSON, map[string]interface{}{
+			"source": string(warning.Source),
+			"format": resource.FormatToString(warning.Format),
+			"index":  warning.Index,
+			"field":  warning.Field,
+			"reason": warning.Reason,
+		})
+	}
+
+	return warningsJSON
+}
+
+// sendResults writes the results as a JSON array, or, if the report is requested, as a JSON object
+// with the results under the key, the failed sources under "errors" and the skipped items under "warnings".
+func (h *NewsAggregatorHandler) sendResults(w http.ResponseWriter, key string, results interface{},
+	result aggregation) {
+
+	if !result.withReport {
+		h.sendJSON(w, results)
+		return
 	}
+
+	h.sendJSON(w, map[string]interface{}{
+		key:        results,
+		"errors":   aggregationErrorsJSON(result.report),
+		"warnings": aggregationWarningsJSON(result.report),
+	})
 }
 
-func (h *NewsAggregatorHandler) sendArticles(w http.ResponseWriter, articles []article.Article) {
+// sendArticles writes the articles as a JSON array. The scores are added to the articles if present,
+// and the sources that carried the articles if the duplicates are collapsed.
+func (h *NewsAggregatorHandler) sendArticles(w http.ResponseWriter, result aggregation) {
 	var articlesJSON []map[string]interface{}
 
-	for _, art := range articles {
-		articleJSON := map[string]interface{}{
-			"title":        art.TitleStr(),
-			"description":  art.DescriptionStr(),
-			"creationDate": art.Date().HumanReadableString(),
-			"source":       art.Source(),
-			"author":       art.Author(),
-			"link":         art.Link(),
+	for i, art := range result.articles {
+		articleJSON := h.articleToJSON(art, result.collapsed)
+		if result.scores != nil {
+			articleJSON["score"] = result.scores[i]
 		}
 
 		articlesJSON = append(articlesJSON, articleJSON)
 	}
 
+	if articlesJSON == nil && result.withReport {
+		articlesJSON = []map[string]interface{}{}
+	}
+
+	h.sendResults(w, "articles", articlesJSON, result)
+}
+
+// sendClusters writes the stories as a JSON array, their articles are written the same way as by sendArticles.
+func (h *NewsAggregatorHandler) sendClusters(w http.ResponseWriter, clusters []cluster.Cluster, result aggregation) {
+	clustersJSON := make([]map[string]interface{}, 0, len(clusters))
+
+	for _, c := range clusters {
+		articlesJSON := make([]map[string]interface{}, 0, len(c.Articles))
+		for _, art := range c.Articles {
+			articlesJSON = append(articlesJSON, h.articleToJSON(art, result.collapsed))
+		}
+
+		clustersJSON = append(clustersJSON, map[string]interface{}{
+			"headline":        c.Headline(),
+			"size":            len(c.Articles),
+			"sources":         c.Sources,
+			"sourceDiversity": c.SourceDiversity(),
+			"start":           article.CreationDate(c.Start).HumanReadableString(),
+			"end":             article.CreationDate(c.End).HumanReadableString(),
+			"span":            c.TimeSpan().String(),
+			"articles":        articlesJSON,
+		})
+	}
+
+	h.sendResults(w, "clusters", clustersJSON, result)
+}
+
+// sendTrends writes the trending terms as a JSON array along with their daily counts.
+func (h *NewsAggregatorHandler) sendTrends(w http.ResponseWriter, trends []trend.Trend, result aggregation) {
+	trendsJSON := make([]map[string]interface{}, 0, len(trends))
+
+	for _, t := range trends {
+		countsJSON := make([]map[string]interface{}, 0, len(t.Counts))
+		for _, c := range t.Counts {
+			countsJSON = append(countsJSON, map[string]interface{}{
+				"date":  c.Date.Format(time.DateOnly),
+				"count": c.Count,
+			})
+		}
+
+		trendsJSON = append(trendsJSON, map[string]interface{}{
+			"term":   t.Term,
+			"score":  t.Score,
+			"total":  t.Total,
+			"counts": countsJSON,
+		})
+	}
+
+	h.sendResults(w, "trends", trendsJSON, result)
+}
+
+// articleToJSON returns the fields of the article, the optional ones only if the source provided them.
+func (h *NewsAggregatorHandler) articleToJSON(art article.Article, withSources bool) map[string]interface{} {
+	articleJSON := map[string]interface{}{
+		"id":           art.ID(),
+		"title":        art.TitleStr(),
+		"description":  art.DescriptionStr(),
+		"creationDate": art.Date().HumanReadableString(),
+		"source":       art.Source(),
+		"author":       art.Author(),
+		"link":         art.Link(),
+	}
+	if len(art.Categories()) > 0 {
+		articleJSON["categories"] = art.Categories()
+	}
+	if len(art.Tags()) > 0 {
+		articleJSON["tags"] = art.Tags()
+	}
+	if art.Image() != "" {
+		articleJSON["image"] = art.Image()
+	}
+	if !art.Updated().IsZero() {
+		articleJSON["updated"] = art.Updated().HumanReadableString()
+	}
+	if art.Language() != "" {
+		articleJSON["language"] = art.Language()
+	}
+	if art.Content() != "" {
+		articleJSON["content"] = art.Content()
+	}
+	if withSources {
+		articleJSON["sources"] = art.Sources()
+	}
+	return articleJSON
+}
+
+func (h *NewsAggregatorHandler) sendJSON(w http.ResponseWriter, value interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 
-	responseJSON, err := json.Marshal(articlesJSON)
+	responseJSON, err := json.Marshal(value)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -3,11 +3,18 @@ package handler
 import (
 	"encoding/json"
 	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"news-aggregator/aggregator"
+	"news-aggregator/aggregator/cache"
 	"news-aggregator/manager"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -81,3 +88,642 @@ func TestNewsAggregatorHandler_Handle(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
 	})
 }
+
+func TestNewsAggregatorHandler_Handle_PartialFailure(t *testing.T) {
+	dir := t.TempDir()
+	storagePath := filepath.Join(dir, "resources")
+	managerConfigPath := filepath.Join(dir, "feeds.json")
+
+	// The pending source is registered but not fetched yet.
+	feeds := `[{"source":"good","format":"RSS","link":"http://good.com"},` +
+		`{"source":"broken","format":"RSS","link":"http://broken.com"},` +
+		`{"source":"pending","format":"ATOM","link":"http://pending.com"}]`
+	assert.NoError(t, os.WriteFile(managerConfigPath, []byte(feeds), 0644))
+
+	content, err := os.ReadFile("../../../aggregator/parser/testdata/rss/test.xml")
+	assert.NoError(t, err)
+	assert.NoError(t, os.MkdirAll(storagePath, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(storagePath, "good_20240101.xml"), content, 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(storagePath, "broken_20240101.xml"), []byte("corrupted"), 0644))
+
+	m, err := manager.New(storagePath, managerConfigPath)
+	assert.NoError(t, err)
+
+	handler := NewNewsHandler(m)
+
+	req := httptest.NewRequest(http.MethodGet, "/news", nil)
+	w := httptest.NewRecorder()
+
+	handler.Handle(w, req)
+
+	resp := w.Result()
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			t.Error(err)
+		}
+	}(resp.Body)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var articlesJSON []map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&articlesJSON)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, articlesJSON)
+	for _, a := range articlesJSON {
+		assert.Equal(t, "good", a["source"])
+	}
+
+	var errorsJSON []map[string]string
+	err = json.Unmarshal([]byte(resp.Header.Get(AggregationErrorsHeader)), &errorsJSON)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(errorsJSON))
+	assert.Equal(t, "pending", errorsJSON[0]["source"])
+	assert.Equal(t, "ATOM", errorsJSON[0]["format"])
+	assert.Contains(t, errorsJSON[0]["error"], "source is unknown")
+	assert.Equal(t, "broken", errorsJSON[1]["source"])
+	assert.Equal(t, "RSS", errorsJSON[1]["format"])
+	assert.NotEmpty(t, errorsJSON[1]["error"])
+
+	// The failed sources are written to the body along with the articles on request.
+	w = httptest.NewRecorder()
+	handler.Handle(w, httptest.NewRequest(http.MethodGet, "/news?report=true", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var body struct {
+		Articles []map[string]interface{} `json:"articles"`
+		Errors   []map[string]string      `json:"errors"`
+	}
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+	assert.Equal(t, len(articlesJSON), len(body.Articles))
+	assert.Equal(t, errorsJSON, body.Errors)
+
+	w = httptest.NewRecorder()
+	handler.Handle(w, httptest.NewRequest(http.MethodGet, "/news?report=maybe", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestNewsAggregatorHandler_Handle_JSONMapping(t *testing.T) {
+	dir := t.TempDir()
+	storagePath := filepath.Join(dir, "resources")
+	managerConfigPath := filepath.Join(dir, "feeds.json")
+
+	feeds := `[{"source":"custom","format":"JSON","link":"http://custom.com","jsonMapping":` +
+		`{"items":"data.posts","title":"headline","description":"teaser","date":"meta.published","link":"links.web",` +
+		`"categories":"section","tags":"keywords","image":"media.image","updated":"meta_updated","language":"lang",` +
+		`"content":"body"}}]`
+	assert.NoError(t, os.WriteFile(managerConfigPath, []byte(feeds), 0644))
+
+	content, err := os.ReadFile("../../../aggregator/parser/testdata/jsonmapping/test.json")
+	assert.NoError(t, err)
+	assert.NoError(t, os.MkdirAll(storagePath, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(storagePath, "custom_20240101.json"), content, 0644))
+
+	m, err := manager.New(storagePath, managerConfigPath)
+	assert.NoError(t, err)
+
+	handler := NewNewsHandler(m)
+
+	req := httptest.NewRequest(http.MethodGet, "/news?sources=custom", nil)
+	w := httptest.NewRecorder()
+
+	handler.Handle(w, req)
+
+	resp := w.Result()
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			t.Error(err)
+		}
+	}(resp.Body)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get(AggregationErrorsHeader))
+
+	var articlesJSON []map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&articlesJSON)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(articlesJSON))
+	assert.Equal(t, "Test Title", articlesJSON[0]["title"])
+	assert.Len(t, articlesJSON[0]["id"], 32)
+	assert.NotEqual(t, articlesJSON[0]["id"], articlesJSON[1]["id"])
+	assert.Equal(t, []interface{}{"World"}, articlesJSON[0]["categories"])
+	assert.Equal(t, []interface{}{"election", "vote"}, articlesJSON[0]["tags"])
+	assert.Equal(t, "http://example.com/image.jpg", articlesJSON[0]["image"])
+	assert.Equal(t, "02 Jun 24 18:30 UTC", articlesJSON[0]["updated"])
+	assert.Equal(t, "en", articlesJSON[0]["language"])
+	assert.Equal(t, "Test Content", articlesJSON[0]["content"])
+	for _, field := range []string{"categories", "tags", "image", "updated", "language", "content"} {
+		assert.NotContains(t, articlesJSON[1], field)
+	}
+
+	// The ID does not change between requests.
+	w = httptest.NewRecorder()
+	handler.Handle(w, httptest.NewRequest(http.MethodGet, "/news?sources=custom", nil))
+	var againJSON []map[string]interface{}
+	assert.NoError(t, json.NewDecoder(w.Result().Body).Decode(&againJSON))
+	assert.Equal(t, articlesJSON[0]["id"], againJSON[0]["id"])
+}
+
+func TestNewsAggregatorHandler_Handle_Cache(t *testing.T) {
+	dir := t.TempDir()
+	storagePath := filepath.Join(dir, "resources")
+	managerConfigPath := filepath.Join(dir, "feeds.json")
+
+	feeds := `[{"source":"rss","format":"RSS","link":"http://rss.com"}]`
+	assert.NoError(t, os.WriteFile(managerConfigPath, []byte(feeds), 0644))
+
+	content, err := os.ReadFile("../../../aggregator/parser/testdata/rss/test.xml")
+	assert.NoError(t, err)
+	assert.NoError(t, os.MkdirAll(storagePath, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(storagePath, "rss_20240101.xml"), content, 0644))
+
+	m, err := manager.New(storagePath, managerConfigPath)
+	assert.NoError(t, err)
+
+	c := cache.New()
+	m.SetCache(c)
+	handler := NewNewsHandler(m)
+	handler.SetCache(c)
+
+	get := func(target string) []map[string]interface{} {
+		w := httptest.NewRecorder()
+		handler.Handle(w, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var articlesJSON []map[string]interface{}
+		assert.NoError(t, json.NewDecoder(w.Result().Body).Decode(&articlesJSON))
+		return articlesJSON
+	}
+
+	first := get("/news?sources=rss")
+	assert.Equal(t, 1, len(first))
+	assert.Empty(t, get("/news?sources=rss&keywords=unknown"), "Cached articles should be filtered")
+	assert.Equal(t, first, get("/news?sources=rss"))
+
+	stats := c.Stats()
+	assert.Equal(t, 1, stats.Entries)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, uint64(2), stats.Hits)
+
+	// A new snapshot written by the updater is not served from the cache.
+	updated := strings.Replace(string(content), "Test Title", "Updated Title", 1)
+	assert.NoError(t, os.WriteFile(filepath.Join(storagePath, "rss_20240101.xml"), []byte(updated), 0644))
+	assert.Equal(t, "Updated Title", get("/news?sources=rss")[0]["title"])
+}
+
+func TestNewsAggregatorHandler_Handle_SkippedItems(t *testing.T) {
+	dir := t.TempDir()
+	storagePath := filepath.Join(dir, "resources")
+	managerConfigPath := filepath.Join(dir, "feeds.json")
+
+	feeds := `[{"source":"partial","format":"RSS","link":"http://partial.com"}]`
+	assert.NoError(t, os.WriteFile(managerConfigPath, []byte(feeds), 0644))
+
+	content, err := os.ReadFile("../../../aggregator/parser/testdata/rss/partially_invalid_test.xml")
+	assert.NoError(t, err)
+	assert.NoError(t, os.MkdirAll(storagePath, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(storagePath, "partial_20240101.xml"), content, 0644))
+
+	m, err := manager.New(storagePath, managerConfigPath)
+	assert.NoError(t, err)
+
+	handler := NewNewsHandler(m)
+
+	req := httptest.NewRequest(http.MethodGet, "/news", nil)
+	w := httptest.NewRecorder()
+
+	handler.Handle(w, req)
+
+	resp := w.Result()
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			t.Error(err)
+		}
+	}(resp.Body)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get(AggregationErrorsHeader))
+
+	var articlesJSON []map[string]interface{}
+	err = json.NewDecoder(resp.Body).Decode(&articlesJSON)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(articlesJSON))
+
+	var warningsJSON []map[string]interface{}
+	err = json.Unmarshal([]byte(resp.Header.Get(AggregationWarningsHeader)), &warningsJSON)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(warningsJSON))
+	assert.Equal(t, "partial", warningsJSON[0]["source"])
+	assert.Equal(t, float64(1), warningsJSON[0]["index"])
+	assert.Equal(t, "date", warningsJSON[0]["field"])
+	assert.Equal(t, "description", warningsJSON[1]["field"])
+	assert.Equal(t, "2", resp.Header.Get(AggregationWarningsCountHeader))
+}
+
+func TestNewsAggregatorHandler_Handle_ManySkippedItems(t *testing.T) {
+	dir := t.TempDir()
+	storagePath := filepath.Join(dir, "resources")
+	managerConfigPath := filepath.Join(dir, "feeds.json")
+
+	feeds := `[{"source":"broken","format":"RSS","link":"http://broken.com"}]`
+	assert.NoError(t, os.WriteFile(managerConfigPath, []byte(feeds), 0644))
+
+	var content strings.Builder
+	content.WriteString(`<rss version="2.0"><channel>`)
+	content.WriteString(`<item><title>Valid</title><link>http://example.com</link>` +
+		`<pubDate>Mon, 01 Jan 2022 00:00:00 +0000</pubDate><description>Valid item</description></item>`)
+	for i := 0; i < 300; i++ {
+		content.WriteString(`<item><title>Broken</title><pubDate>not a date</pubDate>` +
+			`<description>Broken item</description></item>`)
+	}
+	content.WriteString(`</channel></rss>`)
+
+	assert.NoError(t, os.MkdirAll(storagePath, 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(storagePath, "broken_20240101.xml"), []byte(content.String()), 0644))
+
+	m, err := manager.New(storagePath, managerConfigPath)
+	assert.NoError(t, err)
+
+	handler := NewNewsHandler(m)
+
+	w := httptest.NewRecorder()
+	handler.Handle(w, httptest.NewRequest(http.MethodGet, "/news", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var warningsJSON []map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(w.Header().Get(AggregationWarningsHeader)), &warningsJSON))
+	assert.Equal(t, MaxHeaderWarnings, len(warningsJSON), "The header should be capped")
+	assert.Equal(t, "300", w.Header().Get(AggregationWarningsCountHeader))
+
+	w = httptest.NewRecorder()
+	handler.Handle(w, httptest.NewRequest(http.MethodGet, "/news?report=true", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var body struct {
+		Articles []map[string]interface{} `json:"articles"`
+		Errors   []map[string]string      `json:"errors"`
+		Warnings []map[string]interface{} `json:"warnings"`
+	}
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&body))
+	assert.Equal(t, 1, len(body.Articles))
+	assert.Empty(t, body.Errors)
+	assert.Equal(t, 300, len(body.Warnings), "The body should list all the warnings")
+}
+
+func TestNewsAggregatorHandler_Handle_DateRange(t *testing.T) {
+	m, err := manager.New("../../../resources", "../../../config/feeds_dictionary.json")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		query          string
+		legacyLayout   bool
+		expectedStatus int
+	}{
+		{"ISO dates", "date-start=2024-05-01&date-end=2024-05-31&date-format=iso", false, http.StatusOK},
+		{"Unambiguous ISO dates", "date-start=2024-05-13&date-end=2024-05-31", false, http.StatusOK},
+		{"Ambiguous date without date format", "date-start=2024-05-01", false, http.StatusBadRequest},
+		{"Same day in both layouts", "date-start=2024-05-05", false, http.StatusOK},
+		{"RFC3339 timestamps", "date-start=2024-05-01T00:00:00Z&date-end=2024-05-31T23:59:59Z",
+			false, http.StatusOK},
+		{"Relative start date", "date-start=-24h", false, http.StatusOK},
+		{"Since", "since=7d", false, http.StatusOK},
+		{"Legacy date format parameter", "date-start=2024-01-05&date-format=yyyy-dd-mm", false, http.StatusOK},
+		{"Legacy layout by default", "date-start=2024-19-05", true, http.StatusOK},
+		{"ISO format overrides legacy default", "date-start=2024-05-19&date-format=iso", true, http.StatusOK},
+		{"Legacy date without legacy layout", "date-start=2024-19-05", false, http.StatusBadRequest},
+		{"Invalid date format", "date-start=2024-05-19&date-format=dd.mm.yyyy", false, http.StatusBadRequest},
+		{"Since with start date", "since=7d&date-start=2024-05-19", false, http.StatusBadRequest},
+		{"Invalid since", "since=yesterday", false, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewNewsHandler(m)
+			handler.SetLegacyDateLayout(tt.legacyLayout)
+
+			req := httptest.NewRequest(http.MethodGet, "/news?sources=usa-today&"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			resp := w.Result()
+			defer func(Body io.ReadCloser) {
+				err := Body.Close()
+				if err != nil {
+					t.Error(err)
+				}
+			}(resp.Body)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestNewsAggregatorHandler_Handle_Query(t *testing.T) {
+	m, err := manager.New("../../../resources", "../../../config/feeds_dictionary.json")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+	}{
+		{"Valid query", "q=" + url.QueryEscape("ukraine -opinion"), http.StatusOK},
+		{"Invalid query", "q=" + url.QueryEscape("ukraine AND (grain"), http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewNewsHandler(m)
+
+			req := httptest.NewRequest(http.MethodGet, "/news?sources=usa-today&"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			resp := w.Result()
+			defer func(Body io.ReadCloser) {
+				err := Body.Close()
+				if err != nil {
+					t.Error(err)
+				}
+			}(resp.Body)
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var articlesJSON []map[string]interface{}
+			err = json.NewDecoder(resp.Body).Decode(&articlesJSON)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, articlesJSON)
+			for _, a := range articlesJSON {
+				text := strings.ToLower(a["title"].(string) + " " + a["description"].(string))
+				assert.Contains(t, text, "ukrain")
+				assert.NotContains(t, text, "opinion")
+			}
+		})
+	}
+}
+
+func TestNewsAggregatorHandler_Handle_Relevance(t *testing.T) {
+	m, err := manager.New("../../../resources", "../../../config/feeds_dictionary.json")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		query         string
+		expectedScore bool
+	}{
+		{"Relevance order returns scores", "keywords=ukraine&sort-order=relevance", true},
+		{"Relevance order with query", "q=ukraine+-opinion&sort-order=relevance", true},
+		{"Date order omits scores", "keywords=ukraine&sort-order=desc", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewNewsHandler(m)
+
+			req := httptest.NewRequest(http.MethodGet, "/news?sources=usa-today&"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.Handle(w, req)
+
+			resp := w.Result()
+			defer func(Body io.ReadCloser) {
+				err := Body.Close()
+				if err != nil {
+					t.Error(err)
+				}
+			}(resp.Body)
+
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+			var articlesJSON []map[string]interface{}
+			err = json.NewDecoder(resp.Body).Decode(&articlesJSON)
+			assert.NoError(t, err)
+			assert.NotEmpty(t, articlesJSON)
+
+			previous := math.Inf(1)
+			for _, a := range articlesJSON {
+				score, exists := a["score"]
+				assert.Equal(t, tt.expectedScore, exists)
+				if !exists {
+					continue
+				}
+				assert.Greater(t, score.(float64), 0.0)
+				assert.LessOrEqual(t, score.(float64), previous)
+				previous = score.(float64)
+			}
+		})
+	}
+}
+
+func TestNewsAggregatorHandler_Handle_Dedup(t *testing.T) {
+	m, err := manager.New("../../../resources", "../../../config/feeds_dictionary.json")
+	assert.NoError(t, err)
+
+	fetch := func(t *testing.T, query string) (int, []map[string]interface{}) {
+		handler := NewNewsHandler(m)
+
+		req := httptest.NewRequest(http.MethodGet, "/news?"+query, nil)
+		w := httptest.NewRecorder()
+
+		handler.Handle(w, req)
+
+		resp := w.Result()
+		defer func(Body io.ReadCloser) {
+			err := Body.Close()
+			if err != nil {
+				t.Error(err)
+			}
+		}(resp.Body)
+
+		var articlesJSON []map[string]interface{}
+		if resp.StatusCode == http.StatusOK {
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&articlesJSON))
+		}
+		return resp.StatusCode, articlesJSON
+	}
+
+	t.Run("Modes remove more duplicates", func(t *testing.T) {
+		status, off := fetch(t, "")
+		assert.Equal(t, http.StatusOK, status)
+		status, exact := fetch(t, "dedup=exact")
+		assert.Equal(t, http.StatusOK, status)
+		status, near := fetch(t, "dedup=near")
+		assert.Equal(t, http.StatusOK, status)
+
+		assert.LessOrEqual(t, len(exact), len(off))
+		assert.Less(t, len(near), len(exact))
+		for _, a := range near {
+			assert.NotContains(t, a, "sources")
+		}
+	})
+
+	t.Run("Collapsed duplicates list their sources", func(t *testing.T) {
+		status, articlesJSON := fetch(t, "dedup=near&collapse=true")
+		assert.Equal(t, http.StatusOK, status)
+
+		carriedBySeveral := 0
+		for _, a := range articlesJSON {
+			sources := a["sources"].([]interface{})
+			assert.Equal(t, a["source"], sources[0])
+			if len(sources) > 1 {
+				carriedBySeveral++
+			}
+		}
+		assert.Greater(t, carriedBySeveral, 0)
+	})
+
+	t.Run("Duplicates are kept by default", func(t *testing.T) {
+		status, def := fetch(t, "")
+		assert.Equal(t, http.StatusOK, status)
+		status, off := fetch(t, "dedup=off")
+		assert.Equal(t, http.StatusOK, status)
+
+		assert.Equal(t, off, def)
+	})
+
+	t.Run("Invalid options", func(t *testing.T) {
+		for _, query := range []string{"dedup=fuzzy", "collapse=maybe", "dedup=off&collapse=true", "collapse=true"} {
+			status, _ := fetch(t, query)
+			assert.Equal(t, http.StatusBadRequest, status, query)
+		}
+	})
+}
+
+func TestNewsAggregatorHandler_HandleClusters(t *testing.T) {
+	m, err := manager.New("../../../resources", "../../../config/feeds_dictionary.json")
+	assert.NoError(t, err)
+
+	fetch := func(t *testing.T, query string) (int, []map[string]interface{}) {
+		handler := NewNewsHandler(m)
+
+		req := httptest.NewRequest(http.MethodGet, "/news/clusters?"+query, nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleClusters(w, req)
+
+		resp := w.Result()
+		defer func(Body io.ReadCloser) {
+			err := Body.Close()
+			if err != nil {
+				t.Error(err)
+			}
+		}(resp.Body)
+
+		var clustersJSON []map[string]interface{}
+		if resp.StatusCode == http.StatusOK {
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&clustersJSON))
+		}
+		return resp.StatusCode, clustersJSON
+	}
+
+	t.Run("Stories group the articles", func(t *testing.T) {
+		status, clustersJSON := fetch(t, "")
+		assert.Equal(t, http.StatusOK, status)
+		assert.NotEmpty(t, clustersJSON)
+
+		largest := clustersJSON[0]
+		assert.Greater(t, largest["size"].(float64), 1.0)
+		assert.Len(t, largest["articles"], int(largest["size"].(float64)))
+		assert.Len(t, largest["sources"], int(largest["sourceDiversity"].(float64)))
+		assert.NotEmpty(t, largest["headline"])
+		assert.NotEmpty(t, largest["start"])
+		assert.NotEmpty(t, largest["end"])
+		assert.NotEmpty(t, largest["span"])
+
+		previous := math.Inf(1)
+		for _, c := range clustersJSON {
+			assert.LessOrEqual(t, c["size"].(float64), previous)
+			previous = c["size"].(float64)
+		}
+	})
+
+	t.Run("Filters and limit", func(t *testing.T) {
+		status, clustersJSON := fetch(t, "sources=bbc-world&keywords=ukraine&limit=2")
+		assert.Equal(t, http.StatusOK, status)
+		assert.NotEmpty(t, clustersJSON)
+		assert.LessOrEqual(t, len(clustersJSON), 2)
+
+		for _, c := range clustersJSON {
+			assert.Equal(t, []interface{}{"bbc-world"}, c["sources"])
+		}
+	})
+
+	t.Run("Invalid options", func(t *testing.T) {
+		for _, query := range []string{"limit=many", "limit=-1", "sort-order=random", "q=(ukraine"} {
+			status, _ := fetch(t, query)
+			assert.Equal(t, http.StatusBadRequest, status, query)
+		}
+	})
+}
+
+func TestNewsAggregatorHandler_HandleTrends(t *testing.T) {
+	m, err := manager.New("../../../resources", "../../../config/feeds_dictionary.json")
+	assert.NoError(t, err)
+
+	fetch := func(t *testing.T, query string) (int, []map[string]interface{}) {
+		handler := NewNewsHandler(m)
+		handler.now = time.Date(2024, 5, 19, 12, 0, 0, 0, time.UTC)
+
+		req := httptest.NewRequest(http.MethodGet, "/trends?"+query, nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleTrends(w, req)
+
+		resp := w.Result()
+		defer func(Body io.ReadCloser) {
+			err := Body.Close()
+			if err != nil {
+				t.Error(err)
+			}
+		}(resp.Body)
+
+		var trendsJSON []map[string]interface{}
+		if resp.StatusCode == http.StatusOK {
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&trendsJSON))
+		}
+		return resp.StatusCode, trendsJSON
+	}
+
+	t.Run("Trending terms with daily counts", func(t *testing.T) {
+		status, trendsJSON := fetch(t, "window=2d&limit=5")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Len(t, trendsJSON, 5)
+
+		previous := math.Inf(1)
+		for _, tr := range trendsJSON {
+			assert.NotEmpty(t, tr["term"])
+			assert.LessOrEqual(t, tr["score"].(float64), previous)
+			previous = tr["score"].(float64)
+
+			counts := tr["counts"].([]interface{})
+			assert.Len(t, counts, 2)
+			assert.Equal(t, "2024-05-18", counts[0].(map[string]interface{})["date"])
+			assert.Equal(t, "2024-05-19", counts[1].(map[string]interface{})["date"])
+		}
+	})
+
+	t.Run("Sources", func(t *testing.T) {
+		status, all := fetch(t, "limit=0")
+		assert.Equal(t, http.StatusOK, status)
+		status, selected := fetch(t, "sources=bbc-world&limit=0")
+		assert.Equal(t, http.StatusOK, status)
+
+		assert.NotEmpty(t, selected)
+		assert.Less(t, len(selected), len(all))
+	})
+
+	t.Run("Invalid options", func(t *testing.T) {
+		for _, query := range []string{"window=week", "window=0d", "limit=-1", "sources=unknown"} {
+			status, _ := fetch(t, query)
+			assert.Equal(t, http.StatusBadRequest, status, query)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/jobs"
+)
+
+// RefreshHandler is a handler that refreshes the sources on demand with asynchronous jobs.
+type RefreshHandler struct {
+	manager ResourceManager
+	queue   JobQueue
+}
+
+// NewRefreshHandler creates a new RefreshHandler.
+func NewRefreshHandler(manager ResourceManager, queue JobQueue) *RefreshHandler {
+	return &RefreshHandler{
+		manager: manager,
+		queue:   queue,
+	}
+}
+
+// HandleSource handles POST /sources/{name}/refresh to enqueue a job refreshing the source.
+func (h *RefreshHandler) HandleSource(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	source := resource.Source(r.PathValue("name"))
+	if !h.manager.IsSourceSupported(source) {
+		http.Error(w, "Source not found", http.StatusNotFound)
+		return
+	}
+
+	h.enqueue(w, []resource.Source{source})
+}
+
+// HandleAll handles POST /refresh to enqueue a job refreshing all sources.
+func (h *RefreshHandler) HandleAll(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	h.enqueue(w, nil)
+}
+
+// HandleJob handles GET /jobs/{id} to report the status, duration, fetched bytes, parsed articles
+// and errors of the job.
+func (h *RefreshHandler) HandleJob(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	job, exists := h.queue.Job(r.PathValue("id"))
+	if !exists {
+		http.Error(w, "Job not found", http.StatusNotFound)
+		return
+	}
+
+	writeJob(w, http.StatusOK, job)
+}
+
+// enqueue enqueues a job refreshing the sources and responds with 202 Accepted and the job.
+func (h *RefreshHandler) enqueue(w http.ResponseWriter, sources []resource.Source) {
+	job, err := h.queue.Enqueue(sources)
+	if errors.Is(err, jobs.ErrQueueFull) {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to enqueue refresh: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Location", "/jobs/"+job.ID)
+	writeJob(w, http.StatusAccepted, job)
+}
+
+// writeJob writes the job in JSON with the status code.
+func writeJob(w http.ResponseWriter, statusCode int, job jobs.Job) {
+	results := make([]map[string]interface{}, 0, len(job.Results))
+	for _, result := range job.Results {
+		resultJSON := map[string]interface{}{
+			"source":         result.Source,
+			"changed":        result.Changed,
+			"bytesFetched":   result.BytesFetched,
+			"articlesParsed": result.ArticlesParsed,
+		}
+		if result.Error != "" {
+			resultJSON["error"] = result.Error
+		}
+		results = append(results, resultJSON)
+	}
+
+	errs := job.Errors()
+	if errs == nil {
+		errs = []string{}
+	}
+
+	jobJSON := map[string]interface{}{
+		"id":             job.ID,
+		"status":         job.Status,
+		"sources":        job.Sources,
+		"duration":       job.Duration().String(),
+		"bytesFetched":   job.BytesFetched(),
+		"articlesParsed": job.ArticlesParsed(),
+		"errors":         errs,
+		"results":        results,
+	}
+	setTime(jobJSON, "createdAt", job.CreatedAt)
+	setTime(jobJSON, "startedAt", job.StartedAt)
+	setTime(jobJSON, "finishedAt", job.FinishedAt)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	err := json.NewEncoder(w).Encode(jobJSON)
+	if err != nil {
+		http.Error(w, "Failed to encode job", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/cmd/web_server/handler/mocks"
+	"news-aggregator/jobs"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshHandler_HandleSource(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	createdAt := time.Date(2024, 5, 19, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		method         string
+		source         string
+		mockSetup      func(m *mocks.MockResourceManager, q *mocks.MockJobQueue)
+		expectedStatus int
+	}{
+		{"enqueued", http.MethodPost, "abc-news", func(m *mocks.MockResourceManager, q *mocks.MockJobQueue) {
+			m.EXPECT().IsSourceSupported(resource.Source("abc-news")).Return(true)
+			q.EXPECT().Enqueue([]resource.Source{"abc-news"}).Return(jobs.Job{
+				ID: "0123456789abcdef", Sources: []string{"abc-news"}, Status: jobs.Queued, CreatedAt: createdAt,
+			}, nil)
+		}, http.StatusAccepted},
+		{"unknown source", http.MethodPost, "unknown", func(m *mocks.MockResourceManager, q *mocks.MockJobQueue) {
+			m.EXPECT().IsSourceSupported(resource.Source("unknown")).Return(false)
+		}, http.StatusNotFound},
+		{"queue full", http.MethodPost, "abc-news", func(m *mocks.MockResourceManager, q *mocks.MockJobQueue) {
+			m.EXPECT().IsSourceSupported(resource.Source("abc-news")).Return(true)
+			q.EXPECT().Enqueue(gomock.Any()).Return(jobs.Job{}, jobs.ErrQueueFull)
+		}, http.StatusServiceUnavailable},
+		{"method not allowed", http.MethodGet, "abc-news", func(m *mocks.MockResourceManager, q *mocks.MockJobQueue) {},
+			http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockManager := mocks.NewMockResourceManager(ctrl)
+			mockQueue := mocks.NewMockJobQueue(ctrl)
+			tt.mockSetup(mockManager, mockQueue)
+
+			handler := NewRefreshHandler(mockManager, mockQueue)
+			req := httptest.NewRequest(tt.method, "/sources/"+tt.source+"/refresh", nil)
+			req.SetPathValue("name", tt.source)
+			rr := httptest.NewRecorder()
+
+			handler.HandleSource(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			if tt.expectedStatus != http.StatusAccepted {
+				return
+			}
+
+			assert.Equal(t, "/jobs/0123456789abcdef", rr.Header().Get("Location"))
+
+			var jobJSON map[string]interface{}
+			assert.NoError(t, json.NewDecoder(rr.Body).Decode(&jobJSON))
+			assert.Equal(t, "0123456789abcdef", jobJSON["id"])
+			assert.Equal(t, "queued", jobJSON["status"])
+			assert.Equal(t, "2024-05-19T10:00:00Z", jobJSON["createdAt"])
+			assert.NotContains(t, jobJSON, "startedAt")
+		})
+	}
+}
+
+func TestRefreshHandler_HandleAll(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueue := mocks.NewMockJobQueue(ctrl)
+	mockQueue.EXPECT().Enqueue(nil).Return(jobs.Job{}, errors.New("no sources available"))
+	mockQueue.EXPECT().Enqueue(nil).Return(jobs.Job{ID: "fedcba9876543210", Status: jobs.Queued}, nil)
+
+	handler := NewRefreshHandler(mocks.NewMockResourceManager(ctrl), mockQueue)
+
+	rr := httptest.NewRecorder()
+	handler.HandleAll(rr, httptest.NewRequest(http.MethodPost, "/refresh", nil))
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	rr = httptest.NewRecorder()
+	handler.HandleAll(rr, httptest.NewRequest(http.MethodPost, "/refresh", nil))
+	assert.Equal(t, http.StatusAccepted, rr.Code)
+	assert.Equal(t, "/jobs/fedcba9876543210", rr.Header().Get("Location"))
+
+	rr = httptest.NewRecorder()
+	handler.HandleAll(rr, httptest.NewRequest(http.MethodGet, "/refresh", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+}
+
+func TestRefreshHandler_HandleJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	startedAt := time.Date(2024, 5, 19, 10, 0, 0, 0, time.UTC)
+	mockQueue := mocks.NewMockJobQueue(ctrl)
+	mockQueue.EXPECT().Job("0123456789abcdef").Return(jobs.Job{
+		ID:         "0123456789abcdef",
+		Sources:    []string{"abc-news", "cnn"},
+		Status:     jobs.Failed,
+		CreatedAt:  startedAt,
+		StartedAt:  startedAt,
+		FinishedAt: startedAt.Add(1500 * time.Millisecond),
+		Results: []jobs.SourceResult{
+			{Source: "abc-news", BytesFetched: 2048, ArticlesParsed: 12, Changed: true},
+			{Source: "cnn", Error: "status code 502"},
+		},
+	}, true)
+	mockQueue.EXPECT().Job("unknown").Return(jobs.Job{}, false)
+
+	handler := NewRefreshHandler(mocks.NewMockResourceManager(ctrl), mockQueue)
+
+	req := httptest.NewRequest(http.MethodGet, "/jobs/0123456789abcdef", nil)
+	req.SetPathValue("id", "0123456789abcdef")
+	rr := httptest.NewRecorder()
+	handler.HandleJob(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var jobJSON map[string]interface{}
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&jobJSON))
+	assert.Equal(t, "failed", jobJSON["status"])
+	assert.Equal(t, "1.5s", jobJSON["duration"])
+	assert.Equal(t, float64(2048), jobJSON["bytesFetched"])
+	assert.Equal(t, float64(12), jobJSON["articlesParsed"])
+	assert.Equal(t, []interface{}{"cnn: status code 502"}, jobJSON["errors"])
+	assert.Equal(t, "2024-05-19T10:00:01Z", jobJSON["finishedAt"])
+	assert.Len(t, jobJSON["results"], 2)
+
+	req = httptest.NewRequest(http.MethodGet, "/jobs/unknown", nil)
+	req.SetPathValue("id", "unknown")
+	rr = httptest.NewRecorder()
+	handler.HandleJob(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
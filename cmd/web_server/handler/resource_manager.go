@@ -1,6 +1,12 @@
 package handler
 
-import "news-aggregator/aggregator/model/resource"
+import (
+	"news-aggregator/aggregator"
+	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/aggregator/parser"
+	"news-aggregator/fetcher"
+	"news-aggregator/manager"
+)
 
 // ResourceManager is a manager that responsible for retrieval of feeds from the storage,
 // forming them into structures.
@@ -13,8 +19,17 @@ type ResourceManager interface {
 	AvailableFeeds() string
 	// RegisterSource registers a new source with the given URL and format.
 	RegisterSource(name resource.Source, url string, format resource.Format) error
-	// UpdateSource updates the source.
-	UpdateSource(name resource.Source, url string, format resource.Format) error
+	// RegisterScheduledSource registers a new source along with the config of its parser and its update schedule.
+	RegisterScheduledSource(name resource.Source, url string, format resource.Format, config parser.Config,
+		spec string) error
+	// ProbeSource fetches and parses the content of a source without registering it.
+	ProbeSource(name resource.Source, url string, format resource.Format, config parser.Config) (manager.ProbeReport, error)
+	// ProbeSourceUpdate probes the registered source with the link and the format it is to be updated with.
+	ProbeSourceUpdate(name resource.Source, url string, format resource.Format) (manager.ProbeReport, error)
+	// DiscoverFeeds finds the feeds of the website at the page URL.
+	DiscoverFeeds(pageURL string) ([]manager.FeedCandidate, error)
+	// UpdateScheduledSource updates the source along with its update schedule, an empty one keeps the current.
+	UpdateScheduledSource(name resource.Source, url string, format resource.Format, spec string) error
 	// UpdateResource updates the source in the storage.
 	UpdateResource(source resource.Source) error
 	// DeleteSource deletes the source.
@@ -25,4 +40,9 @@ type ResourceManager interface {
 	GetSelectedResources(sourceNames []string) ([]resource.Resource, error)
 	// GetAllResources returns all known resource.Resource's from a file system.
 	GetAllResources() ([]resource.Resource, error)
+	// ParserFactory returns a factory serving the parsers of the sources configured in the feeds dictionary,
+	// and the parsers of the fallback factory for the other sources.
+	ParserFactory(fallback aggregator.Factory) aggregator.Factory
+	// SourcesHealth returns the health of fetching the sources updated since the start.
+	SourcesHealth() map[resource.Source]fetcher.Health
 }
@@ -0,0 +1,11 @@
+package handler
+
+import "news-aggregator/schedule"
+
+// Scheduler is a scheduler that updates every source when it is due.
+//
+//go:generate mockgen -source=scheduler.go -destination=mocks/mock_scheduler.go -package=mocks
+type Scheduler interface {
+	// Runs returns the last and the next runs of the scheduled sources in alphabetical order.
+	Runs() []schedule.Run
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/manager"
+)
+
+// SourcesDiscoverHandler is a handler that discovers the feeds of a website.
+type SourcesDiscoverHandler struct {
+	manager ResourceManager
+}
+
+// NewSourcesDiscoverHandler creates a new SourcesDiscoverHandler.
+func NewSourcesDiscoverHandler(manager ResourceManager) *SourcesDiscoverHandler {
+	return &SourcesDiscoverHandler{
+		manager: manager,
+	}
+}
+
+// Handle handles GET /sources/discover?url= to find the feeds of the website at the url,
+// from the most to the least suitable one.
+func (ch *SourcesDiscoverHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	pageURL := r.URL.Query().Get("url")
+	if err := manager.ValidateLink(pageURL); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	feeds, err := ch.manager.DiscoverFeeds(pageURL)
+	if err != nil {
+		http.Error(w, "Failed to fetch the page: "+err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	feedsJSON := make([]map[string]interface{}, 0, len(feeds))
+	for _, feed := range feeds {
+		feedsJSON = append(feedsJSON, map[string]interface{}{
+			"url":            feed.URL,
+			"format":         resource.FormatToString(feed.Format),
+			"title":          feed.Title,
+			"articlesParsed": feed.ArticlesParsed,
+			"advertised":     feed.Advertised,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(feedsJSON)
+	if err != nil {
+		http.Error(w, "Failed to encode feeds", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/cmd/web_server/handler/mocks"
+	"news-aggregator/manager"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourcesDiscoverHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockManager := mocks.NewMockResourceManager(ctrl)
+	mockManager.EXPECT().DiscoverFeeds("https://www.example.com").Return([]manager.FeedCandidate{
+		{URL: "https://www.example.com/rss", Format: resource.RSS, Title: "Example News", ArticlesParsed: 20,
+			Advertised: true},
+		{URL: "https://www.example.com/feed.json", Format: resource.JSONFEED, Title: "Example", ArticlesParsed: 10},
+	}, nil)
+
+	handler := NewSourcesDiscoverHandler(mockManager)
+	req := httptest.NewRequest(http.MethodGet, "/sources/discover?url=https://www.example.com", nil)
+	rr := httptest.NewRecorder()
+
+	handler.Handle(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	var feedsJSON []map[string]interface{}
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&feedsJSON))
+	assert.Equal(t, []map[string]interface{}{
+		{"url": "https://www.example.com/rss", "format": "RSS", "title": "Example News",
+			"articlesParsed": float64(20), "advertised": true},
+		{"url": "https://www.example.com/feed.json", "format": "JSONFEED", "title": "Example",
+			"articlesParsed": float64(10), "advertised": false},
+	}, feedsJSON)
+}
+
+func TestSourcesDiscoverHandler_Handle_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tests := []struct {
+		name           string
+		method         string
+		target         string
+		mockSetup      func(m *mocks.MockResourceManager)
+		expectedStatus int
+	}{
+		{"method not allowed", http.MethodPost, "/sources/discover?url=https://www.example.com",
+			func(m *mocks.MockResourceManager) {}, http.StatusMethodNotAllowed},
+		{"missing url", http.MethodGet, "/sources/discover", func(m *mocks.MockResourceManager) {},
+			http.StatusBadRequest},
+		{"relative url", http.MethodGet, "/sources/discover?url=www.example.com", func(m *mocks.MockResourceManager) {},
+			http.StatusBadRequest},
+		{"page error", http.MethodGet, "/sources/discover?url=https://www.example.com",
+			func(m *mocks.MockResourceManager) {
+				m.EXPECT().DiscoverFeeds("https://www.example.com").
+					Return(nil, fmt.Errorf("error fetching resource from link: status code 403"))
+			}, http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockManager := mocks.NewMockResourceManager(ctrl)
+			tt.mockSetup(mockManager)
+			handler := NewSourcesDiscoverHandler(mockManager)
+
+			req := httptest.NewRequest(tt.method, tt.target, nil)
+			rr := httptest.NewRecorder()
+
+			handler.Handle(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
+
+func TestSourcesDiscoverHandler_Handle_NoFeeds(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockManager := mocks.NewMockResourceManager(ctrl)
+	mockManager.EXPECT().DiscoverFeeds("https://www.example.com").Return(nil, nil)
+
+	handler := NewSourcesDiscoverHandler(mockManager)
+	req := httptest.NewRequest(http.MethodGet, "/sources/discover?url=https://www.example.com", nil)
+	rr := httptest.NewRecorder()
+
+	handler.Handle(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, "[]", rr.Body.String())
+}
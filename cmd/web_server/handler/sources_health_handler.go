@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// SourcesHealthHandler is a handler that returns the health of fetching the sources.
+type SourcesHealthHandler struct {
+	manager ResourceManager
+}
+
+// NewSourcesHealthHandler creates a new SourcesHealthHandler.
+func NewSourcesHealthHandler(manager ResourceManager) *SourcesHealthHandler {
+	return &SourcesHealthHandler{
+		manager: manager,
+	}
+}
+
+// Handle handles GET /sources/health to retrieve the health of the sources updated since the start,
+// in alphabetical order of the sources.
+func (ch *SourcesHealthHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	sourcesHealth := ch.manager.SourcesHealth()
+
+	healthJSON := make([]map[string]interface{}, 0, len(sourcesHealth))
+	for source, health := range sourcesHealth {
+		sourceJSON := map[string]interface{}{
+			"source":              string(source),
+			"healthy":             health.Healthy(),
+			"consecutiveFailures": health.ConsecutiveFailures,
+		}
+		if health.LastError != "" {
+			sourceJSON["lastError"] = health.LastError
+		}
+		setTime(sourceJSON, "lastSuccess", health.LastSuccess)
+		setTime(sourceJSON, "lastFailure", health.LastFailure)
+		setTime(sourceJSON, "backoffUntil", health.BackoffUntil)
+
+		healthJSON = append(healthJSON, sourceJSON)
+	}
+
+	sort.Slice(healthJSON, func(i, j int) bool {
+		return healthJSON[i]["source"].(string) < healthJSON[j]["source"].(string)
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(healthJSON)
+	if err != nil {
+		http.Error(w, "Failed to encode sources health", http.StatusInternalServerError)
+		return
+	}
+}
+
+// setTime sets the time in RFC 3339 to the key, unless the time is zero.
+func setTime(values map[string]interface{}, key string, t time.Time) {
+	if !t.IsZero() {
+		values[key] = t.Format(time.RFC3339)
+	}
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/cmd/web_server/handler/mocks"
+	"news-aggregator/fetcher"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourcesHealthHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lastSuccess := time.Date(2024, 5, 19, 10, 0, 0, 0, time.UTC)
+	mockManager := mocks.NewMockResourceManager(ctrl)
+	mockManager.EXPECT().SourcesHealth().Return(map[resource.Source]fetcher.Health{
+		"bbc-world": {LastSuccess: lastSuccess},
+		"abc-news": {
+			ConsecutiveFailures: 3,
+			LastError:           "error fetching resource from link: status code 502",
+			LastFailure:         lastSuccess.Add(time.Hour),
+			BackoffUntil:        lastSuccess.Add(2 * time.Hour),
+		},
+	})
+
+	handler := NewSourcesHealthHandler(mockManager)
+	req := httptest.NewRequest(http.MethodGet, "/sources/health", nil)
+	rr := httptest.NewRecorder()
+
+	handler.Handle(rr, req)
+
+	res := rr.Result()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+
+	var healthJSON []map[string]interface{}
+	assert.NoError(t, json.NewDecoder(res.Body).Decode(&healthJSON))
+	assert.Equal(t, 2, len(healthJSON))
+
+	assert.Equal(t, "abc-news", healthJSON[0]["source"])
+	assert.Equal(t, false, healthJSON[0]["healthy"])
+	assert.Equal(t, float64(3), healthJSON[0]["consecutiveFailures"])
+	assert.Equal(t, "error fetching resource from link: status code 502", healthJSON[0]["lastError"])
+	assert.Equal(t, "2024-05-19T12:00:00Z", healthJSON[0]["backoffUntil"])
+	assert.NotContains(t, healthJSON[0], "lastSuccess")
+
+	assert.Equal(t, "bbc-world", healthJSON[1]["source"])
+	assert.Equal(t, true, healthJSON[1]["healthy"])
+	assert.Equal(t, "2024-05-19T10:00:00Z", healthJSON[1]["lastSuccess"])
+	assert.NotContains(t, healthJSON[1], "lastError")
+}
+
+func TestSourcesHealthHandler_Handle_MethodNotAllowed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := NewSourcesHealthHandler(mocks.NewMockResourceManager(ctrl))
+	req := httptest.NewRequest(http.MethodPost, "/sources/health", nil)
+	rr := httptest.NewRecorder()
+
+	handler.Handle(rr, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Result().StatusCode)
+}
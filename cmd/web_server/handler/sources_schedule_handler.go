@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// SourcesScheduleHandler is a handler that returns the last and the next scheduled updates of the sources.
+type SourcesScheduleHandler struct {
+	scheduler Scheduler
+}
+
+// NewSourcesScheduleHandler creates a new SourcesScheduleHandler.
+func NewSourcesScheduleHandler(scheduler Scheduler) *SourcesScheduleHandler {
+	return &SourcesScheduleHandler{
+		scheduler: scheduler,
+	}
+}
+
+// Handle handles GET /sources/schedule to retrieve the scheduled updates of the sources
+// in alphabetical order of the sources.
+func (ch *SourcesScheduleHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	runs := ch.scheduler.Runs()
+
+	runsJSON := make([]map[string]interface{}, 0, len(runs))
+	for _, run := range runs {
+		runJSON := map[string]interface{}{
+			"source": run.Source,
+		}
+		if run.Schedule != "" {
+			runJSON["schedule"] = run.Schedule
+		}
+		setTime(runJSON, "lastRun", run.LastRun)
+		if run.LastError != "" {
+			runJSON["lastError"] = run.LastError
+		}
+		setTime(runJSON, "nextRun", run.NextRun)
+
+		runsJSON = append(runsJSON, runJSON)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(runsJSON)
+	if err != nil {
+		http.Error(w, "Failed to encode sources schedule", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"news-aggregator/cmd/web_server/handler/mocks"
+	"news-aggregator/schedule"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourcesScheduleHandler_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	lastRun := time.Date(2024, 5, 19, 10, 0, 0, 0, time.UTC)
+	mockScheduler := mocks.NewMockScheduler(ctrl)
+	mockScheduler.EXPECT().Runs().Return([]schedule.Run{
+		{Source: "abc-news", Schedule: "5m", LastRun: lastRun, LastError: "status code 502",
+			NextRun: lastRun.Add(5 * time.Minute)},
+		{Source: "bbc-world", NextRun: lastRun.Add(12 * time.Hour)},
+	})
+
+	handler := NewSourcesScheduleHandler(mockScheduler)
+	req := httptest.NewRequest(http.MethodGet, "/sources/schedule", nil)
+	rr := httptest.NewRecorder()
+
+	handler.Handle(rr, req)
+
+	res := rr.Result()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+
+	var runsJSON []map[string]interface{}
+	assert.NoError(t, json.NewDecoder(res.Body).Decode(&runsJSON))
+	assert.Equal(t, []map[string]interface{}{
+		{
+			"source":    "abc-news",
+			"schedule":  "5m",
+			"lastRun":   "2024-05-19T10:00:00Z",
+			"lastError": "status code 502",
+			"nextRun":   "2024-05-19T10:05:00Z",
+		},
+		{
+			"source":  "bbc-world",
+			"nextRun": "2024-05-19T22:00:00Z",
+		},
+	}, runsJSON)
+}
+
+func TestSourcesScheduleHandler_Handle_MethodNotAllowed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler := NewSourcesScheduleHandler(mocks.NewMockScheduler(ctrl))
+	req := httptest.NewRequest(http.MethodPut, "/sources/schedule", nil)
+	rr := httptest.NewRecorder()
+
+	handler.Handle(rr, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, rr.Result().StatusCode)
+}
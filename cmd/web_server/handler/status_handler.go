@@ -3,6 +3,7 @@ package handler
 import (
 	"fmt"
 	"net/http"
+	"news-aggregator/aggregator/cache"
 	"time"
 )
 
@@ -10,6 +11,7 @@ import (
 type StatusHandler struct {
 	startTime time.Time
 	version   string
+	cache     *cache.Cache
 }
 
 // NewStatusHandler creates a new StatusHandler instance.
@@ -20,6 +22,11 @@ func NewStatusHandler(version string) *StatusHandler {
 	}
 }
 
+// SetCache sets the cache of the parsed articles whose statistics are reported.
+func (ssh *StatusHandler) SetCache(c *cache.Cache) {
+	ssh.cache = c
+}
+
 // Handle is responsible for handling the request and response for the server status.
 func (ssh *StatusHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -49,4 +56,26 @@ func (ssh *StatusHandler) Handle(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Failed to write response", http.StatusInternalServerError)
 		return
 	}
+
+	if ssh.cache == nil {
+		return
+	}
+
+	stats := ssh.cache.Stats()
+
+	if _, err := fmt.Fprintf(w, "Cache Entries: %d\n", stats.Entries); err != nil {
+		http.Error(w, "Failed to write response", http.StatusInternalServerError)
+		return
+	}
+
+	if _, err := fmt.Fprintf(w, "Cache Size: %d of %d bytes\n", stats.Bytes, stats.MaxBytes); err != nil {
+		http.Error(w, "Failed to write response", http.StatusInternalServerError)
+		return
+	}
+
+	if _, err := fmt.Fprintf(w, "Cache Hits: %d\nCache Misses: %d\nCache Evictions: %d\n",
+		stats.Hits, stats.Misses, stats.Evictions); err != nil {
+		http.Error(w, "Failed to write response", http.StatusInternalServerError)
+		return
+	}
 }
@@ -3,6 +3,8 @@ package handler
 import (
 	"net/http"
 	"net/http/httptest"
+	"news-aggregator/aggregator/cache"
+	"news-aggregator/aggregator/model/resource"
 	"strings"
 	"testing"
 	"time"
@@ -63,3 +65,28 @@ func TestStatusHandler_Handle(t *testing.T) {
 			rr.Body.String(), expectedTime)
 	}
 }
+
+// TestStatusHandler_Handle_Cache tests the statistics of the cache reported by the Handle function of StatusHandler.
+func TestStatusHandler_Handle_Cache(t *testing.T) {
+	c := cache.New()
+	res, err := resource.New("source", resource.RSS, "content")
+	if err != nil {
+		t.Fatalf("failed to create resource: %v", err)
+	}
+	c.Get(*res, true)
+
+	handler := NewStatusHandler("1.0.0")
+	handler.SetCache(c)
+
+	req := httptest.NewRequest("GET", "http://example.com/status", nil)
+	rr := httptest.NewRecorder()
+
+	handler.Handle(rr, req)
+
+	for _, expected := range []string{"Cache Entries: 0\n", "Cache Size: 0 of 67108864 bytes\n", "Cache Hits: 0\n",
+		"Cache Misses: 1\n", "Cache Evictions: 0\n"} {
+		if !strings.Contains(rr.Body.String(), expected) {
+			t.Errorf("handler returned unexpected body: got %v want %v", rr.Body.String(), expected)
+		}
+	}
+}
@@ -1,21 +1,38 @@
 package main
 
 import (
+	"fmt"
 	"log"
+	"news-aggregator/aggregator/cache"
 	"news-aggregator/cmd/web_server"
 	"news-aggregator/cmd/web_server/handler"
+	"news-aggregator/fetcher"
+	"news-aggregator/jobs"
 	"news-aggregator/manager"
+	"news-aggregator/storage"
 	"os"
 	"path"
 	"strconv"
 	"time"
 )
 
+// Storage backends selected by the STORAGE_BACKEND environment variable.
+const (
+	// FilesBackend keeps the fetched contents of the sources in files of the STORAGE_PATH directory.
+	FilesBackend = "files"
+
+	// BoltBackend keeps the parsed articles in the bbolt database at STORAGE_DB_PATH.
+	BoltBackend = "bolt"
+)
+
 // Default values for environment variables.
 const (
-	// DefaultTimeout is the default timeout for the update scheduler.
+	// DefaultTimeout is the default update interval of the sources without a schedule of their own.
 	DefaultTimeout = "12h"
 
+	// DefaultScheduleJitter is the default limit of the random delay added to the scheduled updates.
+	DefaultScheduleJitter = "1m"
+
 	// DefaultPort is the default port number for the server.
 	DefaultPort = "8443"
 
@@ -25,11 +42,44 @@ const (
 	// DefaultStoragePath is the default path to the storage directory.
 	DefaultStoragePath = "resources"
 
+	// DefaultStorageBackend is the default storage of the fetched sources, see createStorage.
+	DefaultStorageBackend = FilesBackend
+
+	// DefaultStorageDBPath is the default path to the database of the bolt storage backend.
+	DefaultStorageDBPath = "resources/articles.db"
+
 	// DefaultCertFilePath is the default path to the certificate file.
 	DefaultCertFilePath = "/etc/tls/tls.crt"
 
 	// DefaultKeyFilePath is the default path to the key file.
 	DefaultKeyFilePath = "/etc/tls/tls.key"
+
+	// DefaultCacheSize is the default limit of the cache of the parsed articles in megabytes.
+	DefaultCacheSize = "64"
+
+	// DefaultUpdateConcurrency is the default number of the sources fetched at the same time by the scheduler.
+	DefaultUpdateConcurrency = "4"
+
+	// DefaultRetentionMaxAge is the default age of the removed snapshots, e.g. "30d" or "36h". Zero keeps them all.
+	DefaultRetentionMaxAge = "0"
+
+	// DefaultRetentionMaxSnapshots is the default number of the snapshots kept per source. Zero keeps them all.
+	DefaultRetentionMaxSnapshots = "0"
+
+	// DefaultRetentionMaxSize is the default total size of the snapshots in megabytes. Zero leaves it unlimited.
+	DefaultRetentionMaxSize = "0"
+
+	// DefaultFetchTimeout is the default limit of a single request to a source.
+	DefaultFetchTimeout = "30s"
+
+	// DefaultFetchRetries is the default number of the retries of a failed request to a source.
+	DefaultFetchRetries = "3"
+
+	// DefaultUserAgent is the default User-Agent header of the requests to the sources.
+	DefaultUserAgent = fetcher.DefaultUserAgent
+
+	// DefaultLegacyDateFormat defines whether the date filters read calendar dates in the legacy yyyy-dd-mm layout.
+	DefaultLegacyDateFormat = "false"
 )
 
 func main() {
@@ -41,12 +91,39 @@ func main() {
 
 	managerConfigPath := getEnv("MANAGER_CONFIG_PATH", path.Join(basePath, DefaultManagerConfigPath))
 	storagePath := getEnv("STORAGE_PATH", path.Join(basePath, DefaultStoragePath))
-	m, err := createResourceManager(managerConfigPath, storagePath)
+	storageDBPath := getEnv("STORAGE_DB_PATH", path.Join(basePath, DefaultStorageDBPath))
+	s, err := createStorage(getEnv("STORAGE_BACKEND", DefaultStorageBackend), storagePath, storageDBPath)
+
+	if err != nil {
+		log.Fatalf("failed to create storage: %v", err)
+	}
+
+	m, err := createResourceManager(managerConfigPath, s)
 
 	if err != nil {
 		log.Fatalf("failed to create resource manager: %v", err)
 	}
 
+	concurrency, err := strconv.Atoi(getEnv("UPDATE_CONCURRENCY", DefaultUpdateConcurrency))
+
+	if err != nil || concurrency < 1 {
+		log.Fatalf("Failed to parse UPDATE_CONCURRENCY: must be a positive number")
+	}
+
+	m.SetConcurrency(concurrency)
+
+	client, err := createFetchClient(
+		getEnv("FETCH_TIMEOUT", DefaultFetchTimeout),
+		getEnv("FETCH_RETRIES", DefaultFetchRetries),
+		getEnv("USER_AGENT", DefaultUserAgent),
+	)
+
+	if err != nil {
+		log.Fatalf("Failed to create fetch client: %v", err)
+	}
+
+	m.SetFetchClient(client)
+
 	timeoutStr := getEnv("TIMEOUT", DefaultTimeout)
 	timeout, err := time.ParseDuration(timeoutStr)
 
@@ -54,9 +131,39 @@ func main() {
 		log.Fatalf("Failed to parse TIMEOUT duration: %v", err)
 	}
 
+	policy, err := createRetentionPolicy(
+		getEnv("RETENTION_MAX_AGE", DefaultRetentionMaxAge),
+		getEnv("RETENTION_MAX_SNAPSHOTS", DefaultRetentionMaxSnapshots),
+		getEnv("RETENTION_MAX_SIZE", DefaultRetentionMaxSize),
+	)
+
+	if err != nil {
+		log.Fatalf("Failed to parse retention policy: %v", err)
+	}
+
+	jitter, err := time.ParseDuration(getEnv("SCHEDULE_JITTER", DefaultScheduleJitter))
+
+	if err != nil || jitter < 0 {
+		log.Fatalf("Failed to parse SCHEDULE_JITTER: must be a non-negative duration")
+	}
+
+	c, err := createCache(getEnv("CACHE_SIZE", DefaultCacheSize))
+	if err != nil {
+		log.Fatalf("Failed to parse CACHE_SIZE: %v", err)
+	}
+	// The cache is set before the scheduler and the queue start updating the sources concurrently.
+	m.SetCache(c)
+
 	scheduler := web_server.NewUpdateScheduler(m, timeout)
+	scheduler.SetRetentionPolicy(policy)
+	scheduler.SetMaxJitter(jitter)
+	scheduler.SetConcurrency(concurrency)
 	scheduler.Start()
 
+	queue := jobs.NewQueue(m)
+	queue.SetConcurrency(concurrency)
+	queue.Start()
+
 	port, err := getPort()
 
 	if err != nil {
@@ -66,7 +173,12 @@ func main() {
 	certFilePath := getEnv("CERT_FILE_PATH", DefaultCertFilePath)
 	keyFilePath := getEnv("KEY_FILE_PATH", DefaultKeyFilePath)
 
-	startServer(port, certFilePath, keyFilePath, m)
+	legacyDateFormat, err := strconv.ParseBool(getEnv("LEGACY_DATE_FORMAT", DefaultLegacyDateFormat))
+	if err != nil {
+		log.Fatalf("Failed to parse LEGACY_DATE_FORMAT: %v", err)
+	}
+
+	startServer(port, certFilePath, keyFilePath, legacyDateFormat, m, c, scheduler, queue)
 }
 
 // getCurrentDirectory retrieves the current working directory.
@@ -75,9 +187,90 @@ func getCurrentDirectory() (string, error) {
 	return basePath, err
 }
 
+// createStorage initializes and returns the storage of the backend.
+func createStorage(backend, storagePath, storageDBPath string) (storage.Storage, error) {
+	switch backend {
+	case FilesBackend:
+		return storage.New(storagePath), nil
+	case BoltBackend:
+		return storage.NewBolt(storageDBPath)
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %s", backend)
+	}
+}
+
 // createResourceManager initializes and returns the resource manager.
-func createResourceManager(managerConfigPath, storagePath string) (*manager.ResourceManager, error) {
-	return manager.New(storagePath, managerConfigPath)
+func createResourceManager(managerConfigPath string, s storage.Storage) (*manager.ResourceManager, error) {
+	return manager.NewWithStorage(s, managerConfigPath)
+}
+
+// createCache initializes and returns the cache of the parsed articles limited to the size in megabytes.
+func createCache(size string) (*cache.Cache, error) {
+	megabytes, err := strconv.Atoi(size)
+	if err != nil {
+		return nil, err
+	}
+	if megabytes < 1 {
+		return nil, fmt.Errorf("cache size must be positive: %d", megabytes)
+	}
+
+	c := cache.New()
+	c.SetMaxBytes(int64(megabytes) << 20)
+	return c, nil
+}
+
+// createFetchClient initializes and returns the client fetching the sources.
+func createFetchClient(timeout, retries, userAgent string) (*fetcher.Client, error) {
+	duration, err := time.ParseDuration(timeout)
+	if err != nil {
+		return nil, fmt.Errorf("invalid FETCH_TIMEOUT: %v", err)
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("FETCH_TIMEOUT must be positive: %s", timeout)
+	}
+
+	maxRetries, err := strconv.Atoi(retries)
+	if err != nil {
+		return nil, fmt.Errorf("invalid FETCH_RETRIES: %v", err)
+	}
+	if maxRetries < 0 {
+		return nil, fmt.Errorf("FETCH_RETRIES cannot be negative: %d", maxRetries)
+	}
+
+	client := fetcher.New()
+	client.SetTimeout(duration)
+	client.SetMaxRetries(maxRetries)
+	client.SetUserAgent(userAgent)
+	return client, nil
+}
+
+// createRetentionPolicy initializes and returns the retention policy of the snapshots compacted by the scheduler.
+// The maximum size is in megabytes.
+func createRetentionPolicy(maxAge, maxSnapshots, maxSize string) (storage.RetentionPolicy, error) {
+	age, err := storage.ParseMaxAge(maxAge)
+	if err != nil {
+		return storage.RetentionPolicy{}, fmt.Errorf("invalid RETENTION_MAX_AGE: %v", err)
+	}
+
+	snapshots, err := strconv.Atoi(maxSnapshots)
+	if err != nil {
+		return storage.RetentionPolicy{}, fmt.Errorf("invalid RETENTION_MAX_SNAPSHOTS: %v", err)
+	}
+
+	megabytes, err := strconv.ParseInt(maxSize, 10, 64)
+	if err != nil {
+		return storage.RetentionPolicy{}, fmt.Errorf("invalid RETENTION_MAX_SIZE: %v", err)
+	}
+
+	if age < 0 || snapshots < 0 || megabytes < 0 {
+		return storage.RetentionPolicy{}, fmt.Errorf("retention limits cannot be negative")
+	}
+
+	return storage.RetentionPolicy{
+		MaxAge:       age,
+		MaxSnapshots: snapshots,
+		MaxBytes:     megabytes << 20,
+	}, nil
 }
 
 // getPort returns the port number to use for the server.
@@ -90,11 +283,31 @@ func getPort() (string, error) {
 }
 
 // startServer initializes and starts the web server.
-func startServer(port, certFilePath, keyFilePath string, m *manager.ResourceManager) {
+func startServer(port, certFilePath, keyFilePath string, legacyDateFormat bool, m *manager.ResourceManager,
+	c *cache.Cache, scheduler *web_server.UpdateScheduler, queue *jobs.Queue) {
+
+	newsHandler := handler.NewNewsHandler(m)
+	newsHandler.SetLegacyDateLayout(legacyDateFormat)
+	newsHandler.SetCache(c)
+
+	refreshHandler := handler.NewRefreshHandler(m, queue)
+
+	statusHandler := handler.NewStatusHandler("1.0")
+	statusHandler.SetCache(c)
+
 	server := web_server.NewServerBuilder().
 		SetPort(port).
-		AddHandler("/news", handler.NewNewsHandler(m).Handle).
+		SetStatusHandler(statusHandler).
+		AddHandler("/news", newsHandler.Handle).
+		AddHandler("/news/clusters", newsHandler.HandleClusters).
+		AddHandler("/trends", newsHandler.HandleTrends).
 		AddHandler("/sources", handler.NewFeedsManagerHandler(m).Handle).
+		AddHandler("/sources/health", handler.NewSourcesHealthHandler(m).Handle).
+		AddHandler("/sources/schedule", handler.NewSourcesScheduleHandler(scheduler).Handle).
+		AddHandler("/sources/discover", handler.NewSourcesDiscoverHandler(m).Handle).
+		AddHandler("/sources/{name}/refresh", refreshHandler.HandleSource).
+		AddHandler("/refresh", refreshHandler.HandleAll).
+		AddHandler("/jobs/{id}", refreshHandler.HandleJob).
 		AddHandler("/availableFeeds", handler.NewAvailableFeedsHandler(m).Handle).
 		Build()
 
@@ -5,7 +5,9 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"news-aggregator/storage"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -84,3 +86,63 @@ func TestStartServer(t *testing.T) {
 		t.Fatalf("failed to remove config directory: %v", err)
 	}
 }
+
+// TestCreateStorage tests the creation of the storage of every backend.
+func TestCreateStorage(t *testing.T) {
+	dir := t.TempDir()
+
+	s, err := createStorage(FilesBackend, filepath.Join(dir, "resources"), filepath.Join(dir, "articles.db"))
+	assert.NoError(t, err)
+	assert.IsType(t, &storage.FileStorage{}, s)
+
+	s, err = createStorage(BoltBackend, filepath.Join(dir, "resources"), filepath.Join(dir, "articles.db"))
+	assert.NoError(t, err)
+	assert.IsType(t, &storage.BoltStorage{}, s)
+	assert.NoError(t, s.(*storage.BoltStorage).Close())
+
+	_, err = createStorage("unknown", filepath.Join(dir, "resources"), filepath.Join(dir, "articles.db"))
+	assert.Error(t, err)
+}
+
+// TestCreateCache tests the creation of the cache limited to the size in megabytes.
+func TestCreateCache(t *testing.T) {
+	c, err := createCache("2")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2<<20), c.Stats().MaxBytes)
+
+	for _, size := range []string{"0", "-1", "large"} {
+		_, err = createCache(size)
+		assert.Error(t, err, "size %s should be invalid", size)
+	}
+}
+
+func TestCreateFetchClient(t *testing.T) {
+	client, err := createFetchClient(DefaultFetchTimeout, DefaultFetchRetries, DefaultUserAgent)
+	assert.NoError(t, err)
+	assert.NotNil(t, client)
+
+	_, err = createFetchClient("10s", "0", "custom-agent/2.0")
+	assert.NoError(t, err)
+
+	invalid := [][2]string{{"soon", "3"}, {"0s", "3"}, {"-5s", "3"}, {"10s", "many"}, {"10s", "-1"}}
+	for _, values := range invalid {
+		_, err = createFetchClient(values[0], values[1], DefaultUserAgent)
+		assert.Error(t, err, "settings %v should be invalid", values)
+	}
+}
+
+func TestCreateRetentionPolicy(t *testing.T) {
+	policy, err := createRetentionPolicy("30d", "10", "100")
+	assert.NoError(t, err)
+	assert.Equal(t, storage.RetentionPolicy{MaxAge: 30 * 24 * time.Hour, MaxSnapshots: 10, MaxBytes: 100 << 20}, policy)
+
+	policy, err = createRetentionPolicy(DefaultRetentionMaxAge, DefaultRetentionMaxSnapshots, DefaultRetentionMaxSize)
+	assert.NoError(t, err)
+	assert.True(t, policy.IsEmpty(), "The default policy should keep all snapshots")
+
+	invalid := [][3]string{{"month", "10", "100"}, {"30d", "ten", "100"}, {"30d", "10", "large"}, {"30d", "-1", "100"}}
+	for _, values := range invalid {
+		_, err = createRetentionPolicy(values[0], values[1], values[2])
+		assert.Error(t, err, "policy %v should be invalid", values)
+	}
+}
@@ -1,8 +1,15 @@
 package web_server
 
+import (
+	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/storage"
+)
+
 // Manager is an interface that defines the methods for managing resources.
 //
 //go:generate mockgen -source=manager.go -destination=mocks/mock_manager.go -package=mocks
 type Manager interface {
-	UpdateAllSources() error
+	SourceSchedules() map[resource.Source]string
+	UpdateSources(sources []resource.Source) error
+	PruneSnapshots(policy storage.RetentionPolicy) ([]storage.Snapshot, error)
 }
@@ -0,0 +1,52 @@
+package web_server
+
+import (
+	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/schedule"
+	"time"
+)
+
+// sourceRun is the state of the scheduled updates of a source kept by the UpdateScheduler.
+type sourceRun struct {
+	source   resource.Source
+	spec     string
+	schedule schedule.Schedule
+	lastRun  time.Time
+	lastErr  error
+	nextRun  time.Time
+	index    int // Index of the run in the queue
+}
+
+// runQueue is a priority queue of the source runs ordered by their next run, see container/heap.
+type runQueue []*sourceRun
+
+func (q runQueue) Len() int { return len(q) }
+
+func (q runQueue) Less(i, j int) bool {
+	if q[i].nextRun.Equal(q[j].nextRun) {
+		return q[i].source < q[j].source
+	}
+	return q[i].nextRun.Before(q[j].nextRun)
+}
+
+func (q runQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *runQueue) Push(x any) {
+	run := x.(*sourceRun)
+	run.index = len(*q)
+	*q = append(*q, run)
+}
+
+func (q *runQueue) Pop() any {
+	old := *q
+	n := len(old)
+	run := old[n-1]
+	old[n-1] = nil
+	run.index = -1
+	*q = old[:n-1]
+	return run
+}
@@ -13,8 +13,9 @@ const (
 
 // ServerBuilder is a builder pattern for creating a new http.Server instance.
 type ServerBuilder struct {
-	port     string
-	handlers map[string]http.HandlerFunc
+	port          string
+	handlers      map[string]http.HandlerFunc
+	statusHandler *handler.StatusHandler
 }
 
 // NewServerBuilder creates a new ServerBuilder instance.
@@ -31,6 +32,12 @@ func (sb *ServerBuilder) SetPort(port string) *ServerBuilder {
 	return sb
 }
 
+// SetStatusHandler sets the handler of the "/status" path, by default it reports the status of version 1.0.
+func (sb *ServerBuilder) SetStatusHandler(statusHandler *handler.StatusHandler) *ServerBuilder {
+	sb.statusHandler = statusHandler
+	return sb
+}
+
 // AddHandler adds a new handler to the server.
 func (sb *ServerBuilder) AddHandler(path string, handler http.HandlerFunc) *ServerBuilder {
 	sb.handlers[path] = handler
@@ -45,7 +52,11 @@ func (sb *ServerBuilder) Build() *http.Server {
 		mux.HandleFunc(path, hand)
 	}
 
-	mux.HandleFunc("/status", handler.NewStatusHandler("1.0").Handle)
+	statusHandler := sb.statusHandler
+	if statusHandler == nil {
+		statusHandler = handler.NewStatusHandler("1.0")
+	}
+	mux.HandleFunc("/status", statusHandler.Handle)
 
 	return &http.Server{
 		Addr:    ":" + sb.port,
@@ -73,3 +73,32 @@ func TestServerBuilder(t *testing.T) {
 		t.Errorf("expected status 200, got %d", rec.Code)
 	}
 }
+
+// TestServerBuilder_PathValues tests that the wildcards of the paths are passed to the handlers
+// and do not shadow the fixed paths.
+func TestServerBuilder_PathValues(t *testing.T) {
+	named := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("refresh " + r.PathValue("name")))
+	}
+	fixed := func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("health"))
+	}
+
+	server := NewServerBuilder().
+		AddHandler("/sources/{name}/refresh", named).
+		AddHandler("/sources/health", fixed).
+		Build()
+
+	for path, expected := range map[string]string{
+		"/sources/abc-news/refresh": "refresh abc-news",
+		"/sources/health":           "health",
+	} {
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		rec := httptest.NewRecorder()
+		server.Handler.ServeHTTP(rec, req)
+
+		if rec.Body.String() != expected {
+			t.Errorf("expected %s to respond %q, got %q", path, expected, rec.Body.String())
+		}
+	}
+}
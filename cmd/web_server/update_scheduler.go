@@ -1,54 +1,357 @@
 package web_server
 
 import (
+	"container/heap"
+	"errors"
 	"log"
+	"math/rand"
+	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/manager"
+	"news-aggregator/schedule"
+	"news-aggregator/storage"
+	"sort"
+	"sync"
 	"time"
 )
 
-// UpdateScheduler schedules updates based on a timeout duration.
+// DefaultMaxJitter is the default limit of the random delay added to the next run of a source,
+// so that the sources sharing a schedule are not fetched at once.
+const DefaultMaxJitter = time.Minute
+
+// DefaultConcurrency is the default number of the sources updated at the same time.
+const DefaultConcurrency = 4
+
+// DefaultSyncInterval is the longest time before the scheduler picks up the sources registered,
+// deleted or rescheduled since the last run.
+const DefaultSyncInterval = time.Minute
+
+// UpdateScheduler updates every source when it is due according to its schedule, see manager.ResourceManager.
+// The sources without a schedule of their own are updated on the timeout interval.
+// Every due source is updated by a pool of workers on its own and rescheduled once its update finishes.
 type UpdateScheduler struct {
-	manager Manager
-	timeout time.Duration
-	stop    chan struct{} // Channel to signal stopping the scheduler
+	manager      Manager
+	timeout      time.Duration           // Default interval of the sources without a schedule
+	maxJitter    time.Duration           // Limit of the random delay added to the next runs
+	syncInterval time.Duration           // Longest wait before the sources are synced with the manager
+	concurrency  int                     // Number of the sources updated at the same time
+	policy       storage.RetentionPolicy // Retention of the stored snapshots, compacted after every update
+	now          func() time.Time
+	stop         chan struct{} // Channel to signal stopping the scheduler
+	done         chan struct{} // Channel closed once the scheduler is stopped
+	wake         chan struct{} // Channel signaled once an update finishes
+	startOnce    sync.Once
+	stopOnce     sync.Once
+	workers      sync.WaitGroup // Running updates
+	pruneMu      sync.Mutex     // Held while the snapshots are pruned
+
+	mu      sync.Mutex
+	runs    map[resource.Source]*sourceRun
+	queue   runQueue // Runs of the sources waiting for their next update, the earliest first
+	running int      // Number of the running updates
 }
 
 // NewUpdateScheduler creates a new UpdateScheduler instance.
+// The timeout is the interval of the sources without a schedule of their own.
 func NewUpdateScheduler(m Manager, timeout time.Duration) *UpdateScheduler {
 	return &UpdateScheduler{
-		manager: m,
-		timeout: timeout,
-		stop:    make(chan struct{}),
+		manager:      m,
+		timeout:      timeout,
+		maxJitter:    DefaultMaxJitter,
+		syncInterval: DefaultSyncInterval,
+		concurrency:  DefaultConcurrency,
+		now:          time.Now,
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+		wake:         make(chan struct{}, 1),
+		runs:         make(map[resource.Source]*sourceRun),
+	}
+}
+
+// SetRetentionPolicy sets the policy of the snapshots pruned after every update.
+// An empty policy keeps all snapshots.
+func (s *UpdateScheduler) SetRetentionPolicy(policy storage.RetentionPolicy) {
+	s.policy = policy
+}
+
+// SetMaxJitter sets the limit of the random delay added to the next run of a source.
+// The delay never exceeds a tenth of the time to the run. Zero disables the jitter, negative values are ignored.
+func (s *UpdateScheduler) SetMaxJitter(maxJitter time.Duration) {
+	if maxJitter < 0 {
+		return
+	}
+	s.maxJitter = maxJitter
+}
+
+// SetSyncInterval sets the longest time before the scheduler picks up the changes of the sources.
+// Values lower than one are ignored.
+func (s *UpdateScheduler) SetSyncInterval(interval time.Duration) {
+	if interval < 1 {
+		return
 	}
+	s.syncInterval = interval
+}
+
+// SetConcurrency sets the number of the sources updated at the same time. Values lower than one are ignored.
+func (s *UpdateScheduler) SetConcurrency(n int) {
+	if n < 1 {
+		return
+	}
+	s.concurrency = n
 }
 
 // Start starts the update scheduling process in a separate goroutine.
+// It has no effect once the scheduler is started or stopped.
 func (s *UpdateScheduler) Start() {
-	log.Printf("Starting update scheduler with timeout %s ...\n", s.timeout.String())
+	s.startOnce.Do(func() {
+		log.Printf("Starting update scheduler with default interval %s ...\n", s.timeout.String())
+		go s.loop()
+	})
+}
 
-	go func() {
-		ticker := time.NewTicker(s.timeout)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-ticker.C:
-				log.Println("Updating resources...")
-				err := s.manager.UpdateAllSources()
-				if err != nil {
-					log.Printf("Failed to update resources: %v", err)
-				}
-				t := time.Now().Format("2006-01-02 15:04:05")
-				log.Println("Resources updated at", t)
-
-			case <-s.stop:
-				log.Println("Stopping update scheduler...")
-				return
+// Stop stops the update scheduler and waits for the running updates to finish, the due sources waiting
+// for a worker are not updated. It may be called more than once and before the scheduler is started.
+func (s *UpdateScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		// A scheduler that is never started has nothing to wait for.
+		s.startOnce.Do(func() { close(s.done) })
+	})
+	<-s.done
+}
+
+// loop dispatches the due sources to the workers until the scheduler is stopped.
+func (s *UpdateScheduler) loop() {
+	defer close(s.done)
+	defer s.workers.Wait()
+
+	for {
+		select {
+		case <-s.stop:
+			log.Println("Stopping update scheduler...")
+			return
+		default:
+		}
+
+		if due := s.due(); len(due) > 0 {
+			for _, run := range due {
+				s.dispatch(run)
 			}
+			continue
+		}
+
+		timer := time.NewTimer(s.wait())
+		select {
+		case <-timer.C:
+		case <-s.wake:
+			timer.Stop()
+		case <-s.stop:
+			timer.Stop()
+			log.Println("Stopping update scheduler...")
+			return
+		}
+	}
+}
+
+// dispatch updates the source of the run by a worker, waking the loop once the update finishes.
+func (s *UpdateScheduler) dispatch(run *sourceRun) {
+	s.workers.Add(1)
+
+	go func() {
+		defer s.workers.Done()
+
+		s.update(run)
+
+		select {
+		case s.wake <- struct{}{}:
+		default:
 		}
 	}()
 }
 
-// Stop stops the update scheduler.
-func (s *UpdateScheduler) Stop() {
-	close(s.stop)
+// Runs returns the last and the next runs of the scheduled sources in alphabetical order.
+func (s *UpdateScheduler) Runs() []schedule.Run {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	runs := make([]schedule.Run, 0, len(s.runs))
+	for _, run := range s.runs {
+		r := schedule.Run{
+			Source:   string(run.source),
+			Schedule: run.spec,
+			LastRun:  run.lastRun,
+			NextRun:  run.nextRun,
+		}
+		if run.lastErr != nil {
+			r.LastError = run.lastErr.Error()
+		}
+		runs = append(runs, r)
+	}
+
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].Source < runs[j].Source
+	})
+
+	return runs
+}
+
+// due syncs the sources with the manager and takes the runs of the sources due for an update off the queue,
+// as many of them as there are idle workers. The runs taken off are counted as running.
+func (s *UpdateScheduler) due() []*sourceRun {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sync(now)
+
+	var due []*sourceRun
+	for len(s.queue) > 0 && s.running < s.concurrency && !s.queue[0].nextRun.After(now) {
+		due = append(due, heap.Pop(&s.queue).(*sourceRun))
+		s.running++
+	}
+	return due
+}
+
+// sync schedules the sources registered since the last sync, drops the deleted ones and reschedules
+// the sources whose schedule has changed. It is called with the lock held.
+func (s *UpdateScheduler) sync(now time.Time) {
+	schedules := s.manager.SourceSchedules()
+
+	for source, spec := range schedules {
+		run, exists := s.runs[source]
+		if exists && run.spec == spec {
+			continue
+		}
+
+		if !exists {
+			run = &sourceRun{source: source}
+			s.runs[source] = run
+			heap.Push(&s.queue, run)
+		}
+
+		run.spec = spec
+		run.schedule = s.parse(source, spec)
+		// A running source is rescheduled once its update finishes.
+		if run.index >= 0 {
+			run.nextRun = s.next(run.schedule, now)
+			heap.Fix(&s.queue, run.index)
+		}
+	}
+
+	for source, run := range s.runs {
+		if _, exists := schedules[source]; !exists {
+			if run.index >= 0 {
+				heap.Remove(&s.queue, run.index)
+			}
+			delete(s.runs, source)
+		}
+	}
+}
+
+// parse returns the schedule of the source, or the default interval if the source has none.
+func (s *UpdateScheduler) parse(source resource.Source, spec string) schedule.Schedule {
+	if spec == "" {
+		return schedule.Interval(s.timeout)
+	}
+
+	sched, err := schedule.Parse(spec)
+	if err != nil {
+		log.Printf("Invalid schedule of source %s, the default interval is used: %v", source, err)
+		return schedule.Interval(s.timeout)
+	}
+	return sched
+}
+
+// next returns the next run of the schedule after now, delayed by a random jitter.
+// A schedule that never matches falls back to the default interval.
+func (s *UpdateScheduler) next(sched schedule.Schedule, now time.Time) time.Time {
+	next := sched.Next(now)
+	if next.IsZero() {
+		next = now.Add(s.timeout)
+	}
+
+	limit := min(s.maxJitter, next.Sub(now)/10)
+	if limit > 0 {
+		next = next.Add(time.Duration(rand.Int63n(int64(limit) + 1)))
+	}
+	return next
+}
+
+// wait returns the time until the next run, or until the next sync if it comes first.
+// With all workers busy, the next run waits for an update to finish.
+func (s *UpdateScheduler) wait() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wait := s.syncInterval
+	if len(s.queue) > 0 && s.running < s.concurrency {
+		wait = min(wait, s.queue[0].nextRun.Sub(s.now()))
+	}
+	return max(wait, 0)
+}
+
+// update updates the source of the run, records the result of the run and schedules the next one.
+// The run of a source deleted meanwhile is dropped.
+func (s *UpdateScheduler) update(run *sourceRun) {
+	log.Printf("Updating resource %s...", run.source)
+	err := s.manager.UpdateSources([]resource.Source{run.source})
+	if err != nil {
+		log.Printf("Failed to update resource %s: %v", run.source, err)
+	}
+
+	if failed := sourceErrors(err); failed != nil {
+		err = failed[run.source]
+	}
+
+	s.mu.Lock()
+	now := s.now()
+	s.running--
+	run.lastRun = now
+	run.lastErr = err
+	run.nextRun = s.next(run.schedule, now)
+	if s.runs[run.source] == run {
+		heap.Push(&s.queue, run)
+	}
+	s.mu.Unlock()
+
+	t := now.Format("2006-01-02 15:04:05")
+	log.Printf("Resource %s updated at %s", run.source, t)
+
+	if !s.policy.IsEmpty() {
+		s.prune()
+	}
+}
+
+// sourceErrors returns the errors of the failed sources if the error is a *manager.UpdateError, nil otherwise.
+func sourceErrors(err error) map[resource.Source]error {
+	var updateErr *manager.UpdateError
+	if !errors.As(err, &updateErr) {
+		return nil
+	}
+
+	failed := make(map[resource.Source]error)
+	for _, result := range updateErr.Failed() {
+		failed[result.Source] = result.Err
+	}
+	return failed
+}
+
+// prune removes the snapshots exceeding the retention policy.
+// It is skipped while the snapshots are pruned after another update.
+func (s *UpdateScheduler) prune() {
+	if !s.pruneMu.TryLock() {
+		return
+	}
+	defer s.pruneMu.Unlock()
+
+	pruned, err := s.manager.PruneSnapshots(s.policy)
+	if err != nil {
+		log.Printf("Failed to prune snapshots: %v", err)
+		return
+	}
+
+	var size int64
+	for _, snapshot := range pruned {
+		size += snapshot.Size
+	}
+	log.Printf("Pruned %d snapshots of %d bytes", len(pruned), size)
 }
@@ -3,8 +3,14 @@ package web_server
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"log"
+	"news-aggregator/aggregator/model/resource"
 	"news-aggregator/cmd/web_server/mocks"
+	"news-aggregator/manager"
+	"news-aggregator/schedule"
+	"news-aggregator/storage"
+	"sync"
 	"testing"
 	"time"
 
@@ -22,7 +28,8 @@ func TestUpdateScheduler_LogMessages(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockManager := mocks.NewMockManager(ctrl)
-	mockManager.EXPECT().UpdateAllSources().Return(nil).AnyTimes()
+	mockManager.EXPECT().SourceSchedules().Return(map[resource.Source]string{"bbc-world": ""}).AnyTimes()
+	mockManager.EXPECT().UpdateSources([]resource.Source{"bbc-world"}).Return(nil).AnyTimes()
 
 	timeout := time.Millisecond * 100
 	scheduler := NewUpdateScheduler(mockManager, timeout)
@@ -39,8 +46,8 @@ func TestUpdateScheduler_LogMessages(t *testing.T) {
 	<-done
 
 	expectedMessages := []string{
-		"Updating resources...",
-		"Resources updated at",
+		"Updating resource bbc-world...",
+		"Resource bbc-world updated at",
 	}
 
 	for _, expectedMsg := range expectedMessages {
@@ -61,7 +68,8 @@ func TestUpdateScheduler_ErrorLog(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockManager := mocks.NewMockManager(ctrl)
-	mockManager.EXPECT().UpdateAllSources().Return(errors.New("update failed")).AnyTimes()
+	mockManager.EXPECT().SourceSchedules().Return(map[resource.Source]string{"bbc-world": ""}).AnyTimes()
+	mockManager.EXPECT().UpdateSources(gomock.Any()).Return(errors.New("update failed")).AnyTimes()
 
 	timeout := time.Millisecond * 100
 	scheduler := NewUpdateScheduler(mockManager, timeout)
@@ -77,13 +85,267 @@ func TestUpdateScheduler_ErrorLog(t *testing.T) {
 
 	<-done
 
-	expectedErrorMessage := "Failed to update resources: update failed"
+	expectedErrorMessage := "Failed to update resource bbc-world: update failed"
 
 	if !containsLogMessage(&buf, expectedErrorMessage) {
 		t.Errorf("Expected log message '%s' was not found", expectedErrorMessage)
 	}
 }
 
+// TestUpdateScheduler_Prune tests that the snapshots are pruned after the updates with a retention policy.
+func TestUpdateScheduler_Prune(t *testing.T) {
+	var buf bytes.Buffer
+	originalLogOutput := log.Writer()
+	log.SetOutput(&buf)
+	defer log.SetOutput(originalLogOutput)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policy := storage.RetentionPolicy{MaxSnapshots: 2}
+	pruned := []storage.Snapshot{{Source: "bbc-world", Name: "bbc-world_20240519103000.xml", Size: 10}}
+
+	mockManager := mocks.NewMockManager(ctrl)
+	mockManager.EXPECT().SourceSchedules().Return(map[resource.Source]string{"bbc-world": ""}).AnyTimes()
+	mockManager.EXPECT().UpdateSources(gomock.Any()).Return(nil).AnyTimes()
+	mockManager.EXPECT().PruneSnapshots(policy).Return(pruned, nil).MinTimes(1)
+
+	timeout := time.Millisecond * 100
+	scheduler := NewUpdateScheduler(mockManager, timeout)
+	scheduler.SetRetentionPolicy(policy)
+
+	scheduler.Start()
+	time.Sleep(timeout * 3)
+	scheduler.Stop()
+
+	expectedMessage := "Pruned 1 snapshots of 10 bytes"
+
+	if !containsLogMessage(&buf, expectedMessage) {
+		t.Errorf("Expected log message '%s' was not found", expectedMessage)
+	}
+}
+
+// TestUpdateScheduler_PerSourceSchedules tests that every source is updated when it is due.
+func TestUpdateScheduler_PerSourceSchedules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var mu sync.Mutex
+	updates := make(map[resource.Source]int)
+
+	mockManager := mocks.NewMockManager(ctrl)
+	mockManager.EXPECT().SourceSchedules().Return(map[resource.Source]string{
+		"breaking": "50ms",
+		"daily":    "@daily",
+		"default":  "",
+	}).AnyTimes()
+	mockManager.EXPECT().UpdateSources(gomock.Any()).DoAndReturn(func(sources []resource.Source) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, source := range sources {
+			updates[source]++
+		}
+		return nil
+	}).AnyTimes()
+
+	scheduler := NewUpdateScheduler(mockManager, 200*time.Millisecond)
+	scheduler.SetMaxJitter(0)
+	start := time.Now()
+
+	scheduler.Start()
+	time.Sleep(500 * time.Millisecond)
+	scheduler.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if updates["breaking"] < 5 {
+		t.Errorf("Expected the breaking source to be updated at least 5 times, got %d", updates["breaking"])
+	}
+	if updates["default"] < 1 || updates["default"] > 2 {
+		t.Errorf("Expected the default source to be updated 1 or 2 times, got %d", updates["default"])
+	}
+	if updates["daily"] != 0 {
+		t.Errorf("Expected the daily source not to be updated, got %d", updates["daily"])
+	}
+
+	runs := scheduler.Runs()
+	if len(runs) != 3 || runs[0].Source != "breaking" || runs[1].Source != "daily" || runs[2].Source != "default" {
+		t.Fatalf("Unexpected runs: %+v", runs)
+	}
+	if runs[0].Schedule != "50ms" || runs[0].LastRun.Before(start) || !runs[0].NextRun.After(runs[0].LastRun) {
+		t.Errorf("Unexpected run of the breaking source: %+v", runs[0])
+	}
+	if !runs[1].LastRun.IsZero() || runs[1].NextRun.Before(start) {
+		t.Errorf("Unexpected run of the daily source: %+v", runs[1])
+	}
+}
+
+// TestUpdateScheduler_Sync tests that the scheduler picks up the registered, deleted and rescheduled sources.
+func TestUpdateScheduler_Sync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2024, 5, 19, 10, 0, 0, 0, time.UTC)
+	schedules := map[resource.Source]string{"abc-news": "", "bbc-world": "1h"}
+
+	mockManager := mocks.NewMockManager(ctrl)
+	mockManager.EXPECT().SourceSchedules().DoAndReturn(func() map[resource.Source]string {
+		return schedules
+	}).AnyTimes()
+	mockManager.EXPECT().UpdateSources([]resource.Source{"abc-news"}).Return(&manager.UpdateError{
+		Results: []manager.SourceResult{{Source: "abc-news", Err: fmt.Errorf("status code 500")}},
+	})
+
+	scheduler := NewUpdateScheduler(mockManager, 12*time.Hour)
+	scheduler.SetMaxJitter(0)
+	scheduler.now = func() time.Time { return now }
+
+	if due := scheduler.due(); len(due) != 0 {
+		t.Fatalf("Expected no due sources, got %d", len(due))
+	}
+
+	schedules = map[resource.Source]string{"abc-news": "*/30 * * * *", "cnn": ""}
+	now = now.Add(20 * time.Minute)
+
+	if due := scheduler.due(); len(due) != 0 {
+		t.Fatalf("Expected no due sources, got %d", len(due))
+	}
+	if runs := scheduler.Runs(); len(runs) != 2 || !runs[0].NextRun.Equal(now.Add(10*time.Minute)) {
+		t.Fatalf("Expected the rescheduled source at 10:30 and the deleted one dropped, got %+v", runs)
+	}
+
+	now = now.Add(10 * time.Minute)
+	due := scheduler.due()
+	if len(due) != 1 || due[0].source != "abc-news" {
+		t.Fatalf("Expected the rescheduled source to be due, got %+v", due)
+	}
+	scheduler.update(due[0])
+
+	expected := []schedule.Run{
+		{Source: "abc-news", Schedule: "*/30 * * * *", LastRun: now, LastError: "status code 500",
+			NextRun: now.Add(30 * time.Minute)},
+		{Source: "cnn", NextRun: now.Add(12*time.Hour - 10*time.Minute)},
+	}
+	if runs := scheduler.Runs(); fmt.Sprint(runs) != fmt.Sprint(expected) {
+		t.Errorf("Expected runs %+v, got %+v", expected, runs)
+	}
+}
+
+// TestUpdateScheduler_Stop tests that the scheduler stops without waiting for the due sources waiting for a worker.
+func TestUpdateScheduler_Stop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	started := make(chan resource.Source, 3)
+	release := make(chan struct{})
+
+	mockManager := mocks.NewMockManager(ctrl)
+	mockManager.EXPECT().SourceSchedules().Return(map[resource.Source]string{
+		"abc-news":  "",
+		"bbc-world": "",
+		"cnn":       "",
+	}).AnyTimes()
+	mockManager.EXPECT().UpdateSources(gomock.Any()).DoAndReturn(func(sources []resource.Source) error {
+		if len(sources) != 1 {
+			t.Errorf("Expected the sources to be updated one by one, got %v", sources)
+		}
+		started <- sources[0]
+		<-release
+		return nil
+	}).Times(1)
+
+	scheduler := NewUpdateScheduler(mockManager, 10*time.Millisecond)
+	scheduler.SetMaxJitter(0)
+	scheduler.SetConcurrency(1)
+
+	scheduler.Start()
+	<-started
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		scheduler.Stop()
+	}()
+
+	select {
+	case <-stopped:
+		t.Fatal("Expected Stop to wait for the running update")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Expected Stop to return once the running update finished")
+	}
+
+	scheduler.Stop()
+	scheduler.Start()
+}
+
+// TestUpdateScheduler_StopNotStarted tests that a scheduler that is never started can be stopped.
+func TestUpdateScheduler_StopNotStarted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	scheduler := NewUpdateScheduler(mocks.NewMockManager(ctrl), time.Hour)
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		scheduler.Stop()
+		scheduler.Stop()
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Expected Stop to return for a scheduler that is never started")
+	}
+}
+
+// TestUpdateScheduler_Concurrency tests that the due sources are updated by the workers at the same time.
+func TestUpdateScheduler_Concurrency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+
+	mockManager := mocks.NewMockManager(ctrl)
+	mockManager.EXPECT().SourceSchedules().Return(map[resource.Source]string{
+		"abc-news": "", "bbc-world": "", "cnn": "", "nbc-news": "",
+	}).AnyTimes()
+	mockManager.EXPECT().UpdateSources(gomock.Any()).DoAndReturn(func(sources []resource.Source) error {
+		mu.Lock()
+		running++
+		maxRunning = max(maxRunning, running)
+		mu.Unlock()
+
+		time.Sleep(50 * time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return nil
+	}).AnyTimes()
+
+	scheduler := NewUpdateScheduler(mockManager, 10*time.Millisecond)
+	scheduler.SetMaxJitter(0)
+	scheduler.SetConcurrency(2)
+
+	scheduler.Start()
+	time.Sleep(300 * time.Millisecond)
+	scheduler.Stop()
+
+	mu.Lock()
+	defer mu.Unlock()
+	if maxRunning != 2 {
+		t.Errorf("Expected 2 sources to be updated at the same time, got %d", maxRunning)
+	}
+}
+
 // containsLogMessage checks if the expected message is contained in the log buffer.
 func containsLogMessage(buf *bytes.Buffer, expectedMsg string) bool {
 	return bytes.Contains(buf.Bytes(), []byte(expectedMsg))
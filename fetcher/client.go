@@ -0,0 +1,158 @@
+package fetcher
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Default settings of a Client.
+const (
+	// DefaultTimeout is the default limit of a single request, including the reading of the response body.
+	DefaultTimeout = 30 * time.Second
+	// DefaultMaxRetries is the default number of the retries of a failed request.
+	DefaultMaxRetries = 3
+	// DefaultBaseDelay is the default delay before the first retry, doubled before every next one.
+	DefaultBaseDelay = 500 * time.Millisecond
+	// DefaultMaxDelay is the default limit of the delay before a retry, including the one set by Retry-After.
+	DefaultMaxDelay = 30 * time.Second
+	// DefaultUserAgent is the default User-Agent header of the requests.
+	DefaultUserAgent = "news-aggregator/1.0"
+)
+
+// Client is an HTTP client fetching the sources with timeouts and retries.
+// It is safe for concurrent use once configured.
+//
+// The updater module cannot import the fetcher package of the news-aggregator module, so updater/fetcher/client.go
+// is a copy of fetcher/client.go made by go generate in updater/fetcher. Change fetcher/client.go only,
+// the tests of the fetcher package fail once the copy differs.
+type Client struct {
+	client     *http.Client
+	userAgent  string
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+}
+
+// New creates a new Client with the default settings.
+func New() *Client {
+	return &Client{
+		client:     &http.Client{Timeout: DefaultTimeout},
+		userAgent:  DefaultUserAgent,
+		maxRetries: DefaultMaxRetries,
+		baseDelay:  DefaultBaseDelay,
+		maxDelay:   DefaultMaxDelay,
+	}
+}
+
+// SetTimeout sets the limit of a single request. Values lower than one are ignored.
+func (c *Client) SetTimeout(timeout time.Duration) {
+	if timeout < 1 {
+		return
+	}
+	c.client.Timeout = timeout
+}
+
+// SetUserAgent sets the User-Agent header of the requests. An empty value is ignored.
+func (c *Client) SetUserAgent(userAgent string) {
+	if userAgent == "" {
+		return
+	}
+	c.userAgent = userAgent
+}
+
+// SetMaxRetries sets the number of the retries of a failed request, zero disables the retries.
+// Values lower than zero are ignored.
+func (c *Client) SetMaxRetries(maxRetries int) {
+	if maxRetries < 0 {
+		return
+	}
+	c.maxRetries = maxRetries
+}
+
+// SetBaseDelay sets the delay before the first retry. Values lower than one are ignored.
+func (c *Client) SetBaseDelay(delay time.Duration) {
+	if delay < 1 {
+		return
+	}
+	c.baseDelay = delay
+}
+
+// SetMaxDelay sets the limit of the delay before a retry. Values lower than one are ignored.
+func (c *Client) SetMaxDelay(delay time.Duration) {
+	if delay < 1 {
+		return
+	}
+	c.maxDelay = delay
+}
+
+// Do sends the request, retrying the network errors and the 429 and 5xx responses.
+// The response of the last attempt is returned, the caller checks its status code and closes its body.
+// The request must not have a body, so that it can be sent again.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	req.Header.Set("User-Agent", c.userAgent)
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.client.Do(req)
+
+		if attempt == c.maxRetries || !retryable(resp, err) {
+			if err != nil {
+				return nil, fmt.Errorf("request failed after %d attempts: %v", attempt+1, err)
+			}
+			return resp, nil
+		}
+
+		delay := c.backoff(attempt)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+				delay = min(retryAfter, c.maxDelay)
+			}
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// backoff returns the jittered delay before the retry following the attempt,
+// a random duration between the half and the whole of the exponential delay.
+func (c *Client) backoff(attempt int) time.Duration {
+	delay := c.maxDelay
+	if attempt < 32 {
+		delay = min(c.baseDelay<<attempt, c.maxDelay)
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// retryable reports whether the failed request is worth sending again.
+func retryable(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// parseRetryAfter returns the delay set by the Retry-After header, either in seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(date.Sub(now), 0), true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,165 @@
+package fetcher
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// These tests are mirrored by updater/fetcher/client_test.go, see Client.
+
+func TestClient_UpdaterCopy(t *testing.T) {
+	original, err := os.ReadFile("client.go")
+	assert.NoError(t, err)
+	copied, err := os.ReadFile("../updater/fetcher/client.go")
+	assert.NoError(t, err)
+
+	assert.Equal(t, string(original), string(copied), "run go generate ./fetcher in the updater module")
+}
+
+// newTestClient creates a Client retrying without noticeable delays.
+func newTestClient() *Client {
+	c := New()
+	c.SetBaseDelay(time.Millisecond)
+	c.SetMaxDelay(5 * time.Millisecond)
+	return c
+}
+
+func TestClient_Do(t *testing.T) {
+	tests := []struct {
+		name             string
+		failures         int32
+		failureStatus    int
+		retryAfter       string
+		expectedStatus   int
+		expectedAttempts int32
+	}{
+		{"success", 0, 0, "", http.StatusOK, 1},
+		{"retried server errors", 2, http.StatusServiceUnavailable, "", http.StatusOK, 3},
+		{"retried rate limit with Retry-After", 1, http.StatusTooManyRequests, "1", http.StatusOK, 2},
+		{"too many server errors", 10, http.StatusBadGateway, "", http.StatusBadGateway, 4},
+		{"client error", 10, http.StatusNotFound, "", http.StatusNotFound, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "test-agent/1.0", r.Header.Get("User-Agent"))
+				if atomic.AddInt32(&attempts, 1) <= tt.failures {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(tt.failureStatus)
+					return
+				}
+				_, _ = w.Write([]byte("content"))
+			}))
+			defer server.Close()
+
+			c := newTestClient()
+			c.SetUserAgent("test-agent/1.0")
+
+			req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			assert.NoError(t, err)
+
+			start := time.Now()
+			resp, err := c.Do(req)
+			assert.NoError(t, err)
+			defer resp.Body.Close()
+
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tt.expectedAttempts, atomic.LoadInt32(&attempts))
+			assert.Less(t, time.Since(start), time.Second, "The delays should be limited by the maximum delay")
+		})
+	}
+}
+
+func TestClient_Do_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	c := newTestClient()
+	c.SetTimeout(20 * time.Millisecond)
+	c.SetMaxRetries(1)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	assert.NoError(t, err)
+
+	_, err = c.Do(req)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "after 2 attempts")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+}
+
+func TestClient_Setters(t *testing.T) {
+	c := New()
+
+	c.SetTimeout(0)
+	c.SetUserAgent("")
+	c.SetMaxRetries(-1)
+	c.SetBaseDelay(0)
+	c.SetMaxDelay(-time.Second)
+
+	assert.Equal(t, DefaultTimeout, c.client.Timeout, "Invalid values should be ignored")
+	assert.Equal(t, DefaultUserAgent, c.userAgent)
+	assert.Equal(t, DefaultMaxRetries, c.maxRetries)
+	assert.Equal(t, DefaultBaseDelay, c.baseDelay)
+	assert.Equal(t, DefaultMaxDelay, c.maxDelay)
+
+	c.SetMaxRetries(0)
+	assert.Equal(t, 0, c.maxRetries, "Zero should disable the retries")
+}
+
+func TestBackoff(t *testing.T) {
+	c := New()
+	c.SetBaseDelay(100 * time.Millisecond)
+	c.SetMaxDelay(time.Second)
+
+	for attempt, expected := range []time.Duration{100, 200, 400, 800, 1000, 1000} {
+		expected *= time.Millisecond
+		for i := 0; i < 10; i++ {
+			delay := c.backoff(attempt)
+			assert.GreaterOrEqual(t, delay, expected/2)
+			assert.LessOrEqual(t, delay, expected)
+		}
+	}
+	assert.Equal(t, time.Second, max(c.backoff(100), time.Second), "Large attempts should not overflow")
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 19, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"120", 2 * time.Minute, true},
+		{"Sun, 19 May 2024 10:00:30 GMT", 30 * time.Second, true},
+		{"Sun, 19 May 2024 09:00:00 GMT", 0, true},
+		{"", 0, false},
+		{"-1", 0, false},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		delay, ok := parseRetryAfter(tt.value, now)
+		assert.Equal(t, tt.ok, ok, tt.value)
+		assert.Equal(t, tt.expected, delay, tt.value)
+	}
+}
@@ -0,0 +1,7 @@
+// Package fetcher provides the HTTP client fetching the sources and the tracking of their health.
+//
+// The Client limits every request with a timeout and retries the network errors and the 429 and 5xx responses
+// with a jittered exponential backoff, honoring the Retry-After header. The HealthTracker records the consecutive
+// failures, the last error and the last success of every source, and backs off a persistently failing source,
+// so that the scheduled updates do not fetch it again until its backoff expires.
+package fetcher
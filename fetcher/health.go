@@ -0,0 +1,148 @@
+package fetcher
+
+import (
+	"sync"
+	"time"
+)
+
+// Default settings of a HealthTracker.
+const (
+	// DefaultFailureThreshold is the default number of the consecutive failures after which a source is backed off.
+	DefaultFailureThreshold = 3
+	// DefaultBaseBackoff is the default backoff of a source reaching the threshold, doubled on every next failure.
+	DefaultBaseBackoff = 5 * time.Minute
+	// DefaultMaxBackoff is the default limit of the backoff of a source.
+	DefaultMaxBackoff = 6 * time.Hour
+)
+
+// Health is the health of fetching a source.
+type Health struct {
+	// ConsecutiveFailures is the number of the failures since the last success.
+	ConsecutiveFailures int
+	// LastError is the error of the last failure.
+	LastError string
+	// LastSuccess is the time of the last success.
+	LastSuccess time.Time
+	// LastFailure is the time of the last failure.
+	LastFailure time.Time
+	// BackoffUntil is the time before which the source is not fetched by the scheduled updates.
+	// It is zero unless the source has reached the failure threshold.
+	BackoffUntil time.Time
+}
+
+// Healthy reports whether the last fetch of the source has succeeded.
+func (h Health) Healthy() bool {
+	return h.ConsecutiveFailures == 0
+}
+
+// HealthTracker tracks the health of fetching the sources. It is safe for concurrent use.
+type HealthTracker struct {
+	mu               sync.Mutex
+	sources          map[string]Health
+	failureThreshold int
+	baseBackoff      time.Duration
+	maxBackoff       time.Duration
+	// now returns the time of a fetch.
+	now func() time.Time
+}
+
+// NewHealthTracker creates a new HealthTracker with the default settings.
+func NewHealthTracker() *HealthTracker {
+	return &HealthTracker{
+		sources:          make(map[string]Health),
+		failureThreshold: DefaultFailureThreshold,
+		baseBackoff:      DefaultBaseBackoff,
+		maxBackoff:       DefaultMaxBackoff,
+		now:              time.Now,
+	}
+}
+
+// SetFailureThreshold sets the number of the consecutive failures after which a source is backed off.
+// Values lower than one are ignored.
+func (t *HealthTracker) SetFailureThreshold(threshold int) {
+	if threshold < 1 {
+		return
+	}
+	t.failureThreshold = threshold
+}
+
+// SetBackoff sets the backoff of a source reaching the failure threshold and its limit.
+// Values lower than one are ignored.
+func (t *HealthTracker) SetBackoff(base, maxBackoff time.Duration) {
+	if base > 0 {
+		t.baseBackoff = base
+	}
+	if maxBackoff > 0 {
+		t.maxBackoff = maxBackoff
+	}
+}
+
+// RecordSuccess records a successful fetch of the source, ending its backoff.
+func (t *HealthTracker) RecordSuccess(source string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	health := t.sources[source]
+	health.ConsecutiveFailures = 0
+	health.LastSuccess = t.now()
+	health.BackoffUntil = time.Time{}
+	t.sources[source] = health
+}
+
+// RecordFailure records a failed fetch of the source, backing it off once it reaches the failure threshold.
+func (t *HealthTracker) RecordFailure(source string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	health := t.sources[source]
+	health.ConsecutiveFailures++
+	health.LastError = err.Error()
+	health.LastFailure = t.now()
+
+	if exceeded := health.ConsecutiveFailures - t.failureThreshold; exceeded >= 0 {
+		backoff := t.maxBackoff
+		if exceeded < 32 {
+			backoff = min(t.baseBackoff<<exceeded, t.maxBackoff)
+		}
+		health.BackoffUntil = health.LastFailure.Add(backoff)
+	}
+
+	t.sources[source] = health
+}
+
+// Allow reports whether the source may be fetched, i.e. it is not backed off.
+func (t *HealthTracker) Allow(source string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return !t.now().Before(t.sources[source].BackoffUntil)
+}
+
+// Forget removes the health of the source, e.g. once its link is changed.
+func (t *HealthTracker) Forget(source string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.sources, source)
+}
+
+// Health returns the health of the source and whether the source has been fetched.
+func (t *HealthTracker) Health(source string) (Health, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	health, exists := t.sources[source]
+	return health, exists
+}
+
+// All returns the health of all fetched sources.
+func (t *HealthTracker) All() map[string]Health {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	all := make(map[string]Health, len(t.sources))
+	for source, health := range t.sources {
+		all[source] = health
+	}
+	return all
+}
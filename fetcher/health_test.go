@@ -0,0 +1,54 @@
+package fetcher
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthTracker(t *testing.T) {
+	now := time.Date(2024, 5, 19, 10, 0, 0, 0, time.UTC)
+	tracker := NewHealthTracker()
+	tracker.now = func() time.Time { return now }
+	tracker.SetFailureThreshold(2)
+	tracker.SetBackoff(time.Minute, 3*time.Minute)
+
+	_, exists := tracker.Health("bbc")
+	assert.False(t, exists)
+	assert.True(t, tracker.Allow("bbc"), "An unknown source should be allowed")
+
+	tracker.RecordFailure("bbc", errors.New("status code 500"))
+	health, exists := tracker.Health("bbc")
+	assert.True(t, exists)
+	assert.False(t, health.Healthy())
+	assert.Equal(t, "status code 500", health.LastError)
+	assert.True(t, health.BackoffUntil.IsZero(), "A source below the threshold should not be backed off")
+	assert.True(t, tracker.Allow("bbc"))
+
+	for failures, backoff := range map[int]time.Duration{2: time.Minute, 3: 2 * time.Minute, 4: 3 * time.Minute} {
+		tracker.sources["bbc"] = Health{ConsecutiveFailures: failures - 1}
+		tracker.RecordFailure("bbc", errors.New("timeout"))
+
+		health, _ = tracker.Health("bbc")
+		assert.Equal(t, failures, health.ConsecutiveFailures)
+		assert.Equal(t, now.Add(backoff), health.BackoffUntil, "failures: %d", failures)
+	}
+
+	assert.False(t, tracker.Allow("bbc"))
+	now = now.Add(3 * time.Minute)
+	assert.True(t, tracker.Allow("bbc"), "The source should be allowed once the backoff expires")
+
+	tracker.RecordSuccess("bbc")
+	health, _ = tracker.Health("bbc")
+	assert.True(t, health.Healthy())
+	assert.Equal(t, now, health.LastSuccess)
+	assert.Equal(t, "timeout", health.LastError, "The last error should be kept")
+	assert.True(t, health.BackoffUntil.IsZero())
+
+	tracker.RecordSuccess("abc")
+	assert.Equal(t, 2, len(tracker.All()))
+	tracker.Forget("abc")
+	assert.Equal(t, 1, len(tracker.All()))
+}
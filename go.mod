@@ -9,6 +9,7 @@ require (
 	github.com/golang/mock v1.6.0
 	github.com/reiver/go-porterstemmer v1.0.1
 	github.com/stretchr/testify v1.9.0
+	go.etcd.io/bbolt v1.3.10
 )
 
 require (
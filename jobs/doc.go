@@ -0,0 +1,6 @@
+// Package jobs provides the asynchronous jobs refreshing the sources on demand.
+//
+// A Queue runs the enqueued jobs one after another in the background, refreshing up to its concurrency limit
+// of the sources of a job at the same time, and keeps the finished jobs, so that their status, duration,
+// fetched bytes, parsed articles and errors can be reported later by their ID.
+package jobs
@@ -0,0 +1,83 @@
+package jobs
+
+import "time"
+
+// Status is the status of a Job.
+type Status string
+
+const (
+	// Queued is the status of a job waiting for its turn.
+	Queued Status = "queued"
+	// Running is the status of a job refreshing its sources.
+	Running Status = "running"
+	// Succeeded is the status of a job whose sources are all refreshed.
+	Succeeded Status = "succeeded"
+	// Failed is the status of a job with at least one source failed to refresh.
+	Failed Status = "failed"
+)
+
+// SourceResult is the result of refreshing a source of a Job.
+type SourceResult struct {
+	Source string
+	// BytesFetched is the size of the fetched content, zero if the source is not modified.
+	BytesFetched int64
+	// ArticlesParsed is the number of the articles parsed from a new content.
+	ArticlesParsed int
+	// Changed reports whether a new content is stored.
+	Changed bool
+	// Error is the error of the refresh, empty if it has succeeded.
+	Error string
+}
+
+// Job is a request to refresh the sources. It is a copy of the state kept by the Queue.
+type Job struct {
+	ID         string
+	Sources    []string
+	Status     Status
+	CreatedAt  time.Time
+	StartedAt  time.Time
+	FinishedAt time.Time
+	// Results are the results of the refreshed sources in the order of Sources, set once the job is finished.
+	Results []SourceResult
+}
+
+// Duration returns the time the job has been running for, zero if it has not started.
+func (j Job) Duration() time.Duration {
+	switch {
+	case j.StartedAt.IsZero():
+		return 0
+	case j.FinishedAt.IsZero():
+		return time.Since(j.StartedAt)
+	default:
+		return j.FinishedAt.Sub(j.StartedAt)
+	}
+}
+
+// BytesFetched returns the total size of the contents fetched by the job.
+func (j Job) BytesFetched() int64 {
+	var total int64
+	for _, result := range j.Results {
+		total += result.BytesFetched
+	}
+	return total
+}
+
+// ArticlesParsed returns the total number of the articles parsed by the job.
+func (j Job) ArticlesParsed() int {
+	total := 0
+	for _, result := range j.Results {
+		total += result.ArticlesParsed
+	}
+	return total
+}
+
+// Errors returns the errors of the sources failed to refresh, prefixed by the names of the sources.
+func (j Job) Errors() []string {
+	var errs []string
+	for _, result := range j.Results {
+		if result.Error != "" {
+			errs = append(errs, result.Source+": "+result.Error)
+		}
+	}
+	return errs
+}
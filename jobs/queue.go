@@ -0,0 +1,217 @@
+package jobs
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/manager"
+	"sync"
+	"time"
+)
+
+// Default settings of a Queue.
+const (
+	// DefaultQueueSize is the number of the jobs waiting for their turn, above which new jobs are rejected.
+	DefaultQueueSize = 100
+	// DefaultConcurrency is the default number of the sources of a job refreshed at the same time.
+	DefaultConcurrency = 4
+	// DefaultMaxJobs is the default number of the finished jobs kept for reporting, the oldest are dropped first.
+	DefaultMaxJobs = 1000
+)
+
+// ErrQueueFull is returned by Queue.Enqueue when too many jobs are waiting for their turn.
+var ErrQueueFull = errors.New("job queue is full")
+
+// Refresher refreshes the sources, see manager.ResourceManager.
+type Refresher interface {
+	// RegisteredSources returns the registered sources.
+	RegisteredSources() []resource.Source
+	// RefreshResource updates the source and reports the fetched bytes and the parsed articles.
+	RefreshResource(source resource.Source) (manager.RefreshResult, error)
+}
+
+// Queue runs the jobs refreshing the sources in the background. It is safe for concurrent use.
+type Queue struct {
+	refresher   Refresher
+	concurrency int
+	maxJobs     int
+	pending     chan string   // IDs of the jobs waiting for their turn
+	stop        chan struct{} // Channel to signal stopping the queue
+	done        chan struct{} // Channel closed once the queue is stopped
+	// now returns the time of the job events.
+	now func() time.Time
+
+	mu       sync.Mutex
+	jobs     map[string]*Job
+	finished []string // IDs of the finished jobs, the oldest first
+}
+
+// NewQueue creates a new Queue of the jobs refreshing the sources with the refresher.
+func NewQueue(refresher Refresher) *Queue {
+	return &Queue{
+		refresher:   refresher,
+		concurrency: DefaultConcurrency,
+		maxJobs:     DefaultMaxJobs,
+		pending:     make(chan string, DefaultQueueSize),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+		now:         time.Now,
+		jobs:        make(map[string]*Job),
+	}
+}
+
+// SetConcurrency sets the number of the sources of a job refreshed at the same time.
+// Values lower than one are ignored.
+func (q *Queue) SetConcurrency(n int) {
+	if n < 1 {
+		return
+	}
+	q.concurrency = n
+}
+
+// SetMaxJobs sets the number of the finished jobs kept for reporting. Values lower than one are ignored.
+func (q *Queue) SetMaxJobs(n int) {
+	if n < 1 {
+		return
+	}
+	q.maxJobs = n
+}
+
+// Start starts running the enqueued jobs in a separate goroutine.
+func (q *Queue) Start() {
+	go func() {
+		defer close(q.done)
+
+		for {
+			select {
+			case id := <-q.pending:
+				q.run(id)
+			case <-q.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the started queue and waits for the running job to finish. The waiting jobs are left queued.
+func (q *Queue) Stop() {
+	close(q.stop)
+	<-q.done
+}
+
+// Enqueue adds a job refreshing the sources, or all registered sources if none are given, and returns it.
+// ErrQueueFull is returned if too many jobs are waiting for their turn.
+func (q *Queue) Enqueue(sources []resource.Source) (Job, error) {
+	if len(sources) == 0 {
+		sources = q.refresher.RegisteredSources()
+	}
+	if len(sources) == 0 {
+		return Job{}, fmt.Errorf("no sources available")
+	}
+
+	id, err := newID()
+	if err != nil {
+		return Job{}, err
+	}
+
+	job := &Job{
+		ID:        id,
+		Sources:   make([]string, len(sources)),
+		Status:    Queued,
+		CreatedAt: q.now(),
+	}
+	for i, source := range sources {
+		job.Sources[i] = string(source)
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	select {
+	case q.pending <- id:
+	default:
+		return Job{}, ErrQueueFull
+	}
+
+	q.jobs[id] = job
+	return *job, nil
+}
+
+// Job returns the job with the ID and whether it is known. The oldest finished jobs are forgotten
+// once there are more of them than the limit.
+func (q *Queue) Job(id string) (Job, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job, exists := q.jobs[id]
+	if !exists {
+		return Job{}, false
+	}
+	return *job, true
+}
+
+// run refreshes the sources of the job with the ID, up to the concurrency limit of them at the same time.
+func (q *Queue) run(id string) {
+	q.mu.Lock()
+	job := q.jobs[id]
+	job.Status = Running
+	job.StartedAt = q.now()
+	sources := job.Sources
+	q.mu.Unlock()
+
+	results := make([]SourceResult, len(sources))
+	limit := make(chan struct{}, q.concurrency)
+	var wg sync.WaitGroup
+
+	for i, source := range sources {
+		wg.Add(1)
+		limit <- struct{}{}
+
+		go func(i int, source string) {
+			defer wg.Done()
+			defer func() { <-limit }()
+
+			refreshed, err := q.refresher.RefreshResource(resource.Source(source))
+			results[i] = SourceResult{
+				Source:         source,
+				BytesFetched:   refreshed.BytesFetched,
+				ArticlesParsed: refreshed.ArticlesParsed,
+				Changed:        refreshed.Changed,
+			}
+			if err != nil {
+				results[i].Error = err.Error()
+			}
+		}(i, source)
+	}
+
+	wg.Wait()
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job.Results = results
+	job.FinishedAt = q.now()
+	job.Status = Succeeded
+	for _, result := range results {
+		if result.Error != "" {
+			job.Status = Failed
+		}
+	}
+
+	q.finished = append(q.finished, id)
+	for len(q.finished) > q.maxJobs {
+		delete(q.jobs, q.finished[0])
+		q.finished = q.finished[1:]
+	}
+}
+
+// newID returns a random ID of a job.
+func newID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating job ID: %v", err)
+	}
+	return hex.EncodeToString(b), nil
+}
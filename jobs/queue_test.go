@@ -0,0 +1,145 @@
+package jobs
+
+import (
+	"errors"
+	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/manager"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRefresher refreshes the sources with the results set per source, blocking until released if set.
+type fakeRefresher struct {
+	mu      sync.Mutex
+	release chan struct{}
+	results map[resource.Source]manager.RefreshResult
+	errs    map[resource.Source]error
+	calls   []resource.Source
+}
+
+func (f *fakeRefresher) RegisteredSources() []resource.Source {
+	return []resource.Source{"abc-news", "bbc-world"}
+}
+
+func (f *fakeRefresher) RefreshResource(source resource.Source) (manager.RefreshResult, error) {
+	if f.release != nil {
+		<-f.release
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, source)
+	return f.results[source], f.errs[source]
+}
+
+// waitFinished waits for the job with the ID to finish.
+func waitFinished(t *testing.T, q *Queue, id string) Job {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		job, exists := q.Job(id)
+		if exists && (job.Status == Succeeded || job.Status == Failed) {
+			return job
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatalf("job %s has not finished", id)
+	return Job{}
+}
+
+func TestQueue(t *testing.T) {
+	refresher := &fakeRefresher{
+		results: map[resource.Source]manager.RefreshResult{
+			"abc-news":  {BytesFetched: 100, Changed: true, ArticlesParsed: 5},
+			"bbc-world": {BytesFetched: 50, Changed: true, ArticlesParsed: 2},
+		},
+		errs: map[resource.Source]error{"cnn": errors.New("status code 502")},
+	}
+
+	q := NewQueue(refresher)
+	q.Start()
+	defer q.Stop()
+
+	job, err := q.Enqueue(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, Queued, job.Status)
+	assert.Equal(t, []string{"abc-news", "bbc-world"}, job.Sources, "All sources should be refreshed by default")
+	assert.Len(t, job.ID, 16)
+
+	job = waitFinished(t, q, job.ID)
+	assert.Equal(t, Succeeded, job.Status)
+	assert.Equal(t, int64(150), job.BytesFetched())
+	assert.Equal(t, 7, job.ArticlesParsed())
+	assert.Empty(t, job.Errors())
+	assert.False(t, job.StartedAt.Before(job.CreatedAt))
+	assert.Equal(t, job.FinishedAt.Sub(job.StartedAt), job.Duration())
+
+	job, err = q.Enqueue([]resource.Source{"abc-news", "cnn"})
+	assert.NoError(t, err)
+
+	job = waitFinished(t, q, job.ID)
+	assert.Equal(t, Failed, job.Status)
+	assert.Equal(t, []string{"cnn: status code 502"}, job.Errors())
+	assert.Equal(t, "abc-news", job.Results[0].Source)
+	assert.Equal(t, "cnn", job.Results[1].Source)
+
+	_, exists := q.Job("unknown")
+	assert.False(t, exists)
+}
+
+func TestQueue_Full(t *testing.T) {
+	refresher := &fakeRefresher{release: make(chan struct{})}
+
+	q := NewQueue(refresher)
+	q.pending = make(chan string, 1)
+	q.Start()
+
+	running, err := q.Enqueue([]resource.Source{"abc-news"})
+	assert.NoError(t, err)
+
+	// Wait for the first job to be taken off the queue.
+	deadline := time.Now().Add(time.Second)
+	for job, _ := q.Job(running.ID); job.Status != Running && time.Now().Before(deadline); job, _ = q.Job(running.ID) {
+		time.Sleep(time.Millisecond)
+	}
+
+	waiting, err := q.Enqueue([]resource.Source{"bbc-world"})
+	assert.NoError(t, err)
+
+	_, err = q.Enqueue([]resource.Source{"cnn"})
+	assert.ErrorIs(t, err, ErrQueueFull)
+
+	job, _ := q.Job(waiting.ID)
+	assert.Equal(t, Queued, job.Status)
+	assert.Equal(t, time.Duration(0), job.Duration())
+
+	close(refresher.release)
+	waitFinished(t, q, waiting.ID)
+	q.Stop()
+}
+
+func TestQueue_MaxJobs(t *testing.T) {
+	q := NewQueue(&fakeRefresher{})
+	q.SetMaxJobs(2)
+	q.SetMaxJobs(0)
+	q.Start()
+	defer q.Stop()
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		job, err := q.Enqueue(nil)
+		assert.NoError(t, err)
+		waitFinished(t, q, job.ID)
+		ids = append(ids, job.ID)
+	}
+
+	_, exists := q.Job(ids[0])
+	assert.False(t, exists, "The oldest finished job should be forgotten")
+	_, exists = q.Job(ids[2])
+	assert.True(t, exists)
+}
@@ -0,0 +1,13 @@
+package manager
+
+import "news-aggregator/aggregator/parser"
+
+// feedJSON is a struct that represents how feed is stored in the feeds dictionary file.
+// The parser config fields, if any, are stored next to the source ones.
+type feedJSON struct {
+	Source   string `json:"source"`
+	Format   string `json:"format"`
+	Link     string `json:"link"`
+	Schedule string `json:"schedule,omitempty"`
+	parser.Config
+}
@@ -0,0 +1,270 @@
+package manager
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"mime"
+	"net/url"
+	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/aggregator/parser"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// MaxAdvertisedFeeds is the largest number of the feeds advertised by a page that are probed by DiscoverFeeds.
+const MaxAdvertisedFeeds = 10
+
+// discoverySource is the name the candidate feeds are parsed under, as they are not registered.
+const discoverySource resource.Source = "discovery"
+
+// commonFeedPaths are the paths feeds are often served at, probed by DiscoverFeeds in addition
+// to the advertised feeds.
+var commonFeedPaths = []string{
+	"/feed",
+	"/rss",
+	"/feed.xml",
+	"/rss.xml",
+	"/atom.xml",
+	"/index.xml",
+	"/feed.json",
+}
+
+// feedMediaTypes are the formats of the feeds by the media types they are advertised with.
+// Any JSON document is served as application/json, so the format of the links advertised with it
+// is sniffed from their content, which is a JSON Feed only if it names the version of the specification.
+var feedMediaTypes = map[string]resource.Format{
+	"application/rss+xml":   resource.RSS,
+	"application/rdf+xml":   resource.RSS,
+	"application/atom+xml":  resource.ATOM,
+	"application/feed+json": resource.JSONFEED,
+	"application/json":      resource.UNKNOWN,
+}
+
+// FeedCandidate is a feed found by DiscoverFeeds.
+type FeedCandidate struct {
+	// URL is the link of the feed.
+	URL string
+	// Format is the format the feed is parsed in.
+	Format resource.Format
+	// Title is the title of the feed, or the title it is advertised with if the feed has none.
+	Title string
+	// ArticlesParsed is the number of the articles parsed from the feed.
+	ArticlesParsed int
+	// Advertised tells whether the feed is advertised by the page, or found at a common path or the page itself.
+	Advertised bool
+}
+
+// String returns the format, the title and the link of the candidate.
+func (c FeedCandidate) String() string {
+	title := c.Title
+	if title == "" {
+		title = "untitled"
+	}
+	return fmt.Sprintf("%s \"%s\" %s (%d articles)", resource.FormatToString(c.Format), title, c.URL,
+		c.ArticlesParsed)
+}
+
+// feedLink is a link to a possible feed.
+type feedLink struct {
+	url        string
+	format     resource.Format
+	title      string
+	advertised bool
+}
+
+// DiscoverFeeds fetches the page of a website and finds the feeds it advertises with
+// <link rel="alternate"> tags and the ones served at the common feed paths of the website.
+// Every candidate is probed with the parsers and only the ones yielding articles are returned,
+// the advertised feeds first and then the ones with more articles.
+// If the page is a feed itself, it is the only candidate and it is probed without fetching it again.
+// An error is returned if the link is invalid or the page cannot be fetched.
+func (rm *ResourceManager) DiscoverFeeds(pageURL string) ([]FeedCandidate, error) {
+	if err := ValidateLink(pageURL); err != nil {
+		return nil, err
+	}
+
+	report := ProbeReport{URL: pageURL}
+	page, err := rm.fetchContent(pageURL, &report)
+	if err != nil {
+		return nil, err
+	}
+
+	if isFeedFormat(sniffFormat(report.ContentType, page)) {
+		err = probeContent(discoverySource, parser.Config{}, &report, page)
+		return sortCandidates([]*FeedCandidate{feedCandidate(feedLink{url: pageURL}, report, page, err)}), nil
+	}
+
+	links := feedLinks(pageURL, page)
+	candidates := make([]*FeedCandidate, len(links))
+	limit := make(chan struct{}, rm.concurrency)
+	var wg sync.WaitGroup
+
+	for i, link := range links {
+		wg.Add(1)
+		limit <- struct{}{}
+
+		go func(i int, link feedLink) {
+			defer wg.Done()
+			defer func() { <-limit }()
+
+			candidates[i] = rm.probeFeed(link)
+		}(i, link)
+	}
+
+	wg.Wait()
+
+	return sortCandidates(candidates), nil
+}
+
+// sortCandidates returns the probed candidates, the advertised feeds first and then the ones with more articles.
+// The nil candidates of the links yielding no articles are dropped.
+func sortCandidates(candidates []*FeedCandidate) []FeedCandidate {
+	feeds := make([]FeedCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate != nil {
+			feeds = append(feeds, *candidate)
+		}
+	}
+
+	sort.SliceStable(feeds, func(i, j int) bool {
+		if feeds[i].Advertised != feeds[j].Advertised {
+			return feeds[i].Advertised
+		}
+		return feeds[i].ArticlesParsed > feeds[j].ArticlesParsed
+	})
+
+	return feeds
+}
+
+// probeFeed probes the link, returning nil if no articles are parsed from it.
+func (rm *ResourceManager) probeFeed(link feedLink) *FeedCandidate {
+	report, content, err := rm.probe(discoverySource, link.url, link.format, parser.Config{})
+	return feedCandidate(link, report, content, err)
+}
+
+// feedCandidate returns the candidate of the probed link, or nil if probing it failed with the error.
+func feedCandidate(link feedLink, report ProbeReport, content []byte, err error) *FeedCandidate {
+	if err != nil {
+		return nil
+	}
+
+	title := feedTitle(report.Format, content)
+	if title == "" {
+		title = link.title
+	}
+
+	return &FeedCandidate{
+		URL:            link.url,
+		Format:         report.Format,
+		Title:          title,
+		ArticlesParsed: report.ArticlesParsed,
+		Advertised:     link.advertised,
+	}
+}
+
+// feedLinks returns the links to the feeds advertised by the HTML page, up to MaxAdvertisedFeeds of them,
+// followed by the links of the common feed paths of its website.
+// The relative links are resolved against the base of the page.
+func feedLinks(pageURL string, page []byte) []feedLink {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil
+	}
+
+	var links []feedLink
+	seen := make(map[string]bool)
+	add := func(link feedLink) {
+		if !seen[link.url] {
+			seen[link.url] = true
+			links = append(links, link)
+		}
+	}
+
+	doc, err := goquery.NewDocumentFromReader(bytes.NewReader(page))
+	if err == nil {
+		if href, exists := doc.Find("base[href]").First().Attr("href"); exists {
+			if u, err := base.Parse(strings.TrimSpace(href)); err == nil {
+				base = u
+			}
+		}
+
+		doc.Find("link[rel][type][href]").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+			if !hasRel(s.AttrOr("rel", ""), "alternate") {
+				return true
+			}
+
+			mediaType, _, _ := mime.ParseMediaType(s.AttrOr("type", ""))
+			format, ok := feedMediaTypes[strings.ToLower(mediaType)]
+			if !ok {
+				return true
+			}
+
+			u, err := base.Parse(strings.TrimSpace(s.AttrOr("href", "")))
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+				return true
+			}
+			u.Fragment = ""
+
+			add(feedLink{
+				url:        u.String(),
+				format:     format,
+				title:      strings.TrimSpace(s.AttrOr("title", "")),
+				advertised: true,
+			})
+			return len(links) < MaxAdvertisedFeeds
+		})
+	}
+
+	for _, path := range commonFeedPaths {
+		u := url.URL{Scheme: base.Scheme, Host: base.Host, Path: path}
+		add(feedLink{url: u.String()})
+	}
+
+	return links
+}
+
+// hasRel reports whether the space-separated link types of the rel attribute contain the type.
+func hasRel(rel string, linkType string) bool {
+	for _, t := range strings.Fields(rel) {
+		if strings.EqualFold(t, linkType) {
+			return true
+		}
+	}
+	return false
+}
+
+// feedTitle returns the title of the feed in the format, or an empty string if it has none.
+func feedTitle(format resource.Format, content []byte) string {
+	var title string
+
+	switch format {
+	case resource.RSS:
+		var feed struct {
+			Title string `xml:"channel>title"`
+		}
+		if xml.Unmarshal(content, &feed) == nil {
+			title = feed.Title
+		}
+	case resource.ATOM:
+		var feed struct {
+			Title string `xml:"title"`
+		}
+		if xml.Unmarshal(content, &feed) == nil {
+			title = feed.Title
+		}
+	case resource.JSONFEED:
+		var feed struct {
+			Title string `json:"title"`
+		}
+		if json.Unmarshal(content, &feed) == nil {
+			title = feed.Title
+		}
+	}
+
+	return strings.Join(strings.Fields(title), " ")
+}
@@ -0,0 +1,97 @@
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/storage"
+	"os"
+	"time"
+)
+
+// StateSuffix is appended to the path of the feeds dictionary to get the path of the fetch statuses of the sources.
+// The statuses are loaded once on start and the whole file is rewritten on every change, so the file is not shared
+// with the news-updater, which keeps its own next to the dictionary.
+const StateSuffix = ".state"
+
+// FetchStatus is the state of fetching a source kept between the updates.
+// The validators of the last response are sent with the next request, so that an unchanged source
+// is not downloaded again, and the hash of the last stored content prevents storing it again.
+type FetchStatus struct {
+	// ETag is the ETag header of the last response.
+	ETag string `json:"etag,omitempty"`
+	// LastModified is the Last-Modified header of the last response.
+	LastModified string `json:"lastModified,omitempty"`
+	// ContentHash is the SHA-256 hash of the last stored content in hex.
+	ContentHash string `json:"contentHash,omitempty"`
+	// LastChecked is the time of the last successful fetch.
+	LastChecked time.Time `json:"lastChecked"`
+	// LastChanged is the time of the last fetch with a new content.
+	LastChanged time.Time `json:"lastChanged"`
+}
+
+// FetchStatus returns the fetch status of the source and whether the source has been fetched.
+func (rm *ResourceManager) FetchStatus(source resource.Source) (FetchStatus, bool) {
+	rm.statusMu.Lock()
+	defer rm.statusMu.Unlock()
+
+	status, exists := rm.statuses[source]
+	return status, exists
+}
+
+// setFetchStatus records the fetch status of the source and saves the statuses.
+func (rm *ResourceManager) setFetchStatus(source resource.Source, status FetchStatus) error {
+	rm.statusMu.Lock()
+	defer rm.statusMu.Unlock()
+
+	rm.statuses[source] = status
+	return rm.saveFetchStatuses()
+}
+
+// resetFetchStatus forgets the fetch status of the source, e.g. once its link is changed.
+func (rm *ResourceManager) resetFetchStatus(source resource.Source) error {
+	rm.statusMu.Lock()
+	defer rm.statusMu.Unlock()
+
+	if _, exists := rm.statuses[source]; !exists {
+		return nil
+	}
+
+	delete(rm.statuses, source)
+	return rm.saveFetchStatuses()
+}
+
+// saveFetchStatuses writes the fetch statuses next to the feeds dictionary. It is called with the lock held.
+func (rm *ResourceManager) saveFetchStatuses() error {
+	data, err := json.MarshalIndent(rm.statuses, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding fetch statuses: %v", err)
+	}
+
+	err = storage.WriteFileAtomic(rm.feedDictionaryPath+StateSuffix, data, feedsPerm)
+	if err != nil {
+		return fmt.Errorf("error writing fetch statuses: %v", err)
+	}
+
+	return nil
+}
+
+// loadFetchStatuses reads the fetch statuses at the path. Missing or unreadable statuses are dropped,
+// the sources are then fetched unconditionally once.
+func loadFetchStatuses(path string) map[resource.Source]FetchStatus {
+	statuses := make(map[resource.Source]FetchStatus)
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return statuses
+	}
+	if err == nil {
+		err = json.Unmarshal(data, &statuses)
+	}
+	if err != nil {
+		fmt.Printf("error loading fetch statuses, the sources are fetched again: %v\n", err)
+		return make(map[resource.Source]FetchStatus)
+	}
+
+	return statuses
+}
@@ -0,0 +1,59 @@
+package manager
+
+import (
+	"fmt"
+	"news-aggregator/aggregator/model/resource"
+	"strings"
+)
+
+// ImportResult is the result of importing the stored contents of a source, see ResourceManager.Import.
+type ImportResult struct {
+	Source resource.Source
+	// Imported is the number of the imported contents.
+	Imported int
+	// Failed is the number of the contents that failed to be saved.
+	Failed int
+	// Err is the error of reading the source or of the last content that failed to be saved, nil if none failed.
+	Err error
+	// Skipped reports whether the source has no stored contents to import.
+	Skipped bool
+}
+
+// ImportError is returned by ResourceManager.Import when some of the sources fail to import.
+// It keeps the results of all sources, the errors of the failed ones are unwrapped by errors.Is and errors.As.
+type ImportError struct {
+	Results []ImportResult
+}
+
+// Failed returns the results of the sources that failed to import.
+func (e *ImportError) Failed() []ImportResult {
+	var failed []ImportResult
+	for _, result := range e.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Error returns the number of the failed sources followed by their errors.
+func (e *ImportError) Error() string {
+	failed := e.Failed()
+
+	messages := make([]string, 0, len(failed))
+	for _, result := range failed {
+		messages = append(messages, fmt.Sprintf("source \"%s\": %v", result.Source, result.Err))
+	}
+
+	return fmt.Sprintf("%d of %d sources failed to import: %s",
+		len(failed), len(e.Results), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the errors of the failed sources.
+func (e *ImportError) Unwrap() []error {
+	var errs []error
+	for _, result := range e.Failed() {
+		errs = append(errs, result.Err)
+	}
+	return errs
+}
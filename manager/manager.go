@@ -1,33 +1,88 @@
 package manager
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"news-aggregator/aggregator"
+	"news-aggregator/aggregator/cache"
+	"news-aggregator/aggregator/model/article"
 	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/aggregator/parser"
+	"news-aggregator/fetcher"
+	"news-aggregator/schedule"
 	"news-aggregator/storage"
 	"os"
 	"path/filepath"
 	"sort"
+	"sync"
+	"time"
 )
 
 // ResourceDetails is a struct that contains the format and link of a resource.
+// Parser is set for resources whose structure is described in the feeds dictionary.
+// Schedule is the update schedule of the resource, see schedule.Parse, empty for the default one of the scheduler.
 type ResourceDetails struct {
-	Format resource.Format
-	Link   string
+	Format   resource.Format
+	Link     string
+	Parser   parser.Config
+	Schedule string
 }
 
+// keptParser returns the parser config the resource keeps when its format changes to the given one,
+// which is the current config as long as it suits the format, and an empty one otherwise.
+func (d ResourceDetails) keptParser(format resource.Format) parser.Config {
+	if d.Parser.Validate(format) != nil {
+		return parser.Config{}
+	}
+	return d.Parser
+}
+
+// BackupSuffix is appended to the path of the feeds dictionary to get the path of its backup.
+// The backup keeps the last good dictionary and replaces a dictionary that cannot be loaded on start.
+const BackupSuffix = ".bak"
+
+// feedsPerm is the permission of the feeds dictionary and its backup.
+const feedsPerm = 0644
+
+// DefaultConcurrency is the default number of the sources fetched at the same time by UpdateAllSources.
+const DefaultConcurrency = 4
+
 // ResourceManager is a manager that responsible for retrieval of feeds from the storage,
-// forming them into structures.
+// forming them into structures. It is safe for concurrent use.
 type ResourceManager struct {
-	storage            *storage.Storage
-	feeds              map[resource.Source]ResourceDetails
+	storage            storage.Storage
+	cache              *cache.Cache
+	client             *fetcher.Client
+	health             *fetcher.HealthTracker
+	concurrency        int
 	feedDictionaryPath string
+
+	// mu guards the feeds and the writes of the feeds dictionary.
+	mu    sync.RWMutex
+	feeds map[resource.Source]ResourceDetails
+
+	// statusMu guards the fetch statuses and the writes of their file.
+	statusMu sync.Mutex
+	statuses map[resource.Source]FetchStatus
+
+	// parsersMu guards the parsers built from the parser configs of the sources, see ParserFactory.
+	parsersMu sync.Mutex
+	parsers   map[resource.Source]configuredParser
 }
 
-// New creates a new ResourceManager.
+// New creates a new ResourceManager keeping the fetched contents of the sources in files of the storage path.
 func New(storagePath string, feedDictionaryPath string) (*ResourceManager, error) {
+	return NewWithStorage(storage.New(storagePath), feedDictionaryPath)
+}
+
+// NewWithStorage creates a new ResourceManager keeping the fetched contents of the sources in the storage.
+// A storage.ArticleStorage gets the parsers of the sources, so that it keeps the parsed articles.
+func NewWithStorage(s storage.Storage, feedDictionaryPath string) (*ResourceManager, error) {
 
 	dir := filepath.Dir(feedDictionaryPath)
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
@@ -42,58 +97,280 @@ func New(storagePath string, feedDictionaryPath string) (*ResourceManager, error
 		if err != nil {
 			return nil, fmt.Errorf("error creating feed dictionary file: %v", err)
 		}
-	} else if err == nil && fileInfo.Size() == 0 {
+	} else if err == nil && fileInfo.Size() == 0 && !fileExists(feedDictionaryPath+BackupSuffix) {
 		err := createEmptyJSONFile(feedDictionaryPath)
 		if err != nil {
 			return nil, fmt.Errorf("error initializing feed dictionary file: %v", err)
 		}
 	}
 
-	feeds, err := loadResources(feedDictionaryPath)
+	feeds, err := loadResourcesWithBackup(feedDictionaryPath)
 	if err != nil {
 		return nil, fmt.Errorf("error loading feeds: %v", err)
 	}
 
-	return &ResourceManager{
-		storage:            storage.New(storagePath),
+	rm := &ResourceManager{
+		storage:            s,
 		feeds:              feeds,
+		statuses:           loadFetchStatuses(feedDictionaryPath + StateSuffix),
+		parsers:            make(map[resource.Source]configuredParser),
+		client:             fetcher.New(),
+		health:             fetcher.NewHealthTracker(),
+		concurrency:        DefaultConcurrency,
 		feedDictionaryPath: feedDictionaryPath,
-	}, nil
+	}
+
+	if articleStorage, ok := s.(storage.ArticleStorage); ok {
+		articleStorage.SetParser(rm.parse)
+	}
+
+	return rm, nil
+}
+
+// SetCache sets the cache of the parsed articles. The cached articles of a source are invalidated
+// whenever a snapshot of the source is stored or the source is changed, and the returned snapshots
+// are read only when they are not cached. It must be called before the manager is used concurrently,
+// e.g. before the scheduler updating the sources is started.
+func (rm *ResourceManager) SetCache(c *cache.Cache) {
+	rm.cache = c
+}
+
+// SetFetchClient sets the client fetching the sources.
+func (rm *ResourceManager) SetFetchClient(c *fetcher.Client) {
+	rm.client = c
+}
+
+// SetHealthTracker sets the tracker of the health of fetching the sources.
+func (rm *ResourceManager) SetHealthTracker(t *fetcher.HealthTracker) {
+	rm.health = t
+}
+
+// SourcesHealth returns the health of fetching the sources updated since the start.
+func (rm *ResourceManager) SourcesHealth() map[resource.Source]fetcher.Health {
+	all := rm.health.All()
+
+	sourcesHealth := make(map[resource.Source]fetcher.Health, len(all))
+	for source, health := range all {
+		sourcesHealth[resource.Source(source)] = health
+	}
+	return sourcesHealth
+}
+
+// SetConcurrency sets the number of the sources fetched at the same time by UpdateAllSources.
+// Values lower than one are ignored.
+func (rm *ResourceManager) SetConcurrency(n int) {
+	if n < 1 {
+		return
+	}
+	rm.concurrency = n
 }
 
 // RegisterSource registers a new source.
 func (rm *ResourceManager) RegisterSource(name resource.Source, url string, format resource.Format) error {
+	return rm.RegisterConfiguredSource(name, url, format, parser.Config{})
+}
+
+// RegisterConfiguredSource registers a new source along with the config of its parser.
+// ErrSourceExists is returned if a source of the name is already registered.
+func (rm *ResourceManager) RegisterConfiguredSource(name resource.Source, url string, format resource.Format,
+	config parser.Config) error {
+	return rm.RegisterScheduledSource(name, url, format, config, "")
+}
+
+// RegisterScheduledSource registers a new source along with the config of its parser and the schedule
+// of its updates, see schedule.Parse, an empty one for the default of the scheduler.
+// The source is saved at once, so it is either registered with its schedule or not at all.
+// ErrSourceExists is returned if a source of the name is already registered.
+func (rm *ResourceManager) RegisterScheduledSource(name resource.Source, url string, format resource.Format,
+	config parser.Config, spec string) error {
+
+	if err := ValidateSource(name, url); err != nil {
+		return err
+	}
+
+	if err := config.Validate(format); err != nil {
+		return fmt.Errorf("invalid parser config: %v", err)
+	}
+
+	if err := validateSchedule(spec); err != nil {
+		return err
+	}
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if _, exists := rm.feeds[name]; exists {
+		return fmt.Errorf("%w: %s", ErrSourceExists, name)
+	}
 
 	rm.feeds[name] = ResourceDetails{
-		Format: format,
-		Link:   url,
+		Format:   format,
+		Link:     url,
+		Parser:   config,
+		Schedule: spec,
+	}
+	rm.invalidate(name)
+
+	rm.health.Forget(string(name))
+
+	if err := rm.resetFetchStatus(name); err != nil {
+		delete(rm.feeds, name)
+		return err
 	}
 
-	return rm.saveFeeds()
+	if err := rm.saveFeeds(); err != nil {
+		// The source is forgotten, so that the registration can be retried.
+		delete(rm.feeds, name)
+		return err
+	}
+
+	return nil
 }
 
 // UpdateSource updates the source.
+// The parser config of the source is kept as long as it suits the new format, the schedule is always kept.
+// ErrSourceNotFound is returned if the source is not registered.
 func (rm *ResourceManager) UpdateSource(name resource.Source, url string, format resource.Format) error {
+	return rm.UpdateScheduledSource(name, url, format, "")
+}
 
-	rm.feeds[name] = ResourceDetails{
-		Format: format,
-		Link:   url,
+// UpdateScheduledSource updates the source along with the schedule of its updates, see schedule.Parse.
+// The parser config of the source is kept as long as it suits the new format, the schedule is kept
+// unless a new one is given. The source is saved at once, so it is either updated with its schedule or not at all.
+// ErrSourceNotFound is returned if the source is not registered.
+func (rm *ResourceManager) UpdateScheduledSource(name resource.Source, url string, format resource.Format,
+	spec string) error {
+
+	if err := ValidateSource(name, url); err != nil {
+		return err
+	}
+
+	if err := validateSchedule(spec); err != nil {
+		return err
+	}
+
+	details := ResourceDetails{
+		Format:   format,
+		Link:     url,
+		Schedule: spec,
+	}
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	existing, exists := rm.feeds[name]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrSourceNotFound, name)
+	}
+
+	if details.Schedule == "" {
+		details.Schedule = existing.Schedule
+	}
+	details.Parser = existing.keptParser(format)
+
+	rm.feeds[name] = details
+	rm.invalidate(name)
+
+	rm.health.Forget(string(name))
+
+	if err := rm.resetFetchStatus(name); err != nil {
+		rm.feeds[name] = existing
+		return err
 	}
 
-	return rm.saveFeeds()
+	if err := rm.saveFeeds(); err != nil {
+		rm.feeds[name] = existing
+		return err
+	}
+
+	return nil
 }
 
-// DeleteSource deletes the source.
+// DeleteSource deletes the source. ErrSourceNotFound is returned if the source is not registered.
 func (rm *ResourceManager) DeleteSource(name resource.Source) error {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	existing, exists := rm.feeds[name]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrSourceNotFound, name)
+	}
 
 	delete(rm.feeds, name)
+	rm.invalidate(name)
+
+	rm.health.Forget(string(name))
+
+	if err := rm.resetFetchStatus(name); err != nil {
+		rm.feeds[name] = existing
+		return err
+	}
+
+	if err := rm.saveFeeds(); err != nil {
+		rm.feeds[name] = existing
+		return err
+	}
+
+	rm.forgetParser(name)
 
-	return rm.saveFeeds()
+	return nil
+}
+
+// SetSourceSchedule sets the update schedule of the registered source, see schedule.Parse.
+// An empty schedule restores the default one of the scheduler.
+func (rm *ResourceManager) SetSourceSchedule(name resource.Source, spec string) error {
+	if err := validateSchedule(spec); err != nil {
+		return err
+	}
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	existing, exists := rm.feeds[name]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrSourceNotFound, name)
+	}
+
+	details := existing
+	details.Schedule = spec
+	rm.feeds[name] = details
+
+	if err := rm.saveFeeds(); err != nil {
+		rm.feeds[name] = existing
+		return err
+	}
+
+	return nil
+}
+
+// validateSchedule checks that the schedule is empty or valid.
+func validateSchedule(spec string) error {
+	if spec == "" {
+		return nil
+	}
+
+	if _, err := schedule.Parse(spec); err != nil {
+		return fmt.Errorf("invalid schedule: %v", err)
+	}
+	return nil
+}
+
+// SourceSchedules returns the update schedules of the registered sources.
+// The sources without a schedule of their own are mapped to an empty one.
+func (rm *ResourceManager) SourceSchedules() map[resource.Source]string {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	schedules := make(map[resource.Source]string, len(rm.feeds))
+	for source, details := range rm.feeds {
+		schedules[source] = details.Schedule
+	}
+	return schedules
 }
 
 // IsSourceSupported checks if the source is supported.
 func (rm *ResourceManager) IsSourceSupported(source resource.Source) bool {
-	_, exists := rm.feeds[source]
+	_, exists := rm.details(source)
 	return exists
 }
 
@@ -120,14 +397,15 @@ func (rm *ResourceManager) AvailableSources() string {
 
 // AvailableFeeds returns the available feeds registered in a system.
 func (rm *ResourceManager) AvailableFeeds() string {
+	sources := rm.sources()
 
-	if len(rm.feeds) == 0 {
+	if len(sources) == 0 {
 		return "no available feeds"
 	}
 
 	feeds := ""
 
-	for source := range rm.feeds {
+	for _, source := range sources {
 		feeds += string(source) + ","
 	}
 
@@ -135,19 +413,28 @@ func (rm *ResourceManager) AvailableFeeds() string {
 }
 
 // GetAllResources returns all known resource.Resource's from a file system.
+// The sources whose resources cannot be read, e.g. the ones not fetched yet, are skipped
+// and a *ResourceError listing them is returned along with the resources of the other sources.
 func (rm *ResourceManager) GetAllResources() ([]resource.Resource, error) {
 
 	fetchedResources := make([]resource.Resource, 0)
+	var sourceErrors []aggregator.SourceError
 
-	for s := range rm.feeds {
+	for _, s := range rm.sources() {
 		res, err := rm.getResource(s)
 		if err != nil {
-			return fetchedResources, fmt.Errorf("error getting resource : %v", err)
+			details, _ := rm.details(s)
+			sourceErrors = append(sourceErrors, aggregator.SourceError{Source: s, Format: details.Format, Err: err})
+			continue
 		}
 
 		fetchedResources = append(fetchedResources, res...)
 	}
 
+	if len(sourceErrors) > 0 {
+		return fetchedResources, &ResourceError{Errors: sourceErrors}
+	}
+
 	return fetchedResources, nil
 
 }
@@ -159,7 +446,7 @@ func (rm *ResourceManager) GetSelectedResources(sourceNames []string) ([]resourc
 
 	for _, name := range sourceNames {
 		s := resource.Source(name)
-		if _, exists := rm.feeds[s]; exists {
+		if _, exists := rm.details(s); exists {
 			res, err := rm.getResource(s)
 			if err != nil {
 				return nil, fmt.Errorf("error getting resource from source \"%s\" : %v", name, err)
@@ -174,51 +461,291 @@ func (rm *ResourceManager) GetSelectedResources(sourceNames []string) ([]resourc
 	return fetchedResources, nil
 }
 
-// UpdateAllSources updates all sources in the storage.
+// RegisterParsers adds a parser to the factory for every source that has a parser config
+// in the feeds dictionary, so that such sources can be aggregated without dedicated Go code.
+func (rm *ResourceManager) RegisterParsers(factory aggregator.Factory) error {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	for source, details := range rm.feeds {
+		if details.Parser.IsEmpty() {
+			continue
+		}
+
+		p, err := aggregator.NewConfiguredParser(details.Format, details.Parser)
+		if err != nil {
+			return fmt.Errorf("error creating parser for source \"%s\": %v", source, err)
+		}
+
+		factory.AddNewParser(details.Format, source, p)
+	}
+
+	return nil
+}
+
+// UpdateAllSources updates all sources in the storage, see UpdateSources.
 func (rm *ResourceManager) UpdateAllSources() error {
+	sources := rm.sources()
 
-	if len(rm.feeds) == 0 {
+	if len(sources) == 0 {
 		return fmt.Errorf("no sources available")
 	}
 
-	for source := range rm.feeds {
-		err := rm.UpdateResource(source)
-		if err != nil {
-			return fmt.Errorf("error updating source \"%s\": %v", source, err)
+	return rm.UpdateSources(sources)
+}
+
+// UpdateSources updates the sources in the storage, fetching up to the concurrency limit of them at the same time.
+// A failing source does not stop the others, the failures are returned as an *UpdateError.
+// The sources backed off by the health tracker after repeated failures are skipped until their backoff expires.
+func (rm *ResourceManager) UpdateSources(sources []resource.Source) error {
+	results := make([]SourceResult, len(sources))
+	limit := make(chan struct{}, rm.concurrency)
+	var wg sync.WaitGroup
+
+	for i, source := range sources {
+		wg.Add(1)
+		limit <- struct{}{}
+
+		go func(i int, source resource.Source) {
+			defer wg.Done()
+			defer func() { <-limit }()
+
+			if !rm.health.Allow(string(source)) {
+				results[i] = SourceResult{Source: source, Skipped: true}
+				return
+			}
+
+			results[i] = SourceResult{Source: source, Err: rm.UpdateResource(source)}
+		}(i, source)
+	}
+
+	wg.Wait()
+
+	for _, result := range results {
+		if result.Err != nil {
+			return &UpdateError{Results: results}
 		}
 	}
 
 	return nil
 }
 
-// UpdateResource updates the source in the storage.
+// UpdateResource updates the source in the storage. The source is fetched conditionally with the validators
+// of its last response, and a content that is not modified, or identical to the last stored one, is not stored.
+// The result is recorded in the health of the source.
 func (rm *ResourceManager) UpdateResource(source resource.Source) error {
-	details, exists := rm.feeds[source]
+	_, err := rm.refresh(source, false)
+	return err
+}
+
+// RefreshResource updates the source in the storage as UpdateResource does, and reports the size of the fetched
+// content and the number of the articles parsed from it, if it has changed.
+func (rm *ResourceManager) RefreshResource(source resource.Source) (RefreshResult, error) {
+	return rm.refresh(source, true)
+}
+
+// RegisteredSources returns the registered sources in alphabetical order.
+func (rm *ResourceManager) RegisteredSources() []resource.Source {
+	return rm.sources()
+}
+
+// refresh updates the source and records the result in its health.
+// The articles of a changed content are counted if countArticles is set, a content that cannot be parsed
+// is stored nevertheless, as it is by the scheduled updates, but the parsing error is returned.
+func (rm *ResourceManager) refresh(source resource.Source, countArticles bool) (RefreshResult, error) {
+	details, exists := rm.details(source)
 	if !exists {
-		return fmt.Errorf("source \"%s\" is not supported", source)
+		return RefreshResult{}, fmt.Errorf("source \"%s\" is not supported", source)
+	}
+
+	var changed []byte
+	fetched, err := rm.updateResource(source, details, func(body []byte) error {
+		if err := rm.save(source, details.Format, body); err != nil {
+			return err
+		}
+		changed = body
+		return nil
+	})
+
+	result := RefreshResult{BytesFetched: fetched, Changed: changed != nil}
+	if err != nil {
+		rm.health.RecordFailure(string(source), err)
+		return result, err
+	}
+	rm.health.RecordSuccess(string(source))
+
+	if countArticles && result.Changed {
+		articles, err := rm.parse(source, changed)
+		if err != nil {
+			return result, fmt.Errorf("error parsing resource content: %v", err)
+		}
+		result.ArticlesParsed = len(articles)
 	}
 
-	switch details.Format {
-	case resource.RSS:
-		return rm.updateRSSResource(source, details)
+	return result, nil
+}
+
+// Import copies the stored contents of the registered sources from another storage, e.g. the files of
+// a storage.FileStorage into a storage.BoltStorage, and returns the results of all sources.
+// Sources without stored contents are skipped. A source that cannot be read or a content that fails to be saved
+// does not stop the import of the others, an *ImportError is returned once all sources are imported then.
+// Importing a content again replaces its stored articles, so a failed import is resumed by running it again.
+func (rm *ResourceManager) Import(from storage.Storage) ([]ImportResult, error) {
+	var results []ImportResult
+	failed := false
+
+	for _, source := range rm.sources() {
+		details, _ := rm.details(source)
+		result := ImportResult{Source: source}
+
+		contents, err := from.ReadSource(source)
+		if errors.Is(err, storage.ErrSourceUnknown) {
+			result.Skipped = true
+			results = append(results, result)
+			continue
+		}
+		if err != nil {
+			result.Err = fmt.Errorf("error reading source: %v", err)
+			results = append(results, result)
+			failed = true
+			continue
+		}
+
+		for _, content := range contents {
+			err := rm.save(source, details.Format, []byte(content))
+			if err != nil {
+				result.Failed++
+				result.Err = fmt.Errorf("error importing content: %v", err)
+				continue
+			}
+			result.Imported++
+		}
+
+		if result.Err != nil {
+			failed = true
+		}
+		results = append(results, result)
+	}
+
+	if failed {
+		return results, &ImportError{Results: results}
+	}
+
+	return results, nil
+}
+
+// PruneSnapshots removes the stored snapshots exceeding the retention policy and returns them.
+// Storages that do not keep snapshots, e.g. a storage.BoltStorage, are left as is.
+func (rm *ResourceManager) PruneSnapshots(policy storage.RetentionPolicy) ([]storage.Snapshot, error) {
+	pruner, ok := rm.storage.(storage.Pruner)
+	if !ok || policy.IsEmpty() {
+		return nil, nil
+	}
+
+	pruned, err := pruner.Prune(policy, false)
+	if err != nil {
+		return nil, fmt.Errorf("error pruning snapshots: %v", err)
+	}
+
+	for _, snapshot := range pruned {
+		rm.invalidate(snapshot.Source)
+	}
+
+	return pruned, nil
+}
+
+// save passes the content of the source to the update method of the storage suiting the format.
+func (rm *ResourceManager) save(source resource.Source, format resource.Format, body []byte) error {
+	var err error
+
+	switch format {
+	case resource.RSS, resource.ATOM:
+		err = rm.storage.UpdateXMLSource(source, body)
 	case resource.HTML:
-		return rm.updateHTMLResource(source, details)
+		err = rm.storage.UpdateHTMLSource(source, body)
+	case resource.JSON, resource.JSONFEED:
+		err = rm.storage.UpdateJSONSource(source, body)
 	default:
 		return fmt.Errorf("unknown format")
 	}
+
+	if err != nil {
+		return err
+	}
+
+	rm.invalidate(source)
+	return nil
+}
+
+// invalidate removes the cached articles of the source, if the cache is set.
+func (rm *ResourceManager) invalidate(source resource.Source) {
+	if rm.cache != nil {
+		rm.cache.Invalidate(source)
+	}
+}
+
+// parse turns the fetched content of the source into articles with the parser of the source.
+// The items that cannot be parsed are skipped, as the aggregator does in the tolerant mode.
+func (rm *ResourceManager) parse(source resource.Source, content []byte) ([]article.Article, error) {
+	details, exists := rm.details(source)
+	if !exists {
+		return nil, fmt.Errorf("source \"%s\" is not supported", source)
+	}
+
+	articles, _, err := parseContent(source, details, content)
+	return articles, err
+}
+
+// parseContent turns the content into articles with the parser suiting the details of the source,
+// skipping the items that cannot be parsed, and returns the warnings of the skipped items.
+func parseContent(source resource.Source, details ResourceDetails,
+	content []byte) ([]article.Article, []parser.ItemWarning, error) {
+
+	var p aggregator.Parser
+	var err error
+	if details.Parser.IsEmpty() {
+		p, err = aggregator.NewParserFactory().GetParser(details.Format, source)
+	} else {
+		p, err = aggregator.NewConfiguredParser(details.Format, details.Parser)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	res, err := resource.New(source, details.Format, resource.Content(content))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if tolerantParser, ok := p.(aggregator.TolerantParser); ok {
+		return tolerantParser.ParseTolerant(*res)
+	}
+	articles, err := p.Parse(*res)
+	return articles, nil, err
 }
 
 func (rm *ResourceManager) getResource(source resource.Source) ([]resource.Resource, error) {
+	details, _ := rm.details(source)
+
+	if snapshotStorage, ok := rm.storage.(storage.SnapshotStorage); ok {
+		return rm.getSnapshots(snapshotStorage, source, details.Format)
+	}
+
 	resContent, err := rm.storage.ReadSource(source)
 
 	if err != nil {
-		return []resource.Resource{}, fmt.Errorf("error reading file: %v", err)
+		return []resource.Resource{}, fmt.Errorf("error reading file: %w", err)
+	}
+
+	format := details.Format
+	if _, ok := rm.storage.(storage.ArticleStorage); ok {
+		format = resource.STORED
 	}
 
 	resources := make([]resource.Resource, 0)
 
 	for _, content := range resContent {
-		res, err := resource.New(source, rm.feeds[source].Format, resource.Content(content))
+		res, err := resource.New(source, format, resource.Content(content))
 		if err != nil {
 			return resources, fmt.Errorf("error creating resource: %v", err)
 		}
@@ -228,39 +755,79 @@ func (rm *ResourceManager) getResource(source resource.Source) ([]resource.Resou
 	return resources, nil
 }
 
-func (rm *ResourceManager) updateRSSResource(source resource.Source, details ResourceDetails) error {
-	resp, err := http.Get(details.Link)
+// getSnapshots returns a resource for every snapshot of the source,
+// fetched at the time of the snapshot so that the year of the dates without one is inferred relative to it.
+// With a cache set, the snapshots are read only when they are parsed, so that the cached ones are not read at all.
+func (rm *ResourceManager) getSnapshots(snapshotStorage storage.SnapshotStorage, source resource.Source,
+	format resource.Format) ([]resource.Resource, error) {
+
+	snapshots, err := snapshotStorage.SourceSnapshots(source)
 	if err != nil {
-		return fmt.Errorf("error fetching resource from link: %v", err)
+		return []resource.Resource{}, fmt.Errorf("error reading file: %w", err)
 	}
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
+
+	resources := make([]resource.Resource, 0, len(snapshots))
+
+	for _, snapshot := range snapshots {
+		if rm.cache != nil {
+			res, err := resource.NewSnapshot(source, format, snapshot.Identity(), snapshotLoader(snapshotStorage, snapshot))
+			if err != nil {
+				return resources, fmt.Errorf("error creating resource: %v", err)
+			}
+			res.SetFetchedAt(snapshot.Time)
+			resources = append(resources, *res)
+			continue
+		}
+
+		content, err := snapshotStorage.ReadSnapshot(snapshot)
 		if err != nil {
-			fmt.Printf("error closing response body: %v\n", err)
+			return resources, fmt.Errorf("error reading file: %v", err)
 		}
-	}(resp.Body)
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("error fetching resource from link: status code %d", resp.StatusCode)
+		res, err := resource.New(source, format, resource.Content(content))
+		if err != nil {
+			return resources, fmt.Errorf("error creating resource: %v", err)
+		}
+		res.SetFetchedAt(snapshot.Time)
+		resources = append(resources, *res)
 	}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("error reading resource content: %v", err)
+	return resources, nil
+}
+
+// snapshotLoader returns the loader reading the content of the snapshot from the storage.
+func snapshotLoader(snapshotStorage storage.SnapshotStorage, snapshot storage.Snapshot) resource.Loader {
+	return func() (resource.Content, error) {
+		content, err := snapshotStorage.ReadSnapshot(snapshot)
+		if err != nil {
+			return "", fmt.Errorf("error reading file: %v", err)
+		}
+		return resource.Content(content), nil
 	}
+}
+
+// updateResource fetches the resource by its link and passes a changed content to the save function.
+// The fetch status of the source is recorded after every successful fetch.
+// It returns the size of the fetched content, zero if the resource is not modified.
+func (rm *ResourceManager) updateResource(source resource.Source, details ResourceDetails,
+	save func(body []byte) error) (int64, error) {
 
-	err = rm.storage.UpdateXMLSource(source, body)
+	status, _ := rm.FetchStatus(source)
+
+	req, err := http.NewRequest(http.MethodGet, details.Link, nil)
 	if err != nil {
-		return err
+		return 0, fmt.Errorf("error fetching resource from link: %v", err)
+	}
+	if status.ETag != "" {
+		req.Header.Set("If-None-Match", status.ETag)
+	}
+	if status.LastModified != "" {
+		req.Header.Set("If-Modified-Since", status.LastModified)
 	}
 
-	return nil
-}
-
-func (rm *ResourceManager) updateHTMLResource(source resource.Source, details ResourceDetails) error {
-	resp, err := http.Get(details.Link)
+	resp, err := rm.client.Do(req)
 	if err != nil {
-		return fmt.Errorf("error fetching resource from link: %v", err)
+		return 0, fmt.Errorf("error fetching resource from link: %v", err)
 	}
 	defer func(Body io.ReadCloser) {
 		err := Body.Close()
@@ -269,67 +836,160 @@ func (rm *ResourceManager) updateHTMLResource(source resource.Source, details Re
 		}
 	}(resp.Body)
 
+	status.LastChecked = time.Now()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return 0, rm.setFetchStatus(source, status)
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("error fetching resource from link: status code %d", resp.StatusCode)
+		return 0, fmt.Errorf("error fetching resource from link: status code %d", resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("error reading resource content: %v", err)
+		return 0, fmt.Errorf("error reading resource content: %v", err)
+	}
+
+	status.ETag = resp.Header.Get("ETag")
+	status.LastModified = resp.Header.Get("Last-Modified")
+
+	hash := sha256.Sum256(body)
+	contentHash := hex.EncodeToString(hash[:])
+
+	if contentHash != status.ContentHash || !rm.isStored(source) {
+		if err := save(body); err != nil {
+			return int64(len(body)), err
+		}
+		status.ContentHash = contentHash
+		status.LastChanged = status.LastChecked
 	}
 
-	err = rm.storage.UpdateHTMLSource(source, body)
+	return int64(len(body)), rm.setFetchStatus(source, status)
+}
+
+// isStored reports whether the storage keeps a content of the source, e.g. it is not removed by hand.
+func (rm *ResourceManager) isStored(source resource.Source) bool {
+	sources, err := rm.storage.AvailableSources()
 	if err != nil {
-		return err
+		return false
 	}
 
-	return nil
+	for _, stored := range sources {
+		if stored == source {
+			return true
+		}
+	}
+	return false
+}
+
+// details returns the details of the source and whether the source is registered.
+func (rm *ResourceManager) details(source resource.Source) (ResourceDetails, bool) {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	details, exists := rm.feeds[source]
+	return details, exists
+}
+
+// sources returns the registered sources in alphabetical order.
+func (rm *ResourceManager) sources() []resource.Source {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	sources := make([]resource.Source, 0, len(rm.feeds))
+	for source := range rm.feeds {
+		sources = append(sources, source)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i] < sources[j]
+	})
+
+	return sources
 }
 
+// saveFeeds writes the feeds to the feeds dictionary. It is called with the lock held.
 func (rm *ResourceManager) saveFeeds() error {
-	resourceList := make([]struct {
-		Source string `json:"source"`
-		Format string `json:"format"`
-		Link   string `json:"link"`
-	}, 0, len(rm.feeds))
+	resourceList := make([]feedJSON, 0, len(rm.feeds))
 
 	for source, details := range rm.feeds {
-		resourceList = append(resourceList, struct {
-			Source string `json:"source"`
-			Format string `json:"format"`
-			Link   string `json:"link"`
-		}{
-			Source: string(source),
-			Format: resource.FormatToString(details.Format),
-			Link:   details.Link,
+		resourceList = append(resourceList, feedJSON{
+			Source:   string(source),
+			Format:   resource.FormatToString(details.Format),
+			Link:     details.Link,
+			Schedule: details.Schedule,
+			Config:   details.Parser,
 		})
 	}
 
-	file, err := os.Create(rm.feedDictionaryPath)
+	sort.Slice(resourceList, func(i, j int) bool {
+		return resourceList[i].Source < resourceList[j].Source
+	})
 
+	data, err := json.Marshal(&resourceList)
 	if err != nil {
-		return fmt.Errorf("error creating feeds file: %v", err)
+		return fmt.Errorf("error encoding feeds file: %v", err)
 	}
 
-	defer func(file *os.File) {
-		err := file.Close()
-		if err != nil {
-			fmt.Printf("error closing feeds file: %v\n", err)
-		}
-	}(file)
+	err = backupFeeds(rm.feedDictionaryPath)
+	if err != nil {
+		return err
+	}
 
-	sort.Slice(resourceList, func(i, j int) bool {
-		return resourceList[i].Source < resourceList[j].Source
-	})
+	err = storage.WriteFileAtomic(rm.feedDictionaryPath, append(data, '\n'), feedsPerm)
+	if err != nil {
+		return fmt.Errorf("error writing feeds file: %v", err)
+	}
 
-	encoder := json.NewEncoder(file)
-	if err := encoder.Encode(&resourceList); err != nil {
-		return fmt.Errorf("error encoding feeds file: %v", err)
+	return nil
+}
+
+// backupFeeds copies the feeds dictionary at the path to its backup, unless the dictionary cannot be loaded,
+// so that the backup always keeps the last good dictionary.
+func backupFeeds(path string) error {
+	if _, err := loadResources(path); err != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading feeds file: %v", err)
+	}
+
+	err = storage.WriteFileAtomic(path+BackupSuffix, data, feedsPerm)
+	if err != nil {
+		return fmt.Errorf("error writing feeds backup: %v", err)
 	}
 
 	return nil
 }
 
+// loadResourcesWithBackup loads the feeds dictionary at the path. A dictionary that cannot be loaded,
+// e.g. truncated by a crash, is replaced by its backup if the backup can be loaded.
+func loadResourcesWithBackup(path string) (map[resource.Source]ResourceDetails, error) {
+	feeds, err := loadResources(path)
+	if err == nil {
+		return feeds, nil
+	}
+
+	backupPath := path + BackupSuffix
+	feeds, backupErr := loadResources(backupPath)
+	if backupErr != nil {
+		return nil, err
+	}
+
+	data, backupErr := os.ReadFile(backupPath)
+	if backupErr == nil {
+		backupErr = storage.WriteFileAtomic(path, data, feedsPerm)
+	}
+	if backupErr != nil {
+		return nil, fmt.Errorf("%v, restoring backup: %v", err, backupErr)
+	}
+
+	fmt.Printf("feeds file %s is restored from backup: %v\n", path, err)
+	return feeds, nil
+}
+
 func loadResources(path string) (map[resource.Source]ResourceDetails, error) {
 	file, err := os.Open(path)
 	if err != nil {
@@ -342,11 +1002,7 @@ func loadResources(path string) (map[resource.Source]ResourceDetails, error) {
 		}
 	}(file)
 
-	var resourceList []struct {
-		Source string `json:"source"`
-		Format string `json:"format"`
-		Link   string `json:"link"`
-	}
+	var resourceList []feedJSON
 
 	decoder := json.NewDecoder(file)
 	if err := decoder.Decode(&resourceList); err != nil {
@@ -360,28 +1016,34 @@ func loadResources(path string) (map[resource.Source]ResourceDetails, error) {
 			return nil, err
 		}
 
+		if err := res.Config.Validate(format); err != nil {
+			return nil, fmt.Errorf("source \"%s\": %v", res.Source, err)
+		}
+
+		if res.Schedule != "" {
+			if _, err := schedule.Parse(res.Schedule); err != nil {
+				return nil, fmt.Errorf("source \"%s\": invalid schedule: %v", res.Source, err)
+			}
+		}
+
 		rFormats[resource.Source(res.Source)] = ResourceDetails{
-			Format: format,
-			Link:   res.Link,
+			Format:   format,
+			Link:     res.Link,
+			Parser:   res.Config,
+			Schedule: res.Schedule,
 		}
 	}
 
 	return rFormats, nil
 }
 
+// fileExists reports whether the file at the path exists.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
 // createEmptyJSONFile creates a file with an empty JSON array.
 func createEmptyJSONFile(path string) error {
-	emptyFile, err := os.Create(path)
-	if err != nil {
-		return err
-	}
-	defer func(emptyFile *os.File) {
-		err := emptyFile.Close()
-		if err != nil {
-			fmt.Printf("error closing empty JSON file: %v\n", err)
-		}
-	}(emptyFile)
-
-	_, err = emptyFile.Write([]byte("[]"))
-	return err
+	return storage.WriteFileAtomic(path, []byte("[]"), feedsPerm)
 }
@@ -2,9 +2,23 @@ package manager_test
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"news-aggregator/aggregator"
+	"news-aggregator/aggregator/cache"
+	"news-aggregator/aggregator/parser"
+	"news-aggregator/fetcher"
 	"news-aggregator/manager"
+	"news-aggregator/storage"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"news-aggregator/aggregator/model/resource"
@@ -99,7 +113,7 @@ func TestAvailableSources(t *testing.T) {
 	rm, err := manager.New(testStoragePath, testFeedDictionary)
 	assert.NoError(t, err)
 
-	expectedSources := "supported,test,"
+	expectedSources := "supported_source,test,"
 	assert.Equal(t, expectedSources, rm.AvailableSources())
 }
 
@@ -119,6 +133,32 @@ func TestGetAllResources(t *testing.T) {
 	}
 }
 
+func TestGetAllResources_NotFetched(t *testing.T) {
+	content, err := os.ReadFile("../aggregator/parser/testdata/rss/test.xml")
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	dictionary := filepath.Join(dir, "feeds.json")
+	feeds := `[{"source": "bbc", "format": "RSS", "link": "http://bbc.com/rss"},
+		{"source": "cnn", "format": "ATOM", "link": "http://cnn.com/atom"}]`
+	assert.NoError(t, os.WriteFile(dictionary, []byte(feeds), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "bbc_20240101120000.xml"), content, 0644))
+
+	rm, err := manager.New(dir, dictionary)
+	assert.NoError(t, err)
+
+	resources, err := rm.GetAllResources()
+	assert.Len(t, resources, 1, "The resources of the fetched sources should be returned")
+	assert.Equal(t, resource.Source("bbc"), resources[0].Source())
+
+	var resourceErr *manager.ResourceError
+	assert.ErrorAs(t, err, &resourceErr)
+	assert.Len(t, resourceErr.Errors, 1)
+	assert.Equal(t, resource.Source("cnn"), resourceErr.Errors[0].Source)
+	assert.Equal(t, resource.Format(resource.ATOM), resourceErr.Errors[0].Format)
+	assert.ErrorIs(t, err, storage.ErrSourceUnknown)
+}
+
 func TestGetSelectedResources(t *testing.T) {
 	rm, err := manager.New(testStoragePath, testFeedDictionary)
 	assert.NoError(t, err)
@@ -135,6 +175,34 @@ func TestGetSelectedResources(t *testing.T) {
 	}
 }
 
+func TestGetSelectedResources_FetchedAt(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"version": "https://jsonfeed.org/version/1.1", "items": []}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	rm, err := manager.New(dir, filepath.Join(dir, "feeds.json"))
+	assert.NoError(t, err)
+	assert.NoError(t, rm.RegisterSource("json-feed", server.URL, resource.JSONFEED))
+
+	// A snapshot fetched at the end of December, aggregated later.
+	content := `{"version": "https://jsonfeed.org/version/1.1", "items": [{"id": "1", "title": "First", ` +
+		`"summary": "First story", "url": "https://example.com/1", "date_published": "9:00 AM January 3"}]}`
+	err = os.WriteFile(filepath.Join(dir, "json-feed_20231231220000.json"), []byte(content), 0644)
+	assert.NoError(t, err)
+
+	resources, err := rm.GetSelectedResources([]string{"json-feed"})
+	assert.NoError(t, err)
+	assert.Len(t, resources, 1)
+	assert.Equal(t, time.Date(2023, 12, 31, 22, 0, 0, 0, time.UTC), resources[0].FetchedAt())
+
+	articles, err := (&parser.JSONFeedParser{}).Parse(resources[0])
+	assert.NoError(t, err)
+	assert.Len(t, articles, 1)
+	assert.Equal(t, time.Date(2023, 1, 3, 9, 0, 0, 0, time.UTC), time.Time(articles[0].Date()))
+}
+
 func TestGetAvailableFeeds_SingleFeed(t *testing.T) {
 
 	file := "./testdata/empty.json"
@@ -185,3 +253,1055 @@ func TestGetAvailableFeeds_NoFeeds(t *testing.T) {
 
 	assert.Equal(t, "no available feeds", rm.AvailableFeeds())
 }
+
+func TestJSONMappingIsLoaded(t *testing.T) {
+	dir := t.TempDir()
+	dictionary := filepath.Join(dir, "feeds.json")
+	content := `[{"source": "custom", "format": "JSON", "link": "http://custom.com/api",
+		"jsonMapping": {"items": "data.posts", "title": "headline", "description": "teaser", "date": "published"}}]`
+	assert.NoError(t, os.WriteFile(dictionary, []byte(content), 0644))
+
+	rm, err := manager.New(dir, dictionary)
+	assert.NoError(t, err)
+
+	factory := &recordingFactory{}
+	assert.NoError(t, rm.RegisterParsers(factory))
+	assert.Equal(t, []resource.Source{"custom"}, factory.sources)
+
+	assert.NoError(t, rm.UpdateSource("custom", "http://custom.com/v2", resource.JSON))
+
+	data, err := os.ReadFile(dictionary)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"jsonMapping":{"items":"data.posts"`)
+}
+
+func TestInvalidJSONMapping(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name: "missing required path",
+			content: `[{"source": "custom", "format": "JSON", "link": "http://custom.com/api",
+				"jsonMapping": {"items": "data.posts", "title": "headline"}}]`,
+		},
+		{
+			name: "selectors for non HTML format",
+			content: `[{"source": "custom", "format": "RSS", "link": "http://custom.com/rss",
+				"htmlSelectors": {"item": "article"}}]`,
+		},
+		{
+			name: "mapping for non JSON format",
+			content: `[{"source": "custom", "format": "RSS", "link": "http://custom.com/rss",
+				"jsonMapping": {"title": "headline", "description": "teaser", "date": "published"}}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			dictionary := filepath.Join(dir, "feeds.json")
+			assert.NoError(t, os.WriteFile(dictionary, []byte(tt.content), 0644))
+
+			_, err := manager.New(dir, dictionary)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestRegisterConfiguredSource(t *testing.T) {
+	selectors := &parser.HTMLSelectors{
+		Item:  "article",
+		Title: parser.HTMLField{Selector: "h2"},
+		Date:  parser.HTMLField{Selector: "time", Attribute: "datetime"},
+	}
+
+	tests := []struct {
+		name        string
+		format      resource.Format
+		config      parser.Config
+		expectError bool
+	}{
+		{"html selectors", resource.HTML, parser.Config{HTMLSelectors: selectors}, false},
+		{"rss timezone", resource.RSS, parser.Config{Timezone: "America/New_York"}, false},
+		{"unknown timezone", resource.RSS, parser.Config{Timezone: "Mars/Olympus_Mons"}, true},
+		{"html selectors for json", resource.JSON, parser.Config{HTMLSelectors: selectors}, true},
+		{"incomplete html selectors", resource.HTML, parser.Config{HTMLSelectors: &parser.HTMLSelectors{}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			dictionary := filepath.Join(dir, "feeds.json")
+
+			rm, err := manager.New(dir, dictionary)
+			assert.NoError(t, err)
+
+			err = rm.RegisterConfiguredSource("custom", "http://custom.com", tt.format, tt.config)
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.False(t, rm.IsSourceSupported("custom"))
+				return
+			}
+			assert.NoError(t, err)
+
+			reloaded, err := manager.New(dir, dictionary)
+			assert.NoError(t, err)
+
+			factory := &recordingFactory{}
+			assert.NoError(t, reloaded.RegisterParsers(factory))
+			assert.Equal(t, []resource.Source{"custom"}, factory.sources)
+		})
+	}
+}
+
+func TestParserFactory(t *testing.T) {
+	dir := t.TempDir()
+	rm, err := manager.New(dir, filepath.Join(dir, "feeds.json"))
+	assert.NoError(t, err)
+
+	content := []byte(`{"posts": [{"headline": "Headline", "name": "Name", "teaser": "Teaser",
+		"published": "2024-05-01T10:00:00Z"}]}`)
+	res, err := resource.New("custom", resource.JSON, resource.Content(content))
+	assert.NoError(t, err)
+
+	titleOf := func(factory aggregator.Factory) string {
+		p, err := factory.GetParser(resource.JSON, "custom")
+		assert.NoError(t, err)
+		articles, err := p.Parse(*res)
+		assert.NoError(t, err)
+		assert.Len(t, articles, 1)
+		return string(articles[0].Title())
+	}
+
+	mapping := func(title string) parser.Config {
+		return parser.Config{JSONMapping: &parser.JSONMapping{
+			Items: "posts", Title: title, Description: "teaser", Date: "published",
+		}}
+	}
+
+	fallback := aggregator.NewParserFactory()
+	factory := rm.ParserFactory(fallback)
+
+	_, err = factory.GetParser(resource.JSON, "custom")
+	assert.Error(t, err, "the parser is served before the source is registered")
+
+	assert.NoError(t, rm.RegisterConfiguredSource("custom", "http://custom.com", resource.JSON, mapping("headline")))
+	assert.Equal(t, "Headline", titleOf(factory))
+
+	assert.NoError(t, rm.DeleteSource("custom"))
+	_, err = factory.GetParser(resource.JSON, "custom")
+	assert.Error(t, err, "the parser of the deleted source is served")
+
+	assert.NoError(t, rm.RegisterConfiguredSource("custom", "http://custom.com", resource.JSON, mapping("name")))
+	assert.Equal(t, "Name", titleOf(factory))
+
+	_, err = fallback.GetParser(resource.JSON, "custom")
+	assert.Error(t, err, "the parsers of the sources are added to the fallback factory")
+}
+
+func TestUpdateResource_JSON(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items": []}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	rm, err := manager.New(dir, filepath.Join(dir, "feeds.json"))
+	assert.NoError(t, err)
+
+	assert.NoError(t, rm.RegisterSource("json-feed", server.URL, resource.JSONFEED))
+	assert.NoError(t, rm.UpdateResource("json-feed"))
+
+	contents, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+
+	var stored []string
+	for _, entry := range contents {
+		if strings.HasPrefix(entry.Name(), "json-feed_") {
+			stored = append(stored, entry.Name())
+		}
+	}
+	assert.Equal(t, 1, len(stored))
+	assert.True(t, strings.HasSuffix(stored[0], ".json"))
+}
+
+type recordingFactory struct {
+	sources []resource.Source
+}
+
+func (f *recordingFactory) AddNewParser(_ resource.Format, publisher resource.Source, _ aggregator.Parser) {
+	f.sources = append(f.sources, publisher)
+}
+
+func (f *recordingFactory) GetParser(_ resource.Format, _ resource.Source) (aggregator.Parser, error) {
+	return nil, nil
+}
+
+func TestImport(t *testing.T) {
+	dir := t.TempDir()
+	feeds := `[{"source":"bbc-world","format":"RSS","link":"http://bbc.com"},` +
+		`{"source":"bbc","format":"RSS","link":"http://bbc.co.uk"},` +
+		`{"source":"broken","format":"RSS","link":"http://broken.com"}]`
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "feeds.json"), []byte(feeds), 0644))
+
+	content, err := os.ReadFile("../aggregator/parser/testdata/rss/test.xml")
+	assert.NoError(t, err)
+	files := storage.New(filepath.Join(dir, "resources"))
+	assert.NoError(t, files.UpdateXMLSource("bbc-world", content))
+	assert.NoError(t, files.UpdateXMLSource("broken", []byte("not a feed")))
+	assert.NoError(t, files.UpdateXMLSource("unregistered", content))
+
+	db, err := storage.NewBolt(filepath.Join(dir, "articles.db"))
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, db.Close())
+	}()
+
+	rm, err := manager.NewWithStorage(db, filepath.Join(dir, "feeds.json"))
+	assert.NoError(t, err)
+
+	results, err := rm.Import(files)
+	var importErr *manager.ImportError
+	assert.ErrorAs(t, err, &importErr, "A source failing to import should be reported")
+	assert.Len(t, importErr.Failed(), 1)
+	assert.Len(t, results, 3)
+	for _, result := range results {
+		switch result.Source {
+		case "bbc":
+			assert.True(t, result.Skipped, "A source without stored contents should be skipped")
+		case "bbc-world":
+			assert.Equal(t, 1, result.Imported, "The other sources should be imported past the failed one")
+			assert.NoError(t, result.Err)
+		case "broken":
+			assert.Equal(t, 1, result.Failed)
+			assert.Error(t, result.Err)
+		}
+	}
+	assert.Equal(t, "bbc-world,", rm.AvailableSources())
+
+	// Importing again replaces the stored articles, so that the stored ones match the parsed ones below.
+	results, _ = rm.Import(files)
+	assert.Len(t, results, 3)
+
+	resources, err := rm.GetSelectedResources([]string{"bbc-world"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(resources))
+	assert.Equal(t, resource.Format(resource.STORED), resources[0].Format())
+
+	a, err := aggregator.New(aggregator.NewParserFactory())
+	assert.NoError(t, err)
+	stored, err := a.Aggregate(resources[0])
+	assert.NoError(t, err)
+
+	original, err := resource.New("bbc-world", resource.RSS, resource.Content(content))
+	assert.NoError(t, err)
+	parsed, err := a.Aggregate(*original)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, parsed, stored)
+
+	_, err = rm.GetSelectedResources([]string{"bbc"})
+	assert.Error(t, err, "A source without stored articles should not be read")
+}
+
+func TestUpdateResource_ArticleStorage(t *testing.T) {
+	content, err := os.ReadFile("../aggregator/parser/testdata/rss/test.xml")
+	assert.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	db, err := storage.NewBolt(filepath.Join(dir, "articles.db"))
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, db.Close())
+	}()
+
+	rm, err := manager.NewWithStorage(db, filepath.Join(dir, "feeds.json"))
+	assert.NoError(t, err)
+
+	assert.NoError(t, rm.RegisterSource("rss", server.URL, resource.RSS))
+	assert.NoError(t, rm.UpdateResource("rss"))
+	// The articles fetched again replace the stored ones.
+	assert.NoError(t, rm.UpdateResource("rss"))
+
+	articles, err := db.ReadArticles("rss", time.Time{}, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(articles))
+	assert.Equal(t, "Test Title", articles[0].TitleStr())
+}
+
+func TestSetCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items": []}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	rm, err := manager.New(dir, filepath.Join(dir, "feeds.json"))
+	assert.NoError(t, err)
+
+	c := cache.New()
+	rm.SetCache(c)
+
+	put := func(source resource.Source) {
+		res, err := resource.New(source, resource.JSONFEED, "content")
+		assert.NoError(t, err)
+		c.Put(*res, true, nil, nil)
+	}
+
+	tests := []struct {
+		name   string
+		change func() error
+	}{
+		{"register", func() error { return rm.RegisterSource("json-feed", server.URL, resource.JSONFEED) }},
+		{"store snapshot", func() error { return rm.UpdateResource("json-feed") }},
+		{"update", func() error { return rm.UpdateSource("json-feed", server.URL, resource.JSONFEED) }},
+		{"delete", func() error { return rm.DeleteSource("json-feed") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			put("json-feed")
+			put("other")
+
+			assert.NoError(t, tt.change())
+			assert.Equal(t, 1, c.Stats().Entries, "Only the cached articles of the changed source should be removed")
+
+			c.Invalidate("other")
+		})
+	}
+}
+
+func TestGetSelectedResources_Cached(t *testing.T) {
+	content, err := os.ReadFile("../aggregator/parser/testdata/rss/test.xml")
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	dictionary := filepath.Join(dir, "feeds.json")
+	feeds := `[{"source": "bbc", "format": "RSS", "link": "http://bbc.com/rss"}]`
+	assert.NoError(t, os.WriteFile(dictionary, []byte(feeds), 0644))
+	snapshot := filepath.Join(dir, "bbc_20240101120000.xml")
+	assert.NoError(t, os.WriteFile(snapshot, content, 0644))
+
+	rm, err := manager.New(dir, dictionary)
+	assert.NoError(t, err)
+	c := cache.New()
+	rm.SetCache(c)
+
+	a, err := aggregator.New(aggregator.NewParserFactory())
+	assert.NoError(t, err)
+	a.SetCache(c)
+
+	resources, err := rm.GetSelectedResources([]string{"bbc"})
+	assert.NoError(t, err)
+	assert.Len(t, resources, 1)
+	assert.Empty(t, resources[0].Content(), "The snapshot should be read only when it is parsed")
+
+	parsed, err := a.Aggregate(resources[0])
+	assert.NoError(t, err)
+	assert.NotEmpty(t, parsed)
+
+	resources, err = rm.GetSelectedResources([]string{"bbc"})
+	assert.NoError(t, err)
+	assert.Len(t, resources, 1)
+
+	// The snapshot is not read again, so the cached articles are returned even if it is removed meanwhile.
+	assert.NoError(t, os.Remove(snapshot))
+	cached, err := a.Aggregate(resources[0])
+	assert.NoError(t, err)
+	assert.Equal(t, parsed, cached)
+	assert.Equal(t, uint64(1), c.Stats().Hits)
+
+	// A changed snapshot is parsed again.
+	assert.NoError(t, os.WriteFile(snapshot, append(content, '\n'), 0644))
+	resources, err = rm.GetSelectedResources([]string{"bbc"})
+	assert.NoError(t, err)
+	_, err = a.Aggregate(resources[0])
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), c.Stats().Hits)
+}
+
+func TestPruneSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"bbc_20240518100000.xml", "bbc_20240519100000.xml", "abc_20240517100000.xml"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("content"), 0644))
+	}
+
+	rm, err := manager.New(dir, filepath.Join(dir, "feeds.json"))
+	assert.NoError(t, err)
+
+	pruned, err := rm.PruneSnapshots(storage.RetentionPolicy{})
+	assert.NoError(t, err)
+	assert.Empty(t, pruned, "An empty policy should not prune snapshots")
+
+	pruned, err = rm.PruneSnapshots(storage.RetentionPolicy{MaxSnapshots: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(pruned))
+	assert.Equal(t, "bbc_20240518100000.xml", pruned[0].Name)
+	assert.NoFileExists(t, filepath.Join(dir, "bbc_20240518100000.xml"))
+	assert.FileExists(t, filepath.Join(dir, "bbc_20240519100000.xml"))
+
+	db, err := storage.NewBolt(filepath.Join(dir, "articles.db"))
+	assert.NoError(t, err)
+	defer func() {
+		assert.NoError(t, db.Close())
+	}()
+
+	rm, err = manager.NewWithStorage(db, filepath.Join(dir, "feeds.json"))
+	assert.NoError(t, err)
+
+	pruned, err = rm.PruneSnapshots(storage.RetentionPolicy{MaxSnapshots: 1})
+	assert.NoError(t, err)
+	assert.Empty(t, pruned, "A storage without snapshots should not be pruned")
+}
+
+func TestSaveFeeds_Backup(t *testing.T) {
+	dir := t.TempDir()
+	dictionary := filepath.Join(dir, "feeds.json")
+
+	rm, err := manager.New(dir, dictionary)
+	assert.NoError(t, err)
+	assert.NoError(t, rm.RegisterSource("bbc", "http://bbc.com", resource.RSS))
+	assert.NoError(t, rm.RegisterSource("abc", "http://abc.com", resource.RSS))
+
+	backup, err := os.ReadFile(dictionary + manager.BackupSuffix)
+	assert.NoError(t, err)
+	assert.Contains(t, string(backup), `"bbc"`)
+	assert.NotContains(t, string(backup), `"abc"`, "The backup should keep the previous dictionary")
+
+	files, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(files), "No temporary files should be left")
+}
+
+func TestNew_RecoverFromBackup(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{"truncated dictionary", `[{"source":"bbc","format":"RSS","li`},
+		{"empty dictionary", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			dictionary := filepath.Join(dir, "feeds.json")
+
+			rm, err := manager.New(dir, dictionary)
+			assert.NoError(t, err)
+			assert.NoError(t, rm.RegisterSource("bbc", "http://bbc.com", resource.RSS))
+			assert.NoError(t, rm.RegisterSource("abc", "http://abc.com", resource.RSS))
+
+			assert.NoError(t, os.WriteFile(dictionary, []byte(tt.content), 0644))
+
+			rm, err = manager.New(dir, dictionary)
+			assert.NoError(t, err)
+			assert.True(t, rm.IsSourceSupported("bbc"))
+			assert.False(t, rm.IsSourceSupported("abc"), "Only the last good dictionary should be restored")
+
+			_, err = manager.New(dir, dictionary)
+			assert.NoError(t, err, "The restored dictionary should be loaded again")
+		})
+	}
+
+	t.Run("without backup", func(t *testing.T) {
+		dir := t.TempDir()
+		dictionary := filepath.Join(dir, "feeds.json")
+		assert.NoError(t, os.WriteFile(dictionary, []byte(`[{"source":`), 0644))
+
+		_, err := manager.New(dir, dictionary)
+		assert.Error(t, err)
+	})
+}
+
+func TestUpdateAllSources(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			highest := atomic.LoadInt32(&maxInFlight)
+			if current <= highest || atomic.CompareAndSwapInt32(&maxInFlight, highest, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		if strings.HasPrefix(r.URL.Path, "/broken") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write([]byte(`{"items": []}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	rm, err := manager.New(dir, filepath.Join(dir, "feeds.json"))
+	assert.NoError(t, err)
+	rm.SetConcurrency(2)
+	rm.SetConcurrency(0)
+	rm.SetFetchClient(newTestClient())
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, rm.RegisterSource(resource.Source(fmt.Sprintf("source-%d", i)),
+			fmt.Sprintf("%s/source-%d", server.URL, i), resource.JSONFEED))
+	}
+	assert.NoError(t, rm.RegisterSource("broken-a", server.URL+"/broken-a", resource.JSONFEED))
+	assert.NoError(t, rm.RegisterSource("broken-b", server.URL+"/broken-b", resource.JSONFEED))
+
+	err = rm.UpdateAllSources()
+
+	var updateErr *manager.UpdateError
+	assert.True(t, errors.As(err, &updateErr))
+	assert.Equal(t, 7, len(updateErr.Results))
+	failed := updateErr.Failed()
+	assert.Equal(t, 2, len(failed))
+	assert.Equal(t, resource.Source("broken-a"), failed[0].Source)
+	assert.Equal(t, resource.Source("broken-b"), failed[1].Source)
+	assert.Contains(t, err.Error(), "2 of 7 sources failed to update")
+	assert.Contains(t, err.Error(), "status code 500")
+
+	assert.Equal(t, "source-0,source-1,source-2,source-3,source-4,", rm.AvailableSources(),
+		"A failing source should not stop the others")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxInFlight), "The sources should be fetched up to the limit at once")
+
+	assert.NoError(t, rm.DeleteSource("broken-a"))
+	assert.NoError(t, rm.DeleteSource("broken-b"))
+	assert.NoError(t, rm.UpdateAllSources())
+}
+
+func TestResourceManager_ConcurrentUse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items": []}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	rm, err := manager.New(dir, filepath.Join(dir, "feeds.json"))
+	assert.NoError(t, err)
+	rm.SetCache(cache.New())
+	assert.NoError(t, rm.RegisterSource("json-feed", server.URL, resource.JSONFEED))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		source := resource.Source(fmt.Sprintf("source-%d", i))
+		wg.Add(3)
+
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, rm.RegisterSource(source, server.URL, resource.JSONFEED))
+			assert.NoError(t, rm.UpdateSource(source, server.URL, resource.JSONFEED))
+			assert.NoError(t, rm.DeleteSource(source))
+		}()
+		go func() {
+			defer wg.Done()
+			_ = rm.UpdateAllSources()
+		}()
+		go func() {
+			defer wg.Done()
+			_ = rm.AvailableFeeds()
+			_ = rm.IsSourceSupported(source)
+			_, _ = rm.GetAllResources()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, "json-feed", rm.AvailableFeeds())
+}
+
+func TestUpdateResource_Conditional(t *testing.T) {
+	const etag = `"v1"`
+	const lastModified = "Sun, 19 May 2024 10:00:00 GMT"
+
+	var notModified int32
+	content := `{"items": []}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/etag":
+			if r.Header.Get("If-None-Match") == etag {
+				atomic.AddInt32(&notModified, 1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", etag)
+		case "/last-modified":
+			if r.Header.Get("If-Modified-Since") == lastModified {
+				atomic.AddInt32(&notModified, 1)
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("Last-Modified", lastModified)
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	for _, path := range []string{"/etag", "/last-modified", "/plain"} {
+		t.Run(path, func(t *testing.T) {
+			atomic.StoreInt32(&notModified, 0)
+			dir := t.TempDir()
+			dictionary := filepath.Join(dir, "feeds.json")
+			rm, err := manager.New(filepath.Join(dir, "resources"), dictionary)
+			assert.NoError(t, err)
+			assert.NoError(t, rm.RegisterSource("json-feed", server.URL+path, resource.JSONFEED))
+
+			assert.NoError(t, rm.UpdateResource("json-feed"))
+			first, exists := rm.FetchStatus("json-feed")
+			assert.True(t, exists)
+			assert.False(t, first.LastChanged.IsZero())
+			assert.Equal(t, first.LastChecked, first.LastChanged)
+
+			// The statuses are kept between the runs.
+			rm, err = manager.New(filepath.Join(dir, "resources"), dictionary)
+			assert.NoError(t, err)
+			assert.NoError(t, rm.UpdateResource("json-feed"))
+
+			second, _ := rm.FetchStatus("json-feed")
+			assert.True(t, second.LastChecked.After(first.LastChecked))
+			assert.True(t, second.LastChanged.Equal(first.LastChanged), "An unchanged source should not be stored again")
+
+			files, err := os.ReadDir(filepath.Join(dir, "resources"))
+			assert.NoError(t, err)
+			assert.Equal(t, 1, len(files))
+
+			if path == "/plain" {
+				assert.Equal(t, int32(0), atomic.LoadInt32(&notModified))
+			} else {
+				assert.Equal(t, int32(1), atomic.LoadInt32(&notModified))
+			}
+
+			assert.NoError(t, rm.UpdateSource("json-feed", server.URL+path, resource.JSONFEED))
+			_, exists = rm.FetchStatus("json-feed")
+			assert.False(t, exists, "The status should be reset once the source is changed")
+		})
+	}
+}
+
+// newTestClient creates a fetch client retrying without noticeable delays.
+func newTestClient() *fetcher.Client {
+	c := fetcher.New()
+	c.SetBaseDelay(time.Millisecond)
+	c.SetMaxDelay(time.Millisecond)
+	return c
+}
+
+func TestSourcesHealth(t *testing.T) {
+	var attempts int32
+	failing := int32(1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		_, _ = w.Write([]byte(`{"items": []}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	rm, err := manager.New(dir, filepath.Join(dir, "feeds.json"))
+	assert.NoError(t, err)
+	rm.SetFetchClient(newTestClient())
+
+	tracker := fetcher.NewHealthTracker()
+	tracker.SetFailureThreshold(2)
+	tracker.SetBackoff(time.Hour, time.Hour)
+	rm.SetHealthTracker(tracker)
+
+	assert.NoError(t, rm.RegisterSource("json-feed", server.URL, resource.JSONFEED))
+
+	for i := 0; i < 2; i++ {
+		assert.Error(t, rm.UpdateAllSources())
+	}
+	assert.Equal(t, int32(2*(fetcher.DefaultMaxRetries+1)), atomic.LoadInt32(&attempts),
+		"Every update should retry the server errors")
+
+	health := rm.SourcesHealth()["json-feed"]
+	assert.Equal(t, 2, health.ConsecutiveFailures)
+	assert.Contains(t, health.LastError, "status code 502")
+	assert.False(t, health.BackoffUntil.IsZero())
+
+	atomic.StoreInt32(&failing, 0)
+	assert.NoError(t, rm.UpdateAllSources(), "A backed off source should be skipped")
+	assert.Equal(t, int32(2*(fetcher.DefaultMaxRetries+1)), atomic.LoadInt32(&attempts))
+
+	assert.NoError(t, rm.UpdateResource("json-feed"), "A source should be updated on demand despite the backoff")
+	health = rm.SourcesHealth()["json-feed"]
+	assert.True(t, health.Healthy())
+	assert.False(t, health.LastSuccess.IsZero())
+
+	assert.NoError(t, rm.DeleteSource("json-feed"))
+	assert.Empty(t, rm.SourcesHealth())
+}
+
+func TestSourceSchedules(t *testing.T) {
+	dir := t.TempDir()
+	dictionary := filepath.Join(dir, "feeds.json")
+	rm, err := manager.New(dir, dictionary)
+	assert.NoError(t, err)
+
+	assert.NoError(t, rm.RegisterSource("breaking", "http://breaking.com/rss", resource.RSS))
+	assert.NoError(t, rm.RegisterSource("weekly", "http://weekly.com/rss", resource.RSS))
+
+	assert.NoError(t, rm.SetSourceSchedule("breaking", "5m"))
+	assert.NoError(t, rm.SetSourceSchedule("weekly", "0 6 * * *"))
+	assert.Error(t, rm.SetSourceSchedule("weekly", "every day"))
+	assert.Error(t, rm.SetSourceSchedule("unknown", "5m"))
+
+	assert.NoError(t, rm.UpdateSource("weekly", "http://weekly.com/feed", resource.ATOM))
+	expected := map[resource.Source]string{"breaking": "5m", "weekly": "0 6 * * *"}
+	assert.Equal(t, expected, rm.SourceSchedules(), "The schedule should be kept on update")
+
+	reloaded, err := manager.New(dir, dictionary)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, reloaded.SourceSchedules(), "The schedules should be saved in the dictionary")
+
+	assert.NoError(t, rm.SetSourceSchedule("breaking", ""))
+	assert.Equal(t, "", rm.SourceSchedules()["breaking"], "An empty schedule should restore the default one")
+
+	invalid := `[{"source": "custom", "format": "RSS", "link": "http://custom.com/rss", "schedule": "sometimes"}]`
+	assert.NoError(t, os.WriteFile(dictionary, []byte(invalid), 0644))
+	assert.NoError(t, os.Remove(dictionary+manager.BackupSuffix))
+	_, err = manager.New(dir, dictionary)
+	assert.Error(t, err)
+}
+
+func TestScheduledSources(t *testing.T) {
+	dir := t.TempDir()
+	dictionary := filepath.Join(dir, "feeds.json")
+	rm, err := manager.New(dir, dictionary)
+	assert.NoError(t, err)
+
+	assert.Error(t, rm.RegisterScheduledSource("breaking", "http://breaking.com/rss", resource.RSS, parser.Config{},
+		"every minute"))
+	assert.False(t, rm.IsSourceSupported("breaking"), "A source with an invalid schedule should not be registered")
+
+	// The dictionary cannot be written while a directory is in its place.
+	assert.NoError(t, os.Remove(dictionary))
+	assert.NoError(t, os.Mkdir(dictionary, 0755))
+	assert.Error(t, rm.RegisterScheduledSource("breaking", "http://breaking.com/rss", resource.RSS, parser.Config{},
+		"5m"))
+	assert.False(t, rm.IsSourceSupported("breaking"), "A source failed to be saved should not be registered")
+	assert.NoError(t, os.Remove(dictionary))
+
+	assert.NoError(t, rm.RegisterScheduledSource("breaking", "http://breaking.com/rss", resource.RSS, parser.Config{},
+		"5m"))
+
+	assert.NoError(t, os.Rename(dictionary, dictionary+".saved"))
+	assert.NoError(t, os.Mkdir(dictionary, 0755))
+	assert.Error(t, rm.UpdateScheduledSource("breaking", "http://breaking.com/atom", resource.ATOM, "10m"))
+	assert.NoError(t, os.Remove(dictionary))
+	assert.NoError(t, os.Rename(dictionary+".saved", dictionary))
+	assert.Equal(t, "5m", rm.SourceSchedules()["breaking"], "A source failed to be saved should not be updated")
+
+	assert.NoError(t, os.Rename(dictionary, dictionary+".saved"))
+	assert.NoError(t, os.Mkdir(dictionary, 0755))
+	assert.Error(t, rm.SetSourceSchedule("breaking", "10m"))
+	assert.Error(t, rm.DeleteSource("breaking"))
+	assert.NoError(t, os.Remove(dictionary))
+	assert.NoError(t, os.Rename(dictionary+".saved", dictionary))
+	assert.Equal(t, "5m", rm.SourceSchedules()["breaking"], "A schedule failed to be saved should not be set")
+	assert.True(t, rm.IsSourceSupported("breaking"), "A source failed to be deleted should be kept")
+
+	assert.NoError(t, rm.UpdateScheduledSource("breaking", "http://breaking.com/atom", resource.ATOM, "10m"))
+	assert.NoError(t, rm.UpdateScheduledSource("breaking", "http://breaking.com/rss", resource.RSS, ""))
+
+	reloaded, err := manager.New(dir, dictionary)
+	assert.NoError(t, err)
+	assert.Equal(t, map[resource.Source]string{"breaking": "10m"}, reloaded.SourceSchedules(),
+		"The schedule should be saved along with the source and kept if none is given")
+}
+
+func TestUpdateSources(t *testing.T) {
+	var fetched sync.Map
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched.Store(r.URL.Path, true)
+		_, _ = w.Write([]byte(`{"items": []}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	rm, err := manager.New(dir, filepath.Join(dir, "feeds.json"))
+	assert.NoError(t, err)
+	rm.SetFetchClient(newTestClient())
+
+	assert.NoError(t, rm.RegisterSource("due", server.URL+"/due", resource.JSONFEED))
+	assert.NoError(t, rm.RegisterSource("not-due", server.URL+"/not-due", resource.JSONFEED))
+
+	assert.NoError(t, rm.UpdateSources([]resource.Source{"due"}))
+
+	_, dueFetched := fetched.Load("/due")
+	_, notDueFetched := fetched.Load("/not-due")
+	assert.True(t, dueFetched)
+	assert.False(t, notDueFetched, "Only the given sources should be updated")
+
+	err = rm.UpdateSources([]resource.Source{"unknown"})
+	var updateErr *manager.UpdateError
+	assert.True(t, errors.As(err, &updateErr))
+	assert.Equal(t, resource.Source("unknown"), updateErr.Failed()[0].Source)
+}
+
+func TestRefreshResource(t *testing.T) {
+	content, err := os.ReadFile("../aggregator/parser/testdata/jsonfeed/test.json")
+	assert.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	rm, err := manager.New(dir, filepath.Join(dir, "feeds.json"))
+	assert.NoError(t, err)
+	rm.SetFetchClient(newTestClient())
+
+	assert.NoError(t, rm.RegisterSource("json-feed", server.URL, resource.JSONFEED))
+	assert.Equal(t, []resource.Source{"json-feed"}, rm.RegisteredSources())
+
+	result, err := rm.RefreshResource("json-feed")
+	assert.NoError(t, err)
+	assert.Equal(t, manager.RefreshResult{BytesFetched: int64(len(content)), Changed: true, ArticlesParsed: 2}, result)
+
+	result, err = rm.RefreshResource("json-feed")
+	assert.NoError(t, err)
+	assert.Equal(t, manager.RefreshResult{}, result, "A source that is not modified should not be fetched again")
+
+	_, err = rm.RefreshResource("unknown")
+	assert.Error(t, err)
+}
+
+func TestProbeSource(t *testing.T) {
+	testdata := "../aggregator/parser/testdata/"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case "/rss", "/rss-as-atom":
+			w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+		case "/atom":
+			w.Header().Set("Content-Type", "text/xml")
+		case "/html":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte("<!DOCTYPE html><html><body>No feed here</body></html>"))
+			return
+		}
+
+		files := map[string]string{
+			"/rss":         "rss/test.xml",
+			"/rss-as-atom": "rss/test.xml",
+			"/atom":        "atom/test.xml",
+			"/jsonfeed":    "jsonfeed/test.json",
+			"/invalid":     "rss/invalid_data_test.xml",
+		}
+		content, err := os.ReadFile(testdata + files[r.URL.Path])
+		assert.NoError(t, err)
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	rm, err := manager.New(dir, filepath.Join(dir, "feeds.json"))
+	assert.NoError(t, err)
+	rm.SetFetchClient(newTestClient())
+
+	tests := []struct {
+		path             string
+		format           resource.Format
+		detected         resource.Format
+		wantErr          string
+		expectedWarnings int
+	}{
+		{"/rss", resource.RSS, resource.RSS, "", 0},
+		{"/atom", resource.ATOM, resource.ATOM, "", 0},
+		{"/jsonfeed", resource.JSONFEED, resource.JSONFEED, "", 0},
+		{"/rss-as-atom", resource.ATOM, resource.RSS, "error parsing resource content as ATOM", 1},
+		{"/html", resource.RSS, resource.HTML, "no articles parsed", 1},
+		{"/invalid", resource.RSS, resource.RSS, "no articles parsed", 1},
+		{"/missing", resource.RSS, resource.UNKNOWN, "status code 404", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			report, err := rm.ProbeSource("probe", server.URL+tt.path, tt.format, parser.Config{})
+
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.Greater(t, report.ArticlesParsed, 0)
+				assert.Greater(t, report.BytesFetched, int64(0))
+				assert.Equal(t, http.StatusOK, report.StatusCode)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+				assert.Equal(t, 0, report.ArticlesParsed)
+			}
+			assert.Equal(t, tt.detected, report.DetectedFormat)
+			assert.Len(t, report.Warnings, tt.expectedWarnings, "%v", report.Warnings)
+		})
+	}
+
+	assert.False(t, rm.IsSourceSupported("probe"), "Probing should not register the source")
+
+	_, err = rm.ProbeSource("bad name", server.URL+"/rss", resource.RSS, parser.Config{})
+	assert.Error(t, err)
+}
+
+func TestProbeSourceUpdate(t *testing.T) {
+	content, err := os.ReadFile("../aggregator/parser/testdata/jsonmapping/test.json")
+	assert.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	rm, err := manager.New(dir, filepath.Join(dir, "feeds.json"))
+	assert.NoError(t, err)
+	rm.SetFetchClient(newTestClient())
+
+	config := parser.Config{JSONMapping: &parser.JSONMapping{
+		Items: "data.posts", Title: "headline", Description: "teaser", Date: "meta.published",
+	}}
+	assert.NoError(t, rm.RegisterConfiguredSource("mapped", "https://example.com/api", resource.JSON, config))
+
+	report, err := rm.ProbeSourceUpdate("mapped", server.URL+"/posts", resource.JSON)
+	assert.NoError(t, err, "The mapping of the source should be kept")
+	assert.Equal(t, 2, report.ArticlesParsed)
+
+	_, err = rm.ProbeSourceUpdate("mapped", server.URL+"/posts", resource.RSS)
+	assert.Error(t, err)
+
+	_, err = rm.ProbeSourceUpdate("unknown", server.URL+"/posts", resource.JSON)
+	assert.ErrorIs(t, err, manager.ErrSourceNotFound)
+
+	_, err = rm.ProbeSourceUpdate("mapped", "not a url", resource.JSON)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, manager.ErrSourceNotFound)
+}
+
+func TestSourceValidation(t *testing.T) {
+	dir := t.TempDir()
+	rm, err := manager.New(dir, filepath.Join(dir, "feeds.json"))
+	assert.NoError(t, err)
+
+	assert.NoError(t, rm.RegisterSource("abc-news", "https://abcnews.go.com/rss", resource.RSS))
+	assert.ErrorIs(t, rm.RegisterSource("abc-news", "https://abcnews.go.com/feed", resource.ATOM),
+		manager.ErrSourceExists)
+
+	assert.ErrorIs(t, rm.UpdateSource("unknown", "https://unknown.com/rss", resource.RSS), manager.ErrSourceNotFound)
+	assert.ErrorIs(t, rm.DeleteSource("unknown"), manager.ErrSourceNotFound)
+	assert.ErrorIs(t, rm.SetSourceSchedule("unknown", "5m"), manager.ErrSourceNotFound)
+
+	invalid := [][2]string{
+		{"", "https://abcnews.go.com/rss"},
+		{"../etc", "https://abcnews.go.com/rss"},
+		{"abc news", "https://abcnews.go.com/rss"},
+		{"abc-feed", "abcnews.go.com/rss"},
+		{"abc-feed", "ftp://abcnews.go.com/rss"},
+		{"abc-feed", "https:///rss"},
+	}
+	for _, values := range invalid {
+		assert.Error(t, rm.RegisterSource(resource.Source(values[0]), values[1], resource.RSS), "%v", values)
+	}
+	assert.Error(t, rm.UpdateSource("abc-news", "not a url", resource.RSS))
+}
+
+func TestDiscoverFeeds(t *testing.T) {
+	testdata := "../aggregator/parser/testdata/"
+	page := `<!DOCTYPE html><html><head>
+		<base href="/blog/">
+		<link rel="stylesheet" type="text/css" href="/style.css">
+		<link rel="alternate" type="application/rss+xml" title="Site News" href="/news/rss">
+		<link rel="Alternate" type="application/atom+xml; charset=utf-8" href="atom">
+		<link rel="alternate" type="application/rss+xml" href="/broken">
+		<link rel="alternate" type="application/json" href="/api/posts">
+		<link rel="alternate" type="application/json" href="/api/feed">
+		</head><body>Home</body></html>`
+
+	var feedRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		files := map[string]string{
+			"/news/rss":  "rss/test.xml",
+			"/blog/atom": "atom/test.xml",
+			"/feed.json": "jsonfeed/test.json",
+			"/api/feed":  "jsonfeed/test.json",
+		}
+
+		switch r.URL.Path {
+		case "/", "/rss.xml":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(page))
+		case "/api/posts":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"items": [{"id": "1", "title": "Not a feed", "summary": "An API response",
+				"url": "https://example.com/1", "date_published": "2024-06-01T18:30:02Z"}]}`))
+		default:
+			if r.URL.Path == "/feed.json" {
+				feedRequests.Add(1)
+			}
+
+			file, exists := files[r.URL.Path]
+			if !exists {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			content, err := os.ReadFile(testdata + file)
+			assert.NoError(t, err)
+			_, _ = w.Write(content)
+		}
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	rm, err := manager.New(dir, filepath.Join(dir, "feeds.json"))
+	assert.NoError(t, err)
+	rm.SetFetchClient(newTestClient())
+
+	t.Run("website", func(t *testing.T) {
+		feeds, err := rm.DiscoverFeeds(server.URL + "/")
+		assert.NoError(t, err)
+
+		expected := []manager.FeedCandidate{
+			{URL: server.URL + "/blog/atom", Format: resource.ATOM, Title: "Test Feed", ArticlesParsed: 2,
+				Advertised: true},
+			{URL: server.URL + "/api/feed", Format: resource.JSONFEED, Title: "Test Feed", ArticlesParsed: 2,
+				Advertised: true},
+			{URL: server.URL + "/news/rss", Format: resource.RSS, Title: "Site News", ArticlesParsed: 1, Advertised: true},
+			{URL: server.URL + "/feed.json", Format: resource.JSONFEED, Title: "Test Feed", ArticlesParsed: 2},
+		}
+		assert.Equal(t, expected, feeds)
+	})
+
+	t.Run("feed", func(t *testing.T) {
+		feedRequests.Store(0)
+
+		feeds, err := rm.DiscoverFeeds(server.URL + "/feed.json")
+		assert.NoError(t, err)
+		assert.Equal(t, []manager.FeedCandidate{
+			{URL: server.URL + "/feed.json", Format: resource.JSONFEED, Title: "Test Feed", ArticlesParsed: 2},
+		}, feeds)
+		assert.Equal(t, int32(1), feedRequests.Load(), "The feed should be fetched once")
+	})
+
+	t.Run("missing page", func(t *testing.T) {
+		_, err := rm.DiscoverFeeds(server.URL + "/missing")
+		assert.ErrorContains(t, err, "status code 404")
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		_, err := rm.DiscoverFeeds("www.example.com")
+		assert.Error(t, err)
+	})
+
+	assert.False(t, rm.IsSourceSupported("discovery"), "Discovery should not register the sources")
+}
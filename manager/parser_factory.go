@@ -0,0 +1,97 @@
+package manager
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"news-aggregator/aggregator"
+	"news-aggregator/aggregator/model/resource"
+)
+
+// configuredParser is the parser of a source together with the key of the format and config it is built from.
+type configuredParser struct {
+	key    string
+	parser aggregator.Parser
+}
+
+// parserFactory is an aggregator.Factory serving the parsers of the sources configured in the feeds dictionary
+// of the manager, and the parsers of the fallback factory for the other sources.
+type parserFactory struct {
+	rm       *ResourceManager
+	fallback aggregator.Factory
+}
+
+// ParserFactory returns a factory serving a parser for every source that has a parser config in the feeds
+// dictionary, and the parsers of the fallback factory for the other sources. The parsers follow the dictionary:
+// a parser is built again once its source changes the format or the config, and it is not served any longer
+// once its source is deleted, so the fallback factory is never changed by the manager.
+func (rm *ResourceManager) ParserFactory(fallback aggregator.Factory) aggregator.Factory {
+	return &parserFactory{rm: rm, fallback: fallback}
+}
+
+// AddNewParser registers the parser in the fallback factory.
+func (f *parserFactory) AddNewParser(format resource.Format, publisher resource.Source, parser aggregator.Parser) {
+	f.fallback.AddNewParser(format, publisher, parser)
+}
+
+// GetParser returns the parser of the configured source, or the parser of the fallback factory.
+func (f *parserFactory) GetParser(format resource.Format, source resource.Source) (aggregator.Parser, error) {
+	p, configured, err := f.rm.configuredParser(source, format)
+	if err != nil {
+		return nil, err
+	}
+	if configured {
+		return p, nil
+	}
+
+	return f.fallback.GetParser(format, source)
+}
+
+// configuredParser returns the parser built from the parser config of the source when the source is
+// configured for the format, and reports false otherwise. The parser is kept until the source changes.
+func (rm *ResourceManager) configuredParser(source resource.Source,
+	format resource.Format) (aggregator.Parser, bool, error) {
+
+	details, exists := rm.details(source)
+	if !exists || details.Format != format || details.Parser.IsEmpty() {
+		return nil, false, nil
+	}
+
+	key, err := parserKey(details)
+	if err != nil {
+		return nil, false, fmt.Errorf("error creating parser for source \"%s\": %v", source, err)
+	}
+
+	rm.parsersMu.Lock()
+	defer rm.parsersMu.Unlock()
+
+	if kept, ok := rm.parsers[source]; ok && kept.key == key {
+		return kept.parser, true, nil
+	}
+
+	p, err := aggregator.NewConfiguredParser(details.Format, details.Parser)
+	if err != nil {
+		return nil, false, fmt.Errorf("error creating parser for source \"%s\": %v", source, err)
+	}
+	rm.parsers[source] = configuredParser{key: key, parser: p}
+
+	return p, true, nil
+}
+
+// forgetParser drops the parser kept for the source.
+func (rm *ResourceManager) forgetParser(source resource.Source) {
+	rm.parsersMu.Lock()
+	defer rm.parsersMu.Unlock()
+
+	delete(rm.parsers, source)
+}
+
+// parserKey returns the format of the source followed by the hash of its parser config.
+func parserKey(details ResourceDetails) (string, error) {
+	config, err := json.Marshal(details.Parser)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%d:%x", details.Format, sha256.Sum256(config)), nil
+}
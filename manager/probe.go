@@ -0,0 +1,211 @@
+package manager
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"news-aggregator/aggregator/model/resource"
+	"news-aggregator/aggregator/parser"
+	"strings"
+)
+
+// MaxProbeBytes is the limit of the content read by ResourceManager.ProbeSource.
+const MaxProbeBytes = 10 << 20
+
+// ProbeReport is the report of probing a source before its registration, see ResourceManager.ProbeSource.
+type ProbeReport struct {
+	// URL is the probed link of the source.
+	URL string
+	// StatusCode is the status code of the response, zero if the link cannot be fetched.
+	StatusCode int
+	// ContentType is the Content-Type header of the response.
+	ContentType string
+	// DetectedFormat is the format sniffed from the content type and the content, UNKNOWN if it is not recognized.
+	DetectedFormat resource.Format
+	// Format is the format the content is parsed in.
+	Format resource.Format
+	// BytesFetched is the size of the fetched content.
+	BytesFetched int64
+	// ArticlesParsed is the number of the articles parsed from the content.
+	ArticlesParsed int
+	// Warnings are the items skipped by the parser and the mismatch of the formats, if any.
+	Warnings []string
+}
+
+// ProbeSource fetches the link of a source, sniffs its format and parses the content in the given format
+// with the parser config, without registering the source. An error is returned, along with the report made
+// so far, if the link cannot be fetched or the content yields no articles.
+func (rm *ResourceManager) ProbeSource(name resource.Source, link string, format resource.Format,
+	config parser.Config) (ProbeReport, error) {
+
+	if err := ValidateSource(name, link); err != nil {
+		return ProbeReport{URL: link, Format: format}, err
+	}
+	if err := config.Validate(format); err != nil {
+		return ProbeReport{URL: link, Format: format}, fmt.Errorf("invalid parser config: %v", err)
+	}
+
+	report, _, err := rm.probe(name, link, format, config)
+	return report, err
+}
+
+// ProbeSourceUpdate probes the registered source as ProbeSource does with the link and the format it is to be
+// updated with, and with the parser config it keeps, see UpdateScheduledSource.
+// ErrSourceNotFound is returned if the source is not registered.
+func (rm *ResourceManager) ProbeSourceUpdate(name resource.Source, link string, format resource.Format) (ProbeReport,
+	error) {
+
+	if err := ValidateSource(name, link); err != nil {
+		return ProbeReport{URL: link, Format: format}, err
+	}
+
+	existing, exists := rm.details(name)
+	if !exists {
+		return ProbeReport{URL: link, Format: format}, fmt.Errorf("%w: %s", ErrSourceNotFound, name)
+	}
+
+	report, _, err := rm.probe(name, link, format, existing.keptParser(format))
+	return report, err
+}
+
+// probe fetches the link and parses its content in the format, or in the sniffed one if the format is UNKNOWN,
+// returning the report along with the fetched content.
+func (rm *ResourceManager) probe(name resource.Source, link string, format resource.Format,
+	config parser.Config) (ProbeReport, []byte, error) {
+
+	report := ProbeReport{URL: link, Format: format}
+
+	body, err := rm.fetchContent(link, &report)
+	if err != nil {
+		return report, body, err
+	}
+
+	err = probeContent(name, config, &report, body)
+	return report, body, err
+}
+
+// probeContent parses the content fetched from the link of the report in the format of the report,
+// or in the sniffed one if the format is UNKNOWN, recording the results in the report.
+func probeContent(name resource.Source, config parser.Config, report *ProbeReport, body []byte) error {
+	format := report.Format
+
+	report.DetectedFormat = sniffFormat(report.ContentType, body)
+	if format == resource.UNKNOWN {
+		format = report.DetectedFormat
+		report.Format = format
+		if !isFeedFormat(format) {
+			return fmt.Errorf("resource content is not a feed")
+		}
+	} else if report.DetectedFormat != resource.UNKNOWN && report.DetectedFormat != format {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("content looks like %s, not %s",
+			resource.FormatToString(report.DetectedFormat), resource.FormatToString(format)))
+	}
+
+	details := ResourceDetails{Format: format, Link: report.URL, Parser: config}
+	articles, warnings, err := parseContent(name, details, body)
+	for _, warning := range warnings {
+		report.Warnings = append(report.Warnings, warning.String())
+	}
+	if err != nil {
+		return fmt.Errorf("error parsing resource content as %s: %v", resource.FormatToString(format), err)
+	}
+
+	report.ArticlesParsed = len(articles)
+	if report.ArticlesParsed == 0 {
+		return fmt.Errorf("no articles parsed from resource content as %s", resource.FormatToString(format))
+	}
+
+	return nil
+}
+
+// fetchContent fetches up to MaxProbeBytes of the content of the link,
+// recording the status code, the content type and the size of the content in the report.
+func (rm *ResourceManager) fetchContent(link string, report *ProbeReport) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching resource from link: %v", err)
+	}
+
+	resp, err := rm.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching resource from link: %v", err)
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			fmt.Printf("error closing response body: %v\n", err)
+		}
+	}(resp.Body)
+
+	report.StatusCode = resp.StatusCode
+	report.ContentType = resp.Header.Get("Content-Type")
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error fetching resource from link: status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxProbeBytes))
+	if err != nil {
+		return nil, fmt.Errorf("error reading resource content: %v", err)
+	}
+	report.BytesFetched = int64(len(body))
+
+	return body, nil
+}
+
+// isFeedFormat reports whether the format is one of the syndication feed formats.
+func isFeedFormat(format resource.Format) bool {
+	return format == resource.RSS || format == resource.ATOM || format == resource.JSONFEED
+}
+
+// sniffFormat returns the format of the content recognized from its media type and its leading bytes,
+// or UNKNOWN if it is not recognized.
+func sniffFormat(contentType string, body []byte) resource.Format {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch mediaType {
+	case "application/rss+xml", "application/rdf+xml":
+		return resource.RSS
+	case "application/atom+xml":
+		return resource.ATOM
+	case "application/feed+json":
+		return resource.JSONFEED
+	}
+
+	head := bytes.TrimLeft(bytes.TrimPrefix(body, []byte("\xef\xbb\xbf")), " \t\r\n")
+	if len(head) > 4096 {
+		head = head[:4096]
+	}
+	lower := strings.ToLower(string(head))
+
+	switch {
+	case strings.HasPrefix(lower, "{"):
+		if strings.Contains(lower, "jsonfeed.org/version") {
+			return resource.JSONFEED
+		}
+		return resource.JSON
+	case strings.HasPrefix(lower, "<"):
+		return sniffMarkup(lower)
+	}
+
+	if mediaType == "text/html" {
+		return resource.HTML
+	}
+	return resource.UNKNOWN
+}
+
+// sniffMarkup returns the format of the lowercase XML or HTML content.
+func sniffMarkup(lower string) resource.Format {
+	switch {
+	case strings.Contains(lower, "<rss") || strings.Contains(lower, "<rdf:rdf"):
+		return resource.RSS
+	case strings.Contains(lower, "<feed"):
+		return resource.ATOM
+	case strings.Contains(lower, "<!doctype html") || strings.Contains(lower, "<html"):
+		return resource.HTML
+	default:
+		return resource.UNKNOWN
+	}
+}
@@ -0,0 +1,33 @@
+package manager
+
+import (
+	"fmt"
+	"news-aggregator/aggregator"
+	"strings"
+)
+
+// ResourceError is returned by ResourceManager.GetAllResources along with the resources of the other sources
+// when the resources of some sources cannot be read, e.g. of a source registered but not fetched yet.
+// The errors of the sources are unwrapped by errors.Is and errors.As.
+type ResourceError struct {
+	Errors []aggregator.SourceError
+}
+
+// Error returns the number of the sources that cannot be read followed by their errors.
+func (e *ResourceError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, sourceErr := range e.Errors {
+		messages = append(messages, sourceErr.Error())
+	}
+
+	return fmt.Sprintf("%d sources cannot be read: %s", len(e.Errors), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the errors of the sources.
+func (e *ResourceError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, sourceErr := range e.Errors {
+		errs = append(errs, sourceErr)
+	}
+	return errs
+}
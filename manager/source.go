@@ -0,0 +1,43 @@
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"news-aggregator/aggregator/model/resource"
+	"regexp"
+)
+
+var (
+	// ErrSourceExists is returned when a source is registered under the name of a registered one.
+	ErrSourceExists = errors.New("source already exists")
+	// ErrSourceNotFound is returned when a source that is not registered is changed.
+	ErrSourceNotFound = errors.New("source not found")
+)
+
+// sourceNamePattern is the pattern of the names of the sources, which are used in the names of the stored files.
+var sourceNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// ValidateSource checks that the name of the source suits the names of the stored files
+// and that the link is an absolute HTTP or HTTPS URL.
+func ValidateSource(name resource.Source, link string) error {
+	if !sourceNamePattern.MatchString(string(name)) {
+		return fmt.Errorf("invalid source name \"%s\": up to 64 letters, digits, dots, dashes and underscores "+
+			"are allowed, starting with a letter or a digit", name)
+	}
+
+	return ValidateLink(link)
+}
+
+// ValidateLink checks that the link is an absolute HTTP or HTTPS URL.
+func ValidateLink(link string) error {
+	u, err := url.Parse(link)
+	if err != nil {
+		return fmt.Errorf("invalid source URL: %v", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid source URL \"%s\": an absolute http or https URL is required", link)
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package manager
+
+import (
+	"fmt"
+	"news-aggregator/aggregator/model/resource"
+	"strings"
+)
+
+// SourceResult is the result of updating a source.
+type SourceResult struct {
+	Source resource.Source
+	// Err is nil if the source is updated or skipped.
+	Err error
+	// Skipped reports whether the source is backed off after repeated failures and not fetched.
+	Skipped bool
+}
+
+// RefreshResult is the result of refreshing a source on demand, see ResourceManager.RefreshResource.
+type RefreshResult struct {
+	// BytesFetched is the size of the fetched content, zero if the source is not modified.
+	BytesFetched int64
+	// Changed reports whether a new content is stored.
+	Changed bool
+	// ArticlesParsed is the number of the articles parsed from the new content.
+	ArticlesParsed int
+}
+
+// UpdateError is returned by ResourceManager.UpdateAllSources when some of the sources fail to update.
+// It keeps the results of all sources, the errors of the failed ones are unwrapped by errors.Is and errors.As.
+type UpdateError struct {
+	Results []SourceResult
+}
+
+// Failed returns the results of the sources that failed to update.
+func (e *UpdateError) Failed() []SourceResult {
+	var failed []SourceResult
+	for _, result := range e.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Error returns the number of the failed sources followed by their errors.
+func (e *UpdateError) Error() string {
+	failed := e.Failed()
+
+	messages := make([]string, 0, len(failed))
+	for _, result := range failed {
+		messages = append(messages, fmt.Sprintf("source \"%s\": %v", result.Source, result.Err))
+	}
+
+	return fmt.Sprintf("%d of %d sources failed to update: %s",
+		len(failed), len(e.Results), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the errors of the failed sources.
+func (e *UpdateError) Unwrap() []error {
+	var errs []error
+	for _, result := range e.Failed() {
+		errs = append(errs, result.Err)
+	}
+	return errs
+}
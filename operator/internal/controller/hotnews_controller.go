@@ -240,6 +240,10 @@ func (r *HotNewsReconciler) buildRequestURL(spec newsaggregatorv1.HotNewsSpec) (
 	if spec.DateEnd != nil {
 		params = append(params, "date-end="+formatDateForURL(spec.DateEnd.Time))
 	}
+	if spec.DateStart != nil || spec.DateEnd != nil {
+		// The server rejects the dates that read differently in the legacy layout unless the format is given.
+		params = append(params, "date-format="+dateFormat)
+	}
 
 	url := fmt.Sprintf("%s?%s", baseURL, strings.Join(params, "&"))
 	log.Log.Info("Built request URL", "URL", url)
@@ -284,9 +288,12 @@ func (r *HotNewsReconciler) collectUniqueSources(spec newsaggregatorv1.HotNewsSp
 	return allFeeds
 }
 
-// formatDateForURL formats the time to the required format for the URL.
+// dateFormat is the name of the layout of the dates formatted by formatDateForURL, known to the server.
+const dateFormat = "iso"
+
+// formatDateForURL formats the time as a YYYY-MM-DD calendar date for the URL, see dateFormat.
 func formatDateForURL(t time.Time) string {
-	return t.Format("2006-02-01")
+	return t.Format(time.DateOnly)
 }
 
 // fetchNews fetches news articles from the given URL.
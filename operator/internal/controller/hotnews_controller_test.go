@@ -19,6 +19,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"time"
 )
 
 var _ = Describe("HotNews Controller", func() {
@@ -126,6 +127,32 @@ var _ = Describe("HotNews Controller", func() {
 			Expect(hotNews.Status.ArticlesCount).To(Equal(1))
 		})
 
+		It("Should request the dates in the ISO format", func() {
+			hotNews.Spec.Feeds = []string{"test-feed"}
+			hotNews.Spec.DateStart = &metav1.Time{Time: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)}
+			hotNews.Spec.DateEnd = &metav1.Time{Time: time.Date(2024, 5, 6, 10, 0, 0, 0, time.UTC)}
+
+			err := fakeClient.Create(context.TODO(), hotNews)
+			Expect(err).To(BeNil())
+
+			var query url.Values
+			httpClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				query = req.URL.Query()
+				return &http.Response{
+					StatusCode: 200,
+					Body:       io.NopCloser(bytes.NewBufferString("[{\"title\": \"test title\"}]")),
+				}, nil
+			})
+
+			namespacedName := types.NamespacedName{Namespace: "default", Name: "test-hotnews"}
+			_, err = reconcile.Reconcile(context.TODO(), ctrl.Request{NamespacedName: namespacedName})
+			Expect(err).To(BeNil())
+
+			Expect(query.Get("date-start")).To(Equal("2024-05-01"))
+			Expect(query.Get("date-end")).To(Equal("2024-05-06"))
+			Expect(query.Get("date-format")).To(Equal("iso"))
+		})
+
 		It("Should reconcile valid HotNews with both FeedGroups and Feeds defined", func() {
 			hotNews.Spec.FeedGroups = []string{"test-feed-group"}
 			hotNews.Spec.Feeds = []string{"test-feed"}
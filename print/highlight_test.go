@@ -0,0 +1,37 @@
+package print
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlightKeywords(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() { color.NoColor = noColor }()
+
+	underline := color.New(color.Underline).SprintFunc()
+
+	tests := []struct {
+		name     string
+		text     string
+		keywords string
+		expected string
+	}{
+		{"Highlights whole words only", "War, award and software",
+			"war", underline("War") + ", award and software"},
+		{"Highlights stemmed words", "Elections in Ukraine's east",
+			"election,ukraine", underline("Elections") + " in " + underline("Ukraine's") + " east"},
+		{"Does not highlight stopwords", "The war in the east",
+			"the war", "The " + underline("war") + " in the east"},
+		{"Keeps text without matches", "Markets  rally!", "war", "Markets  rally!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, highlightKeywords(tt.text, tt.keywords))
+		})
+	}
+}
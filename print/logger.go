@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"github.com/Masterminds/sprig/v3"
 	"github.com/fatih/color"
-	"github.com/reiver/go-porterstemmer"
+	"news-aggregator/aggregator/analysis"
+	"news-aggregator/aggregator/cluster"
 	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/trend"
 	"os"
 	"path"
 	"strings"
@@ -21,6 +23,7 @@ type Logger struct {
 type FilterParams struct {
 	SourceArg    string
 	KeywordsArg  string
+	QueryArg     string
 	StartDateArg string
 	EndDateArg   string
 	OrderArg     string
@@ -34,45 +37,32 @@ func New() *Logger {
 	}
 }
 
-func extractKeywords(keywordsArg string) []string {
-	keywords := strings.Split(keywordsArg, ",")
-	stemmedKeywords := make([]string, len(keywords))
-	for i, keyword := range keywords {
-		stemmedKeywords[i] = porterstemmer.StemString(keyword)
-	}
-	return stemmedKeywords
-}
+// highlightKeywords underlines the words of the text matching the keywords the same way as the keyword filter does.
+func highlightKeywords(text string, keywordsArg string) string {
+	analyzer := analysis.Default()
 
-func extractWords(text string) ([]string, []string) {
-	words := strings.Fields(text)
-	stemmedWords := make([]string, len(words))
-	for i, word := range words {
-		stemmedWords[i] = porterstemmer.StemString(word)
+	keywordTerms := make(map[string]struct{})
+	for _, keyword := range strings.Split(keywordsArg, ",") {
+		for _, term := range analyzer.Terms(keyword) {
+			keywordTerms[term] = struct{}{}
+		}
 	}
-	return words, stemmedWords
-}
 
-func highlightWords(words, stemmedWords, stemmedKeywords []string) []string {
-	stemmedKeywordsSet := make(map[string]struct{}, len(stemmedKeywords))
-	for _, stemmedKeyword := range stemmedKeywords {
-		stemmedKeywordsSet[stemmedKeyword] = struct{}{}
-	}
+	underline := color.New(color.Underline).SprintFunc()
 
-	for i, stemmedWord := range stemmedWords {
-		if _, exists := stemmedKeywordsSet[stemmedWord]; exists {
-			highlighted := color.New(color.Underline).SprintFunc()(words[i])
-			words[i] = highlighted
+	var highlighted strings.Builder
+	position := 0
+	for _, token := range analyzer.Tokens(text) {
+		if _, exists := keywordTerms[token.Term]; !exists || token.Stopword {
+			continue
 		}
+		highlighted.WriteString(text[position:token.Start])
+		highlighted.WriteString(underline(token.Text))
+		position = token.End
 	}
-	return words
-}
+	highlighted.WriteString(text[position:])
 
-// highlightKeywords is the main function that uses the helper functions to highlight the keywords in the text.
-func highlightKeywords(text string, keywordsArg string) string {
-	stemmedKeywords := extractKeywords(keywordsArg)
-	words, stemmedWords := extractWords(text)
-	highlightedWords := highlightWords(words, stemmedWords, stemmedKeywords)
-	return strings.Join(highlightedWords, " ")
+	return highlighted.String()
 }
 
 func groupBySource(articles []article.Article) map[string][]article.Article {
@@ -94,17 +84,55 @@ func (l *Logger) PrintArticles(articles []article.Article, params FilterParams)
 		Params:   params,
 	}
 
+	return l.execute("main", data)
+}
+
+// PrintClusters prints the stories the articles are grouped into to the console in predefined template.
+func (l *Logger) PrintClusters(clusters []cluster.Cluster, params FilterParams) error {
+	var articles []article.Article
+	for _, c := range clusters {
+		articles = append(articles, c.Articles...)
+	}
+
+	data := struct {
+		Clusters []cluster.Cluster
+		Articles []article.Article
+		Params   FilterParams
+	}{
+		Clusters: clusters,
+		Articles: articles,
+		Params:   params,
+	}
+
+	return l.execute("clustersMain", data)
+}
+
+// PrintTrends prints the trending terms of the articles to the console in predefined template.
+func (l *Logger) PrintTrends(trends []trend.Trend, params FilterParams) error {
+	data := struct {
+		Trends []trend.Trend
+		Params FilterParams
+	}{
+		Trends: trends,
+		Params: params,
+	}
+
+	return l.execute("trendsMain", data)
+}
+
+// execute executes the named template of the template file with the given data.
+func (l *Logger) execute(name string, data interface{}) error {
 	funcMap := template.FuncMap{
 		"highlight":     highlightKeywords,
 		"groupBySource": groupBySource,
 	}
 
-	tmpl, err := template.New("main").Funcs(funcMap).Funcs(sprig.FuncMap()).ParseFiles(l.templatePath)
+	tmpl, err := template.New(name).Funcs(funcMap).Funcs(sprig.FuncMap()).ParseFiles(l.templatePath)
 	if err != nil {
 		return fmt.Errorf("failed to parse template: %v", err)
 	}
 
-	err = tmpl.ExecuteTemplate(os.Stdout, "main", data)
+	err = tmpl.ExecuteTemplate(os.Stdout, name, data)
 	if err != nil {
 		return fmt.Errorf("failed to execute template: %v", err)
 	}
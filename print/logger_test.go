@@ -1,7 +1,9 @@
 package print_test
 
 import (
+	"news-aggregator/aggregator/cluster"
 	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/trend"
 	"news-aggregator/print"
 	"os"
 	"testing"
@@ -39,6 +41,31 @@ func TestPrintArticlesInTemplate(t *testing.T) {
 
 }
 
+func TestPrintArticlesInTemplate_WithOptionalFields(t *testing.T) {
+	l := print.New()
+
+	testDate := time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC)
+	art, _ := article.NewArticleBuilder().
+		SetTitle("Test Title").
+		SetDescription("Test Description").
+		SetDate(article.CreationDate(testDate)).
+		SetSource("Test Source").
+		SetLink("https://testlink.com").
+		SetCategories([]string{"World"}).
+		SetTags([]string{"election", "vote"}).
+		SetImage("https://testlink.com/image.jpg").
+		SetUpdated(article.UpdateDate(testDate.Add(time.Hour))).
+		SetLanguage("en").
+		SetContent("Test Content").
+		Build()
+
+	l.SetTemplatePath("template/article_template.txt")
+	err := l.PrintArticles([]article.Article{*art}, print.FilterParams{KeywordsArg: "Test"})
+	if err != nil {
+		t.Fatalf("Failed to print articles in template: %v", err)
+	}
+}
+
 func TestPrintArticlesInTemplateError(t *testing.T) {
 
 	l := print.New()
@@ -81,6 +108,61 @@ func TestPrintArticlesInTemplate_WithIncorrectLocation(t *testing.T) {
 	}
 }
 
+func TestPrintClustersInTemplate(t *testing.T) {
+	l := print.New()
+
+	testDate := time.Date(2024, time.June, 5, 12, 0, 0, 0, time.UTC)
+	art, _ := article.NewArticleBuilder().
+		SetTitle("Test Title").
+		SetDescription("Test Description").
+		SetDate(article.CreationDate(testDate)).
+		SetSource("Test Source").
+		SetLink("https://testlink.com").
+		Build()
+
+	clusters := cluster.New().Cluster([]article.Article{*art})
+
+	l.SetTemplatePath("template/article_template.txt")
+	err := l.PrintClusters(clusters, print.FilterParams{KeywordsArg: "Test"})
+	if err != nil {
+		t.Fatalf("Failed to print clusters in template: %v", err)
+	}
+}
+
+func TestPrintClustersInTemplate_WithoutClustersTemplate(t *testing.T) {
+
+	l := print.New()
+
+	l.SetTemplatePath("testdata/article_simple_template.txt")
+	err := l.PrintClusters(nil, print.FilterParams{})
+
+	if err == nil {
+		t.Errorf("PrintClusters() should return an error when template does not define the clusters")
+	}
+}
+
+func TestPrintTrendsInTemplate(t *testing.T) {
+	l := print.New()
+
+	trends := []trend.Trend{
+		{
+			Term:  "volcano",
+			Score: 3.5,
+			Total: 4,
+			Counts: []trend.DailyCount{
+				{Date: time.Date(2024, time.June, 4, 0, 0, 0, 0, time.UTC), Count: 0},
+				{Date: time.Date(2024, time.June, 5, 0, 0, 0, 0, time.UTC), Count: 4},
+			},
+		},
+	}
+
+	l.SetTemplatePath("template/article_template.txt")
+	err := l.PrintTrends(trends, print.FilterParams{StartDateArg: "-2d", OrderArg: "trends"})
+	if err != nil {
+		t.Fatalf("Failed to print trends in template: %v", err)
+	}
+}
+
 func TestLog(t *testing.T) {
 	l := print.New()
 
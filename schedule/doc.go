@@ -0,0 +1,6 @@
+// Package schedule provides the update schedules of the sources declared in the feeds dictionary.
+//
+// A schedule is either an interval, e.g. "5m" or "@every 5m", or a standard five-field cron expression,
+// e.g. "0 6 * * *", with the @hourly, @daily, @weekly and @monthly shortcuts. Run is the state of the scheduled
+// updates of a source exposed by the server.
+package schedule
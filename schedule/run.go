@@ -0,0 +1,17 @@
+package schedule
+
+import "time"
+
+// Run is the state of the scheduled updates of a source.
+type Run struct {
+	// Source is the name of the source.
+	Source string
+	// Schedule is the schedule of the source, empty for the default interval of the scheduler.
+	Schedule string
+	// LastRun is the time of the last update, zero before the first one.
+	LastRun time.Time
+	// LastError is the error of the last update, empty if it has succeeded.
+	LastError string
+	// NextRun is the time of the next update, jitter included.
+	NextRun time.Time
+}
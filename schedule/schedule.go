@@ -0,0 +1,69 @@
+package schedule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Schedule returns the times a source is due for an update.
+type Schedule interface {
+	// Next returns the first time the source is due after the given time.
+	Next(after time.Time) time.Time
+}
+
+// Interval is a schedule repeating after a fixed duration.
+type Interval time.Duration
+
+// Next returns the time the interval elapses after the given time.
+func (i Interval) Next(after time.Time) time.Time {
+	return after.Add(time.Duration(i))
+}
+
+// descriptors are the cron expressions of the supported shortcuts.
+var descriptors = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+// Parse parses the schedule of a source, either an interval, e.g. "5m" or "@every 5m",
+// or a cron expression, e.g. "*/15 * * * *" or "@daily".
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, fmt.Errorf("empty schedule")
+	}
+
+	if expression, ok := descriptors[spec]; ok {
+		return ParseCron(expression)
+	}
+
+	if strings.HasPrefix(spec, "@") {
+		every, found := strings.CutPrefix(spec, "@every ")
+		if !found {
+			return nil, fmt.Errorf("unknown schedule descriptor: %s", spec)
+		}
+		return parseInterval(strings.TrimSpace(every))
+	}
+
+	if !strings.Contains(spec, " ") {
+		return parseInterval(spec)
+	}
+
+	return ParseCron(spec)
+}
+
+// parseInterval parses a positive duration.
+func parseInterval(value string) (Schedule, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid interval: %v", err)
+	}
+	if d <= 0 {
+		return nil, fmt.Errorf("interval must be positive: %s", value)
+	}
+	return Interval(d), nil
+}
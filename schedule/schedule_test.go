@@ -0,0 +1,53 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	// Sunday, 19 May 2024.
+	now := time.Date(2024, 5, 19, 10, 7, 30, 0, time.UTC)
+
+	tests := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{"5m", time.Date(2024, 5, 19, 10, 12, 30, 0, time.UTC)},
+		{"@every 24h", time.Date(2024, 5, 20, 10, 7, 30, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 5, 19, 10, 15, 0, 0, time.UTC)},
+		{"0 6 * * *", time.Date(2024, 5, 20, 6, 0, 0, 0, time.UTC)},
+		{"30 9-17 * * 1-5", time.Date(2024, 5, 20, 9, 30, 0, 0, time.UTC)},
+		{"0 0 1,15 * *", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 12 * * 7", time.Date(2024, 5, 19, 12, 0, 0, 0, time.UTC)},
+		{"0 0 1 * 3", time.Date(2024, 5, 22, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, 5, 19, 11, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2024, 5, 26, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		s, err := Parse(tt.spec)
+		assert.NoError(t, err, tt.spec)
+		assert.Equal(t, tt.expected, s.Next(now), tt.spec)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	invalid := []string{"", "soon", "-5m", "0s", "@yearly", "@every never", "* * * *", "60 * * * *",
+		"* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "5-1 * * * *", "*/0 * * * *", "a * * * *"}
+
+	for _, spec := range invalid {
+		_, err := Parse(spec)
+		assert.Error(t, err, "schedule %q should be invalid", spec)
+	}
+}
+
+func TestCron_Next_NeverMatches(t *testing.T) {
+	c, err := ParseCron("0 0 30 2 *")
+	assert.NoError(t, err)
+	assert.True(t, c.Next(time.Date(2024, 5, 19, 10, 0, 0, 0, time.UTC)).IsZero())
+}
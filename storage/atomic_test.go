@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// These tests are mirrored by updater/storage/atomic_test.go, see WriteFileAtomic.
+
+func TestWriteFileAtomic_UpdaterCopy(t *testing.T) {
+	original, err := os.ReadFile("atomic.go")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	copied, err := os.ReadFile("../updater/storage/atomic.go")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(original) != string(copied) {
+		t.Errorf("updater/storage/atomic.go differs from atomic.go, run go generate ./storage in the updater module")
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "feeds.json")
+
+	for _, content := range []string{"first", "second"} {
+		if err := WriteFileAtomic(path, []byte(content), 0600); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if string(data) != content {
+			t.Errorf("expected content %q, got %q", content, data)
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected permission %v, got %v", os.FileMode(0600), info.Mode().Perm())
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 1 {
+		t.Errorf("expected no temporary files, got %d files", len(files))
+	}
+
+	if err := WriteFileAtomic(filepath.Join(dir, "missing", "feeds.json"), []byte("content"), 0600); err == nil {
+		t.Errorf("expected error for missing directory")
+	}
+}
+
+func TestAvailableSources_HiddenFiles(t *testing.T) {
+	dir := t.TempDir()
+	storage := New(dir)
+
+	createTestFile(t, dir, "bbc_20240519103000.xml", "content")
+	createTestFile(t, dir, ".bbc_20240519113000.xml.tmp-123", "partial")
+
+	sources, err := storage.AvailableSources()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sources) != 1 || sources[0] != "bbc" {
+		t.Errorf("expected sources [bbc], got %v", sources)
+	}
+}
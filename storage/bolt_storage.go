@@ -0,0 +1,225 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.etcd.io/bbolt"
+
+	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/model/resource"
+)
+
+// Names of the buckets nested in the bucket of every source.
+var (
+	// datesBucket holds the encoded articles by the keys made of their creation date and ID.
+	datesBucket = []byte("dates")
+	// idsBucket holds the keys of the dates bucket by the article ID.
+	idsBucket = []byte("ids")
+)
+
+// dateKeyLayout is the layout of the creation date in the keys of the dates bucket, ordered the same way as the dates.
+const dateKeyLayout = "20060102150405.000000000"
+
+// BoltStorage is an ArticleStorage keeping the parsed articles in an embedded bbolt database.
+// Every source has its own bucket, where the articles are indexed by their creation date and ID.
+// An article parsed again, e.g. fetched on another day, replaces the stored one.
+type BoltStorage struct {
+	db    *bbolt.DB
+	parse ParseFunc
+}
+
+// NewBolt opens the database at the path, creating it if it does not exist.
+// The database is locked by the BoltStorage until it is closed.
+func NewBolt(path string) (*BoltStorage, error) {
+	err := os.MkdirAll(filepath.Dir(path), os.ModePerm)
+	if err != nil {
+		return nil, fmt.Errorf("error creating directory: %v", err)
+	}
+
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("error opening database: %v", err)
+	}
+
+	return &BoltStorage{
+		db: db,
+	}, nil
+}
+
+// SetParser sets the function parsing the fetched contents.
+func (s *BoltStorage) SetParser(parse ParseFunc) {
+	s.parse = parse
+}
+
+// Close closes the database.
+func (s *BoltStorage) Close() error {
+	return s.db.Close()
+}
+
+// AvailableSources returns the sources having stored articles, in alphabetical order.
+func (s *BoltStorage) AvailableSources() ([]resource.Source, error) {
+	var sources []resource.Source
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return tx.ForEach(func(name []byte, _ *bbolt.Bucket) error {
+			sources = append(sources, resource.Source(name))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading database: %v", err)
+	}
+
+	return sources, nil
+}
+
+// ReadSource returns all articles of the source encoded as a single content of the resource.STORED format.
+func (s *BoltStorage) ReadSource(source resource.Source) ([]string, error) {
+	articles, err := s.ReadArticles(source, time.Time{}, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(articles)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding articles: %v", err)
+	}
+
+	return []string{string(content)}, nil
+}
+
+// ReadArticles returns the articles of the source created between the start and end times, inclusive,
+// in the order of their creation. Zero times leave the range open.
+func (s *BoltStorage) ReadArticles(source resource.Source, start, end time.Time) ([]article.Article, error) {
+	articles := make([]article.Article, 0)
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		sourceBucket := tx.Bucket([]byte(source))
+		if sourceBucket == nil {
+			return fmt.Errorf("%w: %s", ErrSourceUnknown, source)
+		}
+
+		cursor := sourceBucket.Bucket(datesBucket).Cursor()
+
+		var key, value []byte
+		if start.IsZero() {
+			key, value = cursor.First()
+		} else {
+			key, value = cursor.Seek(dateKey(start))
+		}
+
+		var upperBound []byte
+		if !end.IsZero() {
+			// Every key of the end date is lower than the date followed by a byte greater than the separator.
+			upperBound = append(dateKey(end), 0xff)
+		}
+
+		for ; key != nil; key, value = cursor.Next() {
+			if upperBound != nil && bytes.Compare(key, upperBound) > 0 {
+				break
+			}
+
+			var art article.Article
+			if err := json.Unmarshal(value, &art); err != nil {
+				return fmt.Errorf("error decoding article %s: %v", key, err)
+			}
+			articles = append(articles, art)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return articles, nil
+}
+
+// UpdateXMLSource parses the content of the source and stores its articles.
+func (s *BoltStorage) UpdateXMLSource(source resource.Source, content []byte) error {
+	return s.updateSource(source, content)
+}
+
+// UpdateJSONSource parses the content of the source and stores its articles.
+func (s *BoltStorage) UpdateJSONSource(source resource.Source, content []byte) error {
+	return s.updateSource(source, content)
+}
+
+// UpdateHTMLSource parses the content of the source and stores its articles.
+func (s *BoltStorage) UpdateHTMLSource(source resource.Source, content []byte) error {
+	return s.updateSource(source, content)
+}
+
+func (s *BoltStorage) updateSource(source resource.Source, content []byte) error {
+	if s.parse == nil {
+		return errors.New("no parser is set to store the articles")
+	}
+
+	articles, err := s.parse(source, content)
+	if err != nil {
+		return fmt.Errorf("error parsing resource: %v", err)
+	}
+
+	err = s.db.Update(func(tx *bbolt.Tx) error {
+		sourceBucket, err := tx.CreateBucketIfNotExists([]byte(source))
+		if err != nil {
+			return err
+		}
+		dates, err := sourceBucket.CreateBucketIfNotExists(datesBucket)
+		if err != nil {
+			return err
+		}
+		ids, err := sourceBucket.CreateBucketIfNotExists(idsBucket)
+		if err != nil {
+			return err
+		}
+
+		for _, art := range articles {
+			if err := putArticle(dates, ids, art); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error writing articles to database: %v", err)
+	}
+
+	return nil
+}
+
+// putArticle stores the article, replacing the stored article with the same ID.
+func putArticle(dates, ids *bbolt.Bucket, art article.Article) error {
+	id := []byte(art.ID())
+
+	if previousKey := ids.Get(id); previousKey != nil {
+		if err := dates.Delete(previousKey); err != nil {
+			return err
+		}
+	}
+
+	value, err := json.Marshal(art)
+	if err != nil {
+		return err
+	}
+
+	key := append(dateKey(time.Time(art.Date())), '/')
+	key = append(key, id...)
+
+	if err := dates.Put(key, value); err != nil {
+		return err
+	}
+	return ids.Put(id, key)
+}
+
+// dateKey returns the part of the keys of the dates bucket made of the date.
+func dateKey(date time.Time) []byte {
+	return []byte(date.UTC().Format(dateKeyLayout))
+}
@@ -0,0 +1,175 @@
+package storage
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"news-aggregator/aggregator/model/article"
+	"news-aggregator/aggregator/model/resource"
+)
+
+// parseLines is a ParseFunc turning every "title|RFC3339 date" line of the content into an article.
+func parseLines(source resource.Source, content []byte) ([]article.Article, error) {
+	var articles []article.Article
+
+	for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+		parts := strings.Split(line, "|")
+		if len(parts) != 2 {
+			return nil, errors.New("invalid line")
+		}
+		date, err := time.Parse(time.RFC3339, parts[1])
+		if err != nil {
+			return nil, err
+		}
+
+		art, err := article.NewArticleBuilder().
+			SetTitle(article.Title(parts[0])).
+			SetDescription("description").
+			SetDate(article.CreationDate(date)).
+			SetSource(source).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+		articles = append(articles, *art)
+	}
+
+	return articles, nil
+}
+
+func newBoltStorage(t *testing.T) *BoltStorage {
+	s, err := NewBolt(filepath.Join(t.TempDir(), "db", "articles.db"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := s.Close(); err != nil {
+			t.Errorf("error closing database: %v", err)
+		}
+	})
+
+	s.SetParser(parseLines)
+	return s
+}
+
+// titles returns the comma-separated titles of the articles.
+func titles(articles []article.Article) string {
+	var result []string
+	for _, art := range articles {
+		result = append(result, art.TitleStr())
+	}
+	return strings.Join(result, ",")
+}
+
+func TestBoltStorage_UpdateSource(t *testing.T) {
+	s := newBoltStorage(t)
+
+	err := s.UpdateXMLSource("bbc-world", []byte("second|2024-05-19T10:00:00Z\nfirst|2024-05-18T10:00:00Z"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err = s.UpdateJSONSource("bbc", []byte("other|2024-05-18T12:00:00Z"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The same articles fetched again replace the stored ones.
+	err = s.UpdateHTMLSource("bbc-world", []byte("first|2024-05-18T10:00:00Z\nthird|2024-05-20T10:00:00Z"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sources, err := s.AvailableSources()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(sources) != 2 || sources[0] != "bbc" || sources[1] != "bbc-world" {
+		t.Errorf("expected sources [bbc bbc-world], got %v", sources)
+	}
+
+	articles, err := s.ReadArticles("bbc-world", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := titles(articles); got != "first,second,third" {
+		t.Errorf("expected articles first,second,third, got %s", got)
+	}
+}
+
+func TestBoltStorage_ReadArticles(t *testing.T) {
+	s := newBoltStorage(t)
+
+	content := "first|2024-05-18T10:00:00Z\nsecond|2024-05-19T10:00:00Z\nthird|2024-05-20T10:00:00Z"
+	if err := s.UpdateXMLSource("bbc-world", []byte(content)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		start    time.Time
+		end      time.Time
+		expected string
+	}{
+		{"open range", time.Time{}, time.Time{}, "first,second,third"},
+		{"since start", time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC), time.Time{}, "second,third"},
+		{"until end", time.Time{}, time.Date(2024, 5, 19, 10, 0, 0, 0, time.UTC), "first,second"},
+		{"inclusive range",
+			time.Date(2024, 5, 19, 10, 0, 0, 0, time.UTC), time.Date(2024, 5, 19, 10, 0, 0, 0, time.UTC), "second"},
+		{"empty range", time.Date(2024, 5, 21, 0, 0, 0, 0, time.UTC), time.Time{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			articles, err := s.ReadArticles("bbc-world", tt.start, tt.end)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := titles(articles); got != tt.expected {
+				t.Errorf("expected articles %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := s.ReadArticles("bbc", time.Time{}, time.Time{}); err == nil {
+		t.Errorf("expected error for unknown source")
+	}
+}
+
+func TestBoltStorage_ReadSource(t *testing.T) {
+	s := newBoltStorage(t)
+
+	if err := s.UpdateXMLSource("bbc-world", []byte("first|2024-05-18T10:00:00Z")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	contents, err := s.ReadSource("bbc-world")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(contents) != 1 || !strings.Contains(contents[0], `"title":"first"`) {
+		t.Errorf("expected the stored articles, got %v", contents)
+	}
+
+	if _, err := s.ReadSource("bbc"); err == nil {
+		t.Errorf("expected error for unknown source")
+	}
+}
+
+func TestBoltStorage_UpdateSource_Errors(t *testing.T) {
+	s := newBoltStorage(t)
+
+	if err := s.UpdateXMLSource("bbc-world", []byte("invalid")); err == nil {
+		t.Errorf("expected error for invalid content")
+	}
+
+	s.SetParser(nil)
+	if err := s.UpdateXMLSource("bbc-world", []byte("first|2024-05-18T10:00:00Z")); err == nil {
+		t.Errorf("expected error without parser")
+	}
+
+	if sources, _ := s.AvailableSources(); len(sources) != 0 {
+		t.Errorf("expected no sources, got %v", sources)
+	}
+}
@@ -1,2 +1,3 @@
 // Package storage provides an API for supplying structured resources.
+// The contents of the sources are kept either in files of a directory or in an embedded bbolt database.
 package storage
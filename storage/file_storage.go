@@ -0,0 +1,260 @@
+package storage
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"news-aggregator/aggregator/model/resource"
+)
+
+// Layouts of the time in the names of the snapshot files.
+const (
+	// SnapshotLayout is the layout of the snapshots written by the FileStorage, precise to a second.
+	SnapshotLayout = "20060102150405"
+	// DailySnapshotLayout is the layout of the snapshots written before, precise to a day.
+	DailySnapshotLayout = "20060102"
+)
+
+// snapshotPerm is the permission of the snapshot files.
+const snapshotPerm = 0644
+
+// FileStorage is a Storage keeping the fetched contents of the sources in files of a directory.
+// Every update of a source is written to a snapshot file named after the source and the time of the update
+// in UTC, e.g. "bbc-world_20240519103000.xml". A content identical to a stored snapshot of the source
+// is not written again, the snapshot is renamed after the time of the update instead.
+// The snapshots are written with WriteFileAtomic, so a crash never leaves a truncated snapshot.
+type FileStorage struct {
+	basePath string
+	// now returns the time of an update.
+	now func() time.Time
+}
+
+// New creates a new FileStorage.
+func New(basePath string) *FileStorage {
+
+	if basePath == "" {
+		basePath = "/resources"
+	}
+
+	if _, err := os.Stat(basePath); os.IsNotExist(err) {
+		err := os.MkdirAll(basePath, os.ModePerm)
+		if err != nil {
+			fmt.Printf("error creating directory: %v\n", err)
+		}
+	}
+
+	return &FileStorage{
+		basePath: basePath,
+		now:      time.Now,
+	}
+}
+
+// FileExists checks if a file exists in the storage.
+func (s *FileStorage) fileExists(filename string) bool {
+	absPath := filepath.Join(s.basePath, filename)
+	_, err := os.Stat(absPath)
+	return err == nil
+}
+
+// AvailableSources returns all the available registered in storage sources.
+func (s *FileStorage) AvailableSources() ([]resource.Source, error) {
+	files, err := os.ReadDir(s.basePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading directory: %v", err)
+	}
+
+	sourceMap := make(map[resource.Source]bool)
+	var sources []resource.Source
+
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		if source, ok := fileSource(file.Name()); ok && !sourceMap[source] {
+			sourceMap[source] = true
+			sources = append(sources, source)
+		}
+	}
+
+	return sources, nil
+}
+
+// ReadSource reads the content of files of the source.
+func (s *FileStorage) ReadSource(source resource.Source) ([]string, error) {
+	snapshots, err := s.SourceSnapshots(source)
+	if err != nil {
+		return nil, err
+	}
+
+	contents := make([]string, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		content, err := s.ReadSnapshot(snapshot)
+		if err != nil {
+			return nil, err
+		}
+		contents = append(contents, content)
+	}
+
+	return contents, nil
+}
+
+// SourceSnapshots returns the snapshots of the source in the order of their names, the oldest first.
+// The time of a file of the source that is not named after the time of the update is zero.
+func (s *FileStorage) SourceSnapshots(source resource.Source) ([]Snapshot, error) {
+	files, err := os.ReadDir(s.basePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading directory: %v", err)
+	}
+
+	var snapshots []Snapshot
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		fileName := file.Name()
+		if fileSource, ok := fileSource(fileName); !ok || fileSource != source {
+			continue
+		}
+
+		snapshot, ok := parseSnapshot(fileName)
+		if !ok {
+			snapshot = Snapshot{Source: source, Name: fileName}
+		}
+
+		info, err := file.Info()
+		if os.IsNotExist(err) {
+			// The snapshot is renamed by a concurrent update of the source.
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading file info: %v", err)
+		}
+		snapshot.Size = info.Size()
+		snapshot.ModTime = info.ModTime()
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrSourceUnknown, source)
+	}
+
+	return snapshots, nil
+}
+
+// ReadSnapshot returns the content of the snapshot file.
+func (s *FileStorage) ReadSnapshot(snapshot Snapshot) (string, error) {
+	return s.readFileContents(filepath.Join(s.basePath, snapshot.Name))
+}
+
+// UpdateXMLSource creates a new xml file with the content of the source.
+func (s *FileStorage) UpdateXMLSource(source resource.Source, content []byte) error {
+	return s.updateSource(source, content, "xml")
+}
+
+// UpdateJSONSource creates a new json file with the content of the source.
+func (s *FileStorage) UpdateJSONSource(source resource.Source, content []byte) error {
+	return s.updateSource(source, content, "json")
+}
+
+// UpdateHTMLSource creates a new html file with the content of the source.
+func (s *FileStorage) UpdateHTMLSource(source resource.Source, content []byte) error {
+	return s.updateSource(source, content, "html")
+}
+
+func (s *FileStorage) updateSource(source resource.Source, content []byte, ext string) error {
+	fileName := fmt.Sprintf("%s_%s.%s", source, s.now().UTC().Format(SnapshotLayout), ext)
+	filePath := filepath.Join(s.basePath, fileName)
+
+	duplicate, err := s.findDuplicate(source, ext, content)
+	if err != nil {
+		return err
+	}
+
+	if duplicate != "" {
+		err = os.Rename(filepath.Join(s.basePath, duplicate), filePath)
+		if err != nil {
+			return fmt.Errorf("error renaming identical snapshot: %v", err)
+		}
+		return nil
+	}
+
+	err = WriteFileAtomic(filePath, content, snapshotPerm)
+	if err != nil {
+		return fmt.Errorf("error writing resource to file: %v", err)
+	}
+
+	return nil
+}
+
+// findDuplicate returns the name of the snapshot of the source with the same extension and content,
+// or an empty string if there is none.
+func (s *FileStorage) findDuplicate(source resource.Source, ext string, content []byte) (string, error) {
+	snapshots, err := s.Snapshots()
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(content)
+
+	for _, snapshot := range snapshots {
+		if snapshot.Source != source || snapshot.Size != int64(len(content)) || filepath.Ext(snapshot.Name) != "."+ext {
+			continue
+		}
+
+		stored, err := os.ReadFile(filepath.Join(s.basePath, snapshot.Name))
+		if err != nil {
+			return "", fmt.Errorf("error reading snapshot: %v", err)
+		}
+		if sha256.Sum256(stored) == hash {
+			return snapshot.Name, nil
+		}
+	}
+
+	return "", nil
+}
+
+// fileSource returns the source of the file named after the source and the time of the update,
+// e.g. "bbc-world_20240519103000.xml". It reports false for the files that are not named this way
+// and for the hidden files, e.g. the temporary files of WriteFileAtomic.
+func fileSource(fileName string) (resource.Source, bool) {
+	if strings.HasPrefix(fileName, ".") {
+		return "", false
+	}
+
+	i := strings.LastIndex(fileName, "_")
+	if i <= 0 {
+		return "", false
+	}
+	return resource.Source(fileName[:i]), true
+}
+
+func (s *FileStorage) readFileContents(absPath string) (string, error) {
+	file, err := os.Open(absPath)
+	if err != nil {
+		return "", fmt.Errorf("error opening file: %v", err)
+	}
+	defer func(file *os.File) {
+		err := file.Close()
+		if err != nil {
+			fmt.Printf("error closing file: %v\n", err)
+		}
+	}(file)
+
+	scanner := bufio.NewScanner(file)
+	var content strings.Builder
+	for scanner.Scan() {
+		content.WriteString(scanner.Text())
+		content.WriteString("\n")
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("error scanning file: %v", err)
+	}
+
+	return content.String(), nil
+}
@@ -9,6 +9,9 @@ import (
 	"news-aggregator/aggregator/model/resource"
 )
 
+// testTime is the time of the updates in the tests.
+var testTime = time.Date(2024, 5, 19, 10, 30, 0, 0, time.UTC)
+
 func createTestFile(t *testing.T, dir, name, content string) {
 	err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
 	if err != nil {
@@ -130,9 +133,42 @@ func TestReadSource(t *testing.T) {
 	}
 }
 
+func TestReadSource_SimilarSources(t *testing.T) {
+	dir := t.TempDir()
+	storage := New(dir)
+
+	createTestFile(t, dir, "bbc_20210101.xml", "bbc")
+	createTestFile(t, dir, "bbc-world_20210101.xml", "bbc-world")
+	createTestFile(t, dir, "bbc_world_20210101.xml", "bbc_world")
+
+	tests := []struct {
+		source   resource.Source
+		expected string
+	}{
+		{"bbc", "bbc\n"},
+		{"bbc-world", "bbc-world\n"},
+		{"bbc_world", "bbc_world\n"},
+	}
+
+	for _, tt := range tests {
+		contents, err := storage.ReadSource(tt.source)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(contents) != 1 || contents[0] != tt.expected {
+			t.Errorf("expected only the content of %s, got %q", tt.source, contents)
+		}
+	}
+
+	if _, err := storage.ReadSource("bb"); err == nil {
+		t.Fatalf("expected error for unknown source")
+	}
+}
+
 func TestUpdateXMLSource(t *testing.T) {
 	dir := "testdata"
 	storage := New(dir)
+	storage.now = func() time.Time { return testTime }
 	source := resource.Source("source1")
 	content := []byte("<xml>content</xml>")
 
@@ -150,7 +186,7 @@ func TestUpdateXMLSource(t *testing.T) {
 		t.Fatalf("expected 1 file, got %d", len(files))
 	}
 
-	expectedFileName := string(source) + "_" + time.Now().Format("20060102") + ".xml"
+	expectedFileName := string(source) + "_" + testTime.Format(SnapshotLayout) + ".xml"
 	if files[0].Name() != expectedFileName {
 		t.Errorf("expected file name %q, got %q", expectedFileName, files[0].Name())
 	}
@@ -175,6 +211,7 @@ func TestUpdateXMLSource(t *testing.T) {
 func TestUpdateJSONSource(t *testing.T) {
 	dir := "testdata"
 	storage := New(dir)
+	storage.now = func() time.Time { return testTime }
 	source := resource.Source("source2")
 	content := []byte(`{"key": "value"}`)
 
@@ -192,7 +229,7 @@ func TestUpdateJSONSource(t *testing.T) {
 		t.Fatalf("expected 1 file, got %d", len(files))
 	}
 
-	expectedFileName := string(source) + "_" + time.Now().Format("20060102") + ".json"
+	expectedFileName := string(source) + "_" + testTime.Format(SnapshotLayout) + ".json"
 	if files[0].Name() != expectedFileName {
 		t.Errorf("expected file name %q, got %q", expectedFileName, files[0].Name())
 	}
@@ -217,6 +254,7 @@ func TestUpdateJSONSource(t *testing.T) {
 func TestUpdateHTMLSource(t *testing.T) {
 	dir := "testdata"
 	storage := New(dir)
+	storage.now = func() time.Time { return testTime }
 	source := resource.Source("source3")
 	content := []byte("<html>content</html>")
 
@@ -234,7 +272,7 @@ func TestUpdateHTMLSource(t *testing.T) {
 		t.Fatalf("expected 1 file, got %d", len(files))
 	}
 
-	expectedFileName := string(source) + "_" + time.Now().Format("20060102") + ".html"
+	expectedFileName := string(source) + "_" + testTime.Format(SnapshotLayout) + ".html"
 	if files[0].Name() != expectedFileName {
 		t.Errorf("expected file name %q, got %q", expectedFileName, files[0].Name())
 	}
@@ -255,3 +293,50 @@ func TestUpdateHTMLSource(t *testing.T) {
 		t.Errorf("expected file content %q, got %q", content, fileContent)
 	}
 }
+
+func TestUpdateSource_Snapshots(t *testing.T) {
+	dir := t.TempDir()
+	storage := New(dir)
+	now := testTime
+	storage.now = func() time.Time { return now }
+
+	updates := []struct {
+		content string
+		after   time.Duration
+	}{
+		{"first", 0},
+		{"second", time.Hour},
+		// The same content fetched again renames the stored snapshot.
+		{"first", time.Hour},
+	}
+
+	for _, update := range updates {
+		now = now.Add(update.after)
+		if err := storage.UpdateXMLSource("source1", []byte(update.content)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	snapshots, err := storage.Snapshots()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"source1_20240519123000.xml", "source1_20240519113000.xml"}
+	if len(snapshots) != len(expected) {
+		t.Fatalf("expected %d snapshots, got %v", len(expected), snapshots)
+	}
+	for i, snapshot := range snapshots {
+		if snapshot.Name != expected[i] {
+			t.Errorf("expected snapshot %q, got %q", expected[i], snapshot.Name)
+		}
+	}
+
+	info, err := os.Stat(filepath.Join(dir, expected[0]))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Mode().Perm() != snapshotPerm {
+		t.Errorf("expected permission %v, got %v", os.FileMode(snapshotPerm), info.Mode().Perm())
+	}
+}
@@ -0,0 +1,134 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// names returns the comma-separated names of the snapshots.
+func names(snapshots []Snapshot) string {
+	var result []string
+	for _, snapshot := range snapshots {
+		result = append(result, snapshot.Name)
+	}
+	return strings.Join(result, ",")
+}
+
+func TestSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	storage := New(dir)
+
+	createTestFile(t, dir, "bbc_20240518.xml", "daily")
+	createTestFile(t, dir, "bbc_20240519103000.xml", "content")
+	createTestFile(t, dir, "bbc-world_20240519090000.json", "content")
+	createTestFile(t, dir, "bbc_latest.xml", "unknown time")
+	createTestFile(t, dir, "notes.txt", "unknown source")
+
+	snapshots, err := storage.Snapshots()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "bbc_20240519103000.xml,bbc-world_20240519090000.json,bbc_20240518.xml"
+	if got := names(snapshots); got != expected {
+		t.Errorf("expected snapshots %q, got %q", expected, got)
+	}
+
+	if snapshots[1].Source != "bbc-world" || snapshots[1].Size != int64(len("content")) ||
+		!snapshots[1].Time.Equal(time.Date(2024, 5, 19, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected snapshot: %+v", snapshots[1])
+	}
+}
+
+func TestPrune(t *testing.T) {
+	files := map[string]string{
+		"bbc_20240519100000.xml":       "12345",
+		"bbc_20240518100000.xml":       "12345",
+		"bbc_20240517100000.xml":       "12345",
+		"bbc_20240516.xml":             "12345",
+		"bbc-world_20240510100000.xml": "12345",
+		"bbc-world_20240509100000.xml": "12345",
+	}
+
+	tests := []struct {
+		name     string
+		policy   RetentionPolicy
+		expected string
+	}{
+		{"empty policy", RetentionPolicy{}, ""},
+		{"max snapshots", RetentionPolicy{MaxSnapshots: 2},
+			"bbc_20240516.xml,bbc_20240517100000.xml"},
+		{"max age", RetentionPolicy{MaxAge: 48 * time.Hour},
+			"bbc-world_20240509100000.xml,bbc_20240516.xml,bbc_20240517100000.xml"},
+		{"max bytes", RetentionPolicy{MaxBytes: 15},
+			"bbc-world_20240509100000.xml,bbc_20240516.xml,bbc_20240517100000.xml"},
+		{"all limits", RetentionPolicy{MaxAge: 72 * time.Hour, MaxSnapshots: 3, MaxBytes: 10},
+			"bbc-world_20240509100000.xml,bbc_20240516.xml,bbc_20240517100000.xml,bbc_20240518100000.xml"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, dryRun := range []bool{true, false} {
+				dir := t.TempDir()
+				storage := New(dir)
+				storage.now = func() time.Time { return time.Date(2024, 5, 19, 12, 0, 0, 0, time.UTC) }
+				for name, content := range files {
+					createTestFile(t, dir, name, content)
+				}
+
+				pruned, err := storage.Prune(tt.policy, dryRun)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if got := names(pruned); got != tt.expected {
+					t.Errorf("expected pruned snapshots %q, got %q", tt.expected, got)
+				}
+
+				for _, snapshot := range pruned {
+					_, err := os.Stat(filepath.Join(dir, snapshot.Name))
+					if dryRun && err != nil {
+						t.Errorf("expected snapshot %s to be kept on dry run", snapshot.Name)
+					}
+					if !dryRun && !os.IsNotExist(err) {
+						t.Errorf("expected snapshot %s to be removed", snapshot.Name)
+					}
+				}
+
+				remaining, err := storage.Snapshots()
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !dryRun && len(remaining) != len(files)-len(pruned) {
+					t.Errorf("expected %d remaining snapshots, got %d", len(files)-len(pruned), len(remaining))
+				}
+			}
+		})
+	}
+}
+
+func TestParseMaxAge(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{"30d", 30 * 24 * time.Hour, false},
+		{"36h", 36 * time.Hour, false},
+		{"0", 0, false},
+		{"xd", 0, true},
+		{"month", 0, true},
+	}
+
+	for _, tt := range tests {
+		age, err := ParseMaxAge(tt.value)
+		if tt.wantErr != (err != nil) {
+			t.Errorf("ParseMaxAge(%q): unexpected error: %v", tt.value, err)
+		}
+		if age != tt.expected {
+			t.Errorf("ParseMaxAge(%q): expected %v, got %v", tt.value, tt.expected, age)
+		}
+	}
+}
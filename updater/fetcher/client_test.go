@@ -0,0 +1,133 @@
+package fetcher
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// These tests mirror fetcher/client_test.go of the news-aggregator module, see Client.
+
+// newTestClient creates a Client retrying without noticeable delays.
+func newTestClient() *Client {
+	c := New()
+	c.SetBaseDelay(time.Millisecond)
+	c.SetMaxDelay(5 * time.Millisecond)
+	return c
+}
+
+func TestClient_Do(t *testing.T) {
+	tests := []struct {
+		name             string
+		failures         int32
+		failureStatus    int
+		retryAfter       string
+		expectedStatus   int
+		expectedAttempts int32
+	}{
+		{"success", 0, 0, "", http.StatusOK, 1},
+		{"retried server errors", 2, http.StatusServiceUnavailable, "", http.StatusOK, 3},
+		{"retried rate limit with Retry-After", 1, http.StatusTooManyRequests, "1", http.StatusOK, 2},
+		{"too many server errors", 10, http.StatusBadGateway, "", http.StatusBadGateway, 4},
+		{"client error", 10, http.StatusNotFound, "", http.StatusNotFound, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if agent := r.Header.Get("User-Agent"); agent != "test-agent/1.0" {
+					t.Errorf("expected User-Agent test-agent/1.0, got %q", agent)
+				}
+				if atomic.AddInt32(&attempts, 1) <= tt.failures {
+					if tt.retryAfter != "" {
+						w.Header().Set("Retry-After", tt.retryAfter)
+					}
+					w.WriteHeader(tt.failureStatus)
+					return
+				}
+				_, _ = w.Write([]byte("content"))
+			}))
+			defer server.Close()
+
+			c := newTestClient()
+			c.SetUserAgent("test-agent/1.0")
+
+			req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			resp, err := c.Do(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, resp.StatusCode)
+			}
+			if got := atomic.LoadInt32(&attempts); got != tt.expectedAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.expectedAttempts, got)
+			}
+		})
+	}
+}
+
+func TestClient_Do_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	c := newTestClient()
+	c.SetTimeout(20 * time.Millisecond)
+	c.SetMaxRetries(1)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = c.Do(req)
+	if err == nil || !strings.Contains(err.Error(), "after 2 attempts") {
+		t.Errorf("expected error after 2 attempts, got %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 5, 19, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"120", 2 * time.Minute, true},
+		{"Sun, 19 May 2024 10:00:30 GMT", 30 * time.Second, true},
+		{"Sun, 19 May 2024 09:00:00 GMT", 0, true},
+		{"", 0, false},
+		{"-1", 0, false},
+		{"soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		delay, ok := parseRetryAfter(tt.value, now)
+		if ok != tt.ok || delay != tt.expected {
+			t.Errorf("parseRetryAfter(%q): expected %v, %v, got %v, %v", tt.value, tt.expected, tt.ok, delay, ok)
+		}
+	}
+}
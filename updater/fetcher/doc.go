@@ -0,0 +1,8 @@
+// Package fetcher provides the HTTP client fetching the feeds.
+//
+// The Client limits every request with a timeout and retries the network errors and the 429 and 5xx responses
+// with a jittered exponential backoff, honoring the Retry-After header. It mirrors the fetch client of the
+// news-aggregator server, so that both of them treat the sources the same way.
+package fetcher
+
+//go:generate cp ../../fetcher/client.go client.go
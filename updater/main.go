@@ -3,6 +3,8 @@ package main
 import (
 	"flag"
 	"log"
+	"os"
+	"updater/fetcher"
 	"updater/storage"
 	"updater/updater"
 )
@@ -13,9 +15,17 @@ const (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "storage" {
+		runStorageCommand(os.Args[2:])
+		return
+	}
+
 	resource := flag.String("resource", "", "[Optional] Name of the resource to update")
 	feedsConfig := flag.String("feeds-config", "", "[Optional] Path to the feeds config file")
 	resourcesPath := flag.String("resources-path", "", "[Optional] Path to the resources directory")
+	timeout := flag.Duration("timeout", fetcher.DefaultTimeout, "[Optional] Limit of a single request to a feed")
+	retries := flag.Int("retries", fetcher.DefaultMaxRetries, "[Optional] Number of the retries of a failed request")
+	userAgent := flag.String("user-agent", fetcher.DefaultUserAgent, "[Optional] User-Agent header of the requests")
 	flag.Usage = printUsage
 	flag.Parse()
 
@@ -27,6 +37,10 @@ func main() {
 		*resourcesPath = defaultResourcesPath
 	}
 
+	if err := storage.CheckBackend(os.Getenv(storage.BackendEnv)); err != nil {
+		log.Fatalf("Error of storage creation: %v", err)
+	}
+
 	s, err := storage.New(*resourcesPath)
 
 	if err != nil {
@@ -39,6 +53,16 @@ func main() {
 		log.Fatalf("Error of updater creation: %v", err)
 	}
 
+	if *timeout <= 0 || *retries < 0 {
+		log.Fatalln("The timeout must be positive and the retries cannot be negative")
+	}
+
+	client := fetcher.New()
+	client.SetTimeout(*timeout)
+	client.SetMaxRetries(*retries)
+	client.SetUserAgent(*userAgent)
+	u.SetFetchClient(client)
+
 	if *resource == "" {
 		errs := u.UpdateAllFeeds()
 		if len(errs) > 0 {
@@ -63,4 +87,67 @@ func printUsage() {
 	log.Println("Pay attention: If resource is not specified, all resources will be updated!")
 	log.Println("If you didn't specify the feeds-config and resources-path, the default values will be used.")
 	log.Println("Example: updater -resource=example -feeds-config=feeds.json -resources-path=./resources")
+	log.Println("Use 'updater storage prune -h' to see the options of removing the stored snapshots.")
+}
+
+// runStorageCommand runs the storage subcommand with the arguments following it.
+func runStorageCommand(args []string) {
+	if len(args) == 0 || args[0] != "prune" {
+		log.Fatalln("Usage: updater storage prune [options]")
+	}
+
+	flags := flag.NewFlagSet("storage prune", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "[Optional] Only print the snapshots that would be removed")
+	maxAge := flags.String("max-age", "0", "[Optional] Age of the removed snapshots, e.g. 30d or 36h")
+	maxSnapshots := flags.Int("max-snapshots", 0, "[Optional] Number of the latest snapshots kept per source")
+	maxSize := flags.Int64("max-size", 0, "[Optional] Total size of the snapshots in megabytes")
+	resourcesPath := flags.String("resources-path", "", "[Optional] Path to the resources directory")
+	flags.Usage = func() {
+		log.Println("Usage: updater storage prune [options]")
+		log.Println("Options:")
+		flags.PrintDefaults()
+		log.Println("Pay attention: The latest snapshot of every source is always kept!")
+		log.Println("Example: updater storage prune -dry-run -max-age=30d -max-snapshots=10 -resources-path=./resources")
+	}
+	_ = flags.Parse(args[1:])
+
+	if *resourcesPath == "" {
+		*resourcesPath = defaultResourcesPath
+	}
+
+	age, err := storage.ParseMaxAge(*maxAge)
+	if err != nil {
+		log.Fatalf("Error of max-age parsing: %v", err)
+	}
+
+	policy := storage.RetentionPolicy{
+		MaxAge:       age,
+		MaxSnapshots: *maxSnapshots,
+		MaxBytes:     *maxSize << 20,
+	}
+	if policy.IsEmpty() {
+		log.Fatalln("Error of pruning: at least one of max-age, max-snapshots or max-size should be set")
+	}
+
+	s, err := storage.New(*resourcesPath)
+	if err != nil {
+		log.Fatalf("Error of storage creation: %v", err)
+	}
+
+	pruned, err := s.Prune(policy, *dryRun)
+	if err != nil {
+		log.Fatalf("Error of pruning: %v", err)
+	}
+
+	action := "Removed"
+	if *dryRun {
+		action = "Would remove"
+	}
+
+	var size int64
+	for _, snapshot := range pruned {
+		log.Printf("%s %s (%d bytes)", action, snapshot.Name, snapshot.Size)
+		size += snapshot.Size
+	}
+	log.Printf("%s %d snapshots of %d bytes", action, len(pruned), size)
 }
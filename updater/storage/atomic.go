@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// The updater module cannot import the storage package of the news-aggregator module, so updater/storage/atomic.go
+// is a copy of storage/atomic.go made by go generate in updater/storage. Change storage/atomic.go only,
+// the tests of the storage package fail once the copy differs.
+
+// WriteFileAtomic writes the data to the file at the path, replacing it only once the data is on the disk.
+// The data is written to a hidden temporary file of the same directory, synced and renamed to the path,
+// so a crash or a full disk leaves either the previous file or the new one, never a truncated file.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir, name := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+name+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("error creating temporary file: %v", err)
+	}
+	tmpPath := tmp.Name()
+
+	err = writeAndSync(tmp, data, perm)
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	err = os.Rename(tmpPath, path)
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("error replacing file: %v", err)
+	}
+
+	syncDir(dir)
+	return nil
+}
+
+// writeAndSync writes the data to the file, flushes it to the disk and closes the file.
+func writeAndSync(file *os.File, data []byte, perm os.FileMode) error {
+	_, err := file.Write(data)
+	if err == nil {
+		err = file.Chmod(perm)
+	}
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("error writing temporary file: %v", err)
+	}
+	return nil
+}
+
+// syncDir flushes the entries of the directory, so that a rename survives a crash.
+// Errors are ignored, as not every platform supports syncing directories.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	_ = d.Sync()
+	_ = d.Close()
+}
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// These tests mirror storage/atomic_test.go of the news-aggregator module, see WriteFileAtomic.
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test-source_20240519103000.xml")
+
+	for _, content := range []string{"first", "second"} {
+		err := WriteFileAtomic(path, []byte(content), snapshotPerm)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("expected to read file %s, got error %v", path, err)
+		}
+		if string(data) != content {
+			t.Errorf("expected file content %s, got %s", content, data)
+		}
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("expected to read directory, got error %v", err)
+	}
+	if len(files) != 1 {
+		t.Errorf("expected no temporary files, got %d files", len(files))
+	}
+
+	err = WriteFileAtomic(filepath.Join(dir, "missing", "file.xml"), []byte("content"), snapshotPerm)
+	if err == nil {
+		t.Errorf("expected error, got nil")
+	}
+}
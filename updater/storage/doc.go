@@ -1,2 +1,4 @@
 // Package storage provides an API for managing files in specific filepath
 package storage
+
+//go:generate cp ../../storage/atomic.go atomic.go
@@ -0,0 +1,178 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"updater/updater/model/feed"
+)
+
+// RetentionPolicy limits the snapshots kept by the Storage.
+// Zero values leave the corresponding limit unset.
+// The latest snapshot of every source is kept regardless of the limits.
+type RetentionPolicy struct {
+	// MaxAge is the age after which the snapshots are removed.
+	MaxAge time.Duration
+	// MaxSnapshots is the number of the latest snapshots kept per source.
+	MaxSnapshots int
+	// MaxBytes is the total size of the snapshots, the oldest snapshots are removed above it.
+	MaxBytes int64
+}
+
+// IsEmpty reports whether the policy sets no limit.
+func (p RetentionPolicy) IsEmpty() bool {
+	return p.MaxAge <= 0 && p.MaxSnapshots <= 0 && p.MaxBytes <= 0
+}
+
+// ParseMaxAge parses the maximum age of the snapshots, either a number of days like "30d"
+// or a duration like "36h". Zero leaves the age unlimited.
+func ParseMaxAge(value string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid number of days: %s", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	return time.ParseDuration(value)
+}
+
+// Snapshot is a stored content of a source fetched at a time.
+type Snapshot struct {
+	Source feed.Source
+	// Name is the name of the snapshot in the storage.
+	Name string
+	Time time.Time
+	Size int64
+}
+
+// Snapshots returns the snapshots of all sources, the latest first.
+// The files not named after a source and the time of the update are skipped.
+func (s *Storage) Snapshots() ([]Snapshot, error) {
+	files, err := os.ReadDir(s.basePath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading directory: %v", err)
+	}
+
+	var snapshots []Snapshot
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+
+		snapshot, ok := parseSnapshot(file.Name())
+		if !ok {
+			continue
+		}
+
+		info, err := file.Info()
+		if os.IsNotExist(err) {
+			// The snapshot is renamed by a concurrent update of the source.
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading file info: %v", err)
+		}
+		snapshot.Size = info.Size()
+
+		snapshots = append(snapshots, snapshot)
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].Time.After(snapshots[j].Time)
+	})
+
+	return snapshots, nil
+}
+
+// Prune removes the snapshots exceeding the policy and returns them, the oldest first.
+// The snapshots older than the maximum age or beyond the maximum number of a source are removed first,
+// then the oldest snapshots of all sources are removed until the total size fits the maximum.
+func (s *Storage) Prune(policy RetentionPolicy, dryRun bool) ([]Snapshot, error) {
+	snapshots, err := s.Snapshots()
+	if err != nil {
+		return nil, err
+	}
+
+	pruned := selectPruned(snapshots, policy, s.now())
+
+	if !dryRun {
+		for _, snapshot := range pruned {
+			err := os.Remove(filepath.Join(s.basePath, snapshot.Name))
+			if err != nil {
+				return nil, fmt.Errorf("error removing snapshot: %v", err)
+			}
+		}
+	}
+
+	return pruned, nil
+}
+
+// selectPruned returns the snapshots exceeding the policy at the time, the oldest first.
+// The snapshots are expected to be ordered from the latest.
+func selectPruned(snapshots []Snapshot, policy RetentionPolicy, now time.Time) []Snapshot {
+	var pruned, kept []Snapshot
+	var totalBytes int64
+	perSource := make(map[feed.Source]int)
+
+	for _, snapshot := range snapshots {
+		index := perSource[snapshot.Source]
+		perSource[snapshot.Source]++
+
+		if index > 0 && (policy.MaxSnapshots > 0 && index >= policy.MaxSnapshots ||
+			policy.MaxAge > 0 && snapshot.Time.Before(now.Add(-policy.MaxAge))) {
+			pruned = append(pruned, snapshot)
+			continue
+		}
+
+		kept = append(kept, snapshot)
+		totalBytes += snapshot.Size
+	}
+
+	if policy.MaxBytes > 0 {
+		latest := make(map[feed.Source]int)
+		for i := len(kept) - 1; i >= 0; i-- {
+			latest[kept[i].Source] = i
+		}
+
+		for i := len(kept) - 1; i >= 0 && totalBytes > policy.MaxBytes; i-- {
+			if latest[kept[i].Source] == i {
+				continue
+			}
+			pruned = append(pruned, kept[i])
+			totalBytes -= kept[i].Size
+		}
+	}
+
+	sort.SliceStable(pruned, func(i, j int) bool {
+		return pruned[i].Time.Before(pruned[j].Time)
+	})
+
+	return pruned
+}
+
+// parseSnapshot returns the snapshot of the file named after the source and the time of the update.
+// It reports false for the files that are not named this way.
+func parseSnapshot(fileName string) (Snapshot, bool) {
+	source, ok := fileSource(fileName)
+	if !ok {
+		return Snapshot{}, false
+	}
+
+	timestamp := strings.TrimSuffix(fileName[len(source)+1:], filepath.Ext(fileName))
+	for _, layout := range []string{SnapshotLayout, DailySnapshotLayout} {
+		if len(timestamp) != len(layout) {
+			continue
+		}
+		if t, err := time.Parse(layout, timestamp); err == nil {
+			return Snapshot{Source: source, Name: fileName, Time: t}, true
+		}
+	}
+
+	return Snapshot{}, false
+}
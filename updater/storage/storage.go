@@ -1,16 +1,53 @@
 package storage
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 	"updater/updater/model/feed"
 )
 
+// Layouts of the time in the names of the snapshot files.
+const (
+	// SnapshotLayout is the layout of the snapshots written by the Storage, precise to a second.
+	SnapshotLayout = "20060102150405"
+	// DailySnapshotLayout is the layout of the snapshots written before, precise to a day.
+	DailySnapshotLayout = "20060102"
+)
+
+// snapshotPerm is the permission of the snapshot files.
+const snapshotPerm = 0644
+
+// BackendEnv is the environment variable selecting the storage backend of the web server.
+const BackendEnv = "STORAGE_BACKEND"
+
+// FilesBackend is the storage backend of the web server reading the snapshot files written by the Storage.
+const FilesBackend = "files"
+
+// CheckBackend checks that the web server reads the snapshot files written by the Storage,
+// which holds for the files backend, also selected by an empty backend.
+// The other backends, e.g. bolt, never read the files, so the updates would be lost.
+func CheckBackend(backend string) error {
+	if backend == "" || backend == FilesBackend {
+		return nil
+	}
+
+	return fmt.Errorf("storage backend \"%s\" is not supported, the updater writes the snapshot files "+
+		"of the \"%s\" backend only", backend, FilesBackend)
+}
+
 // Storage is a component enabling the retrieval and manipulation of known files from a file system.
+// Every update of a source is written to a snapshot file named after the source and the time of the update
+// in UTC, e.g. "bbc-world_20240519103000.xml". A content identical to a stored snapshot of the source
+// is not written again, the snapshot is renamed after the time of the update instead.
+// The snapshots are written with WriteFileAtomic, so a crash never leaves a truncated snapshot.
 type Storage struct {
 	basePath string
+	// now returns the time of an update.
+	now func() time.Time
 }
 
 // New creates a new Storage.
@@ -27,12 +64,14 @@ func New(basePath string) (*Storage, error) {
 		if err != nil {
 			return &Storage{
 				basePath: basePath,
+				now:      time.Now,
 			}, fmt.Errorf("error creating directory: %v", err)
 		}
 	}
 
 	return &Storage{
 		basePath: basePath,
+		now:      time.Now,
 	}, nil
 }
 
@@ -46,15 +85,89 @@ func (s *Storage) UpdateHTMLFeed(source feed.Source, content []byte) error {
 	return s.updateSource(source, content, "html")
 }
 
+// UpdateJSONFeed creates a new JSON file with the content of the source.
+func (s *Storage) UpdateJSONFeed(source feed.Source, content []byte) error {
+	return s.updateSource(source, content, "json")
+}
+
+// HasFeed reports whether a snapshot of the source is stored.
+func (s *Storage) HasFeed(source feed.Source) bool {
+	snapshots, err := s.Snapshots()
+	if err != nil {
+		return false
+	}
+
+	for _, snapshot := range snapshots {
+		if snapshot.Source == source {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Storage) updateSource(source feed.Source, content []byte, ext string) error {
-	timestamp := time.Now().Format("20060102")
-	filePath := fmt.Sprintf("%s_%s.%s", source, timestamp, ext)
-	filePath = filepath.Join(s.basePath, filePath)
+	fileName := fmt.Sprintf("%s_%s.%s", source, s.now().UTC().Format(SnapshotLayout), ext)
+	filePath := filepath.Join(s.basePath, fileName)
 
-	err := os.WriteFile(filePath, content, os.ModePerm)
+	duplicate, err := s.findDuplicate(source, ext, content)
+	if err != nil {
+		return err
+	}
+
+	if duplicate != "" {
+		err = os.Rename(filepath.Join(s.basePath, duplicate), filePath)
+		if err != nil {
+			return fmt.Errorf("error renaming identical snapshot: %v", err)
+		}
+		return nil
+	}
+
+	err = WriteFileAtomic(filePath, content, snapshotPerm)
 	if err != nil {
 		return fmt.Errorf("error writing resource to file: %v", err)
 	}
 
 	return nil
 }
+
+// findDuplicate returns the name of the snapshot of the source with the same extension and content,
+// or an empty string if there is none.
+func (s *Storage) findDuplicate(source feed.Source, ext string, content []byte) (string, error) {
+	snapshots, err := s.Snapshots()
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(content)
+
+	for _, snapshot := range snapshots {
+		if snapshot.Source != source || snapshot.Size != int64(len(content)) || filepath.Ext(snapshot.Name) != "."+ext {
+			continue
+		}
+
+		stored, err := os.ReadFile(filepath.Join(s.basePath, snapshot.Name))
+		if err != nil {
+			return "", fmt.Errorf("error reading snapshot: %v", err)
+		}
+		if sha256.Sum256(stored) == hash {
+			return snapshot.Name, nil
+		}
+	}
+
+	return "", nil
+}
+
+// fileSource returns the source of the file named after the source and the time of the update,
+// e.g. "bbc-world_20240519103000.xml". It reports false for the files that are not named this way
+// and for the hidden files, e.g. the temporary files of WriteFileAtomic.
+func fileSource(fileName string) (feed.Source, bool) {
+	if strings.HasPrefix(fileName, ".") {
+		return "", false
+	}
+
+	i := strings.LastIndex(fileName, "_")
+	if i <= 0 {
+		return "", false
+	}
+	return feed.Source(fileName[:i]), true
+}
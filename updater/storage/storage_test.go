@@ -9,6 +9,9 @@ import (
 	"updater/updater/model/feed"
 )
 
+// testTime is the time of the updates in the tests.
+var testTime = time.Date(2024, 5, 19, 10, 30, 0, 0, time.UTC)
+
 func TestNew(t *testing.T) {
 	t.Run("basePath provided", func(t *testing.T) {
 		basePath := "testdata"
@@ -83,6 +86,7 @@ func TestStorage_UpdateRSSFeed(t *testing.T) {
 		}
 	}(basePath)
 	storage, _ := New(basePath)
+	storage.now = func() time.Time { return testTime }
 
 	source := feed.Source("test-source")
 	content := []byte("<rss>test content</rss>")
@@ -92,7 +96,7 @@ func TestStorage_UpdateRSSFeed(t *testing.T) {
 		t.Errorf("expected no error, got %v", err)
 	}
 
-	timestamp := time.Now().Format("20060102")
+	timestamp := testTime.Format(SnapshotLayout)
 	expectedFilePath := filepath.Join(basePath, fmt.Sprintf("%s_%s.xml", source, timestamp))
 
 	if _, err := os.Stat(expectedFilePath); os.IsNotExist(err) {
@@ -118,6 +122,7 @@ func TestStorage_UpdateHTMLFeed(t *testing.T) {
 		}
 	}(basePath)
 	storage, _ := New(basePath)
+	storage.now = func() time.Time { return testTime }
 
 	source := feed.Source("test-source")
 	content := []byte("<html>test content</html>")
@@ -127,7 +132,7 @@ func TestStorage_UpdateHTMLFeed(t *testing.T) {
 		t.Errorf("expected no error, got %v", err)
 	}
 
-	timestamp := time.Now().Format("20060102")
+	timestamp := testTime.Format(SnapshotLayout)
 	expectedFilePath := filepath.Join(basePath, fmt.Sprintf("%s_%s.html", source, timestamp))
 
 	if _, err := os.Stat(expectedFilePath); os.IsNotExist(err) {
@@ -143,3 +148,121 @@ func TestStorage_UpdateHTMLFeed(t *testing.T) {
 		t.Errorf("expected file content %s, got %s", content, fileContent)
 	}
 }
+
+func TestStorage_UpdateJSONFeed(t *testing.T) {
+	basePath := "testdata"
+	defer func(path string) {
+		err := os.RemoveAll(path)
+		if err != nil {
+			fmt.Println("Error of removing directory")
+		}
+	}(basePath)
+	storage, _ := New(basePath)
+	storage.now = func() time.Time { return testTime }
+
+	source := feed.Source("test-source")
+	content := []byte(`{"items": []}`)
+
+	err := storage.UpdateJSONFeed(source, content)
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	timestamp := testTime.Format(SnapshotLayout)
+	expectedFilePath := filepath.Join(basePath, fmt.Sprintf("%s_%s.json", source, timestamp))
+
+	if _, err := os.Stat(expectedFilePath); os.IsNotExist(err) {
+		t.Errorf("expected file %s to be created", expectedFilePath)
+	}
+
+	fileContent, err := os.ReadFile(expectedFilePath)
+	if err != nil {
+		t.Fatalf("expected to read file %s, got error %v", expectedFilePath, err)
+	}
+
+	if string(fileContent) != string(content) {
+		t.Errorf("expected file content %s, got %s", content, fileContent)
+	}
+}
+
+func TestStorage_UpdateSnapshots(t *testing.T) {
+	basePath := t.TempDir()
+	storage, _ := New(basePath)
+	now := testTime
+	storage.now = func() time.Time { return now }
+
+	source := feed.Source("test-source")
+
+	for _, content := range []string{"first", "second", "first"} {
+		now = now.Add(time.Hour)
+		err := storage.UpdateRSSFeed(source, []byte(content))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	files, err := os.ReadDir(basePath)
+	if err != nil {
+		t.Fatalf("expected to read directory, got error %v", err)
+	}
+
+	// The same content fetched again renames the stored snapshot.
+	expected := []string{"test-source_20240519123000.xml", "test-source_20240519133000.xml"}
+	if len(files) != len(expected) {
+		t.Fatalf("expected %d files, got %d", len(expected), len(files))
+	}
+	for i, file := range files {
+		if file.Name() != expected[i] {
+			t.Errorf("expected file %s, got %s", expected[i], file.Name())
+		}
+	}
+
+	info, err := os.Stat(filepath.Join(basePath, expected[1]))
+	if err != nil {
+		t.Fatalf("expected to stat file, got error %v", err)
+	}
+	if info.Mode().Perm() != snapshotPerm {
+		t.Errorf("expected permission %v, got %v", os.FileMode(snapshotPerm), info.Mode().Perm())
+	}
+}
+
+func TestStorage_HasFeed(t *testing.T) {
+	storage, _ := New(t.TempDir())
+
+	if storage.HasFeed("test-source") {
+		t.Errorf("expected no stored feed")
+	}
+
+	err := storage.UpdateRSSFeed("test-source", []byte("<rss>test content</rss>"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !storage.HasFeed("test-source") {
+		t.Errorf("expected stored feed")
+	}
+	if storage.HasFeed("test") {
+		t.Errorf("expected no stored feed of a similar source")
+	}
+}
+
+func TestCheckBackend(t *testing.T) {
+	tests := []struct {
+		backend     string
+		expectError bool
+	}{
+		{"", false},
+		{"files", false},
+		{"bolt", true},
+		{"unknown", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.backend, func(t *testing.T) {
+			err := CheckBackend(tt.backend)
+			if (err != nil) != tt.expectError {
+				t.Errorf("expected error %v, got %v", tt.expectError, err)
+			}
+		})
+	}
+}
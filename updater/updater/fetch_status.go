@@ -0,0 +1,79 @@
+package updater
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+	"updater/storage"
+	"updater/updater/model/feed"
+)
+
+// StateSuffix is appended to the path of the feeds config to get the path of the fetch statuses of the feeds.
+// The statuses are loaded once on start and the whole file is rewritten on every change, so the updater keeps
+// its own file instead of sharing the one of the news-aggregator web server.
+const StateSuffix = ".updater.state"
+
+// FetchStatus is the state of fetching a feed kept between the updates.
+type FetchStatus struct {
+	// ETag is the ETag header of the last response.
+	ETag string `json:"etag,omitempty"`
+	// LastModified is the Last-Modified header of the last response.
+	LastModified string `json:"lastModified,omitempty"`
+	// ContentHash is the SHA-256 hash of the last stored content in hex.
+	ContentHash string `json:"contentHash,omitempty"`
+	// LastChecked is the time of the last successful fetch.
+	LastChecked time.Time `json:"lastChecked"`
+	// LastChanged is the time of the last fetch with a new content.
+	LastChanged time.Time `json:"lastChanged"`
+}
+
+// FetchStatus returns the fetch status of the feed and whether the feed has been fetched.
+func (u *Updater) FetchStatus(source feed.Source) (FetchStatus, bool) {
+	status, exists := u.statuses[source]
+	return status, exists
+}
+
+// setFetchStatus records the fetch status of the feed and saves the statuses next to the feeds config.
+func (u *Updater) setFetchStatus(source feed.Source, status FetchStatus) error {
+	if u.statuses == nil {
+		u.statuses = make(map[feed.Source]FetchStatus)
+	}
+	u.statuses[source] = status
+
+	if u.feedsConfigPath == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(u.statuses, "", "  ")
+	if err != nil {
+		return fmt.Errorf("can't encode fetch statuses: %v", err)
+	}
+
+	err = storage.WriteFileAtomic(u.feedsConfigPath+StateSuffix, data, 0644)
+	if err != nil {
+		return fmt.Errorf("can't write fetch statuses: %v", err)
+	}
+
+	return nil
+}
+
+// loadFetchStatuses reads the fetch statuses at the path. Missing or unreadable statuses are dropped,
+// the feeds are then fetched unconditionally once.
+func loadFetchStatuses(path string) map[feed.Source]FetchStatus {
+	statuses := make(map[feed.Source]FetchStatus)
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return statuses
+	}
+	if err == nil {
+		err = json.Unmarshal(data, &statuses)
+	}
+	if err != nil {
+		fmt.Printf("Error of loading fetch statuses, the feeds are fetched again: %v\n", err)
+		return make(map[feed.Source]FetchStatus)
+	}
+
+	return statuses
+}
@@ -34,6 +34,20 @@ func (m *MockStorageInterface) EXPECT() *MockStorageInterfaceMockRecorder {
 	return m.recorder
 }
 
+// HasFeed mocks base method.
+func (m *MockStorageInterface) HasFeed(arg0 feed.Source) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasFeed", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasFeed indicates an expected call of HasFeed.
+func (mr *MockStorageInterfaceMockRecorder) HasFeed(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasFeed", reflect.TypeOf((*MockStorageInterface)(nil).HasFeed), arg0)
+}
+
 // UpdateHTMLFeed mocks base method.
 func (m *MockStorageInterface) UpdateHTMLFeed(arg0 feed.Source, arg1 []byte) error {
 	m.ctrl.T.Helper()
@@ -48,6 +62,20 @@ func (mr *MockStorageInterfaceMockRecorder) UpdateHTMLFeed(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHTMLFeed", reflect.TypeOf((*MockStorageInterface)(nil).UpdateHTMLFeed), arg0, arg1)
 }
 
+// UpdateJSONFeed mocks base method.
+func (m *MockStorageInterface) UpdateJSONFeed(arg0 feed.Source, arg1 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateJSONFeed", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateJSONFeed indicates an expected call of UpdateJSONFeed.
+func (mr *MockStorageInterfaceMockRecorder) UpdateJSONFeed(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateJSONFeed", reflect.TypeOf((*MockStorageInterface)(nil).UpdateJSONFeed), arg0, arg1)
+}
+
 // UpdateRSSFeed mocks base method.
 func (m *MockStorageInterface) UpdateRSSFeed(arg0 feed.Source, arg1 []byte) error {
 	m.ctrl.T.Helper()
@@ -13,6 +13,8 @@ const (
 	RSS
 	HTML
 	JSON
+	ATOM
+	JSONFEED
 )
 
 // FormatToString converts a Format to a string.
@@ -24,6 +26,10 @@ func FormatToString(f Format) string {
 		return "HTML"
 	case JSON:
 		return "JSON"
+	case ATOM:
+		return "ATOM"
+	case JSONFEED:
+		return "JSONFEED"
 	default:
 		return "UNKNOWN"
 	}
@@ -38,6 +44,10 @@ func ParseFormat(formatStr string) (Format, error) {
 		return HTML, nil
 	case "JSON":
 		return JSON, nil
+	case "ATOM":
+		return ATOM, nil
+	case "JSONFEED":
+		return JSONFEED, nil
 	default:
 		return UNKNOWN, fmt.Errorf("unknown format: %s", formatStr)
 	}
@@ -14,6 +14,8 @@ func TestFormatToString(t *testing.T) {
 		{RSS, "RSS"},
 		{HTML, "HTML"},
 		{JSON, "JSON"},
+		{ATOM, "ATOM"},
+		{JSONFEED, "JSONFEED"},
 		{UNKNOWN, "UNKNOWN"},
 		{Format(999), "UNKNOWN"}, // Test with an invalid format
 	}
@@ -39,6 +41,10 @@ func TestParseFormat(t *testing.T) {
 		{"html", HTML, nil},
 		{"JSON", JSON, nil},
 		{"json", JSON, nil},
+		{"ATOM", ATOM, nil},
+		{"atom", ATOM, nil},
+		{"JSONFEED", JSONFEED, nil},
+		{"jsonfeed", JSONFEED, nil},
 		{"UNKNOWN", UNKNOWN, fmt.Errorf("unknown format: UNKNOWN")},
 		{"invalid", UNKNOWN, fmt.Errorf("unknown format: invalid")},
 	}
@@ -11,4 +11,7 @@ import (
 type StorageInterface interface {
 	UpdateRSSFeed(source feed.Source, content []byte) error
 	UpdateHTMLFeed(source feed.Source, content []byte) error
+	UpdateJSONFeed(source feed.Source, content []byte) error
+	// HasFeed reports whether a content of the source is stored.
+	HasFeed(source feed.Source) bool
 }
@@ -1,11 +1,15 @@
 package updater
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"time"
+	"updater/fetcher"
 	"updater/updater/model/feed"
 )
 
@@ -13,6 +17,8 @@ type Updater struct {
 	feedsConfigPath string
 	storage         StorageInterface
 	feeds           []*feed.Feed
+	statuses        map[feed.Source]FetchStatus
+	client          *fetcher.Client
 }
 
 func New(feedsConfigPath string, storage StorageInterface) (Updater, error) {
@@ -27,9 +33,19 @@ func New(feedsConfigPath string, storage StorageInterface) (Updater, error) {
 		feedsConfigPath: feedsConfigPath,
 		storage:         storage,
 		feeds:           feeds,
+		statuses:        loadFetchStatuses(feedsConfigPath + StateSuffix),
+		client:          fetcher.New(),
 	}, err
 }
 
+// SetFetchClient sets the client fetching the feeds. A nil client is ignored.
+func (u *Updater) SetFetchClient(client *fetcher.Client) {
+	if client == nil {
+		return
+	}
+	u.client = client
+}
+
 // UpdateAllFeeds updates all feeds. If some feed fails to update, it will continue with the next one.
 func (u *Updater) UpdateAllFeeds() []error {
 
@@ -45,7 +61,8 @@ func (u *Updater) UpdateAllFeeds() []error {
 	return errors
 }
 
-// UpdateFeed updates a specific feeds.
+// UpdateFeed updates a specific feeds. The feed is fetched conditionally with the validators of its last response,
+// and a content that is not modified, or identical to the last stored one, is not stored again.
 func (u *Updater) UpdateFeed(feedSource string) error {
 
 	source := feed.Source(feedSource)
@@ -65,7 +82,20 @@ func (u *Updater) UpdateFeed(feedSource string) error {
 		return fmt.Errorf("feed source not found: %s", feedSource)
 	}
 
-	resp, err := http.Get(string(targetFeed.Link()))
+	status, _ := u.FetchStatus(source)
+
+	req, err := http.NewRequest(http.MethodGet, string(targetFeed.Link()), nil)
+	if err != nil {
+		return fmt.Errorf("error fetching resource from link: %v", err)
+	}
+	if status.ETag != "" {
+		req.Header.Set("If-None-Match", status.ETag)
+	}
+	if status.LastModified != "" {
+		req.Header.Set("If-Modified-Since", status.LastModified)
+	}
+
+	resp, err := u.client.Do(req)
 	if err != nil {
 		return fmt.Errorf("error fetching resource from link: %v", err)
 	}
@@ -76,6 +106,12 @@ func (u *Updater) UpdateFeed(feedSource string) error {
 		}
 	}(resp.Body)
 
+	status.LastChecked = time.Now()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return u.setFetchStatus(source, status)
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("error fetching resource from link: status code %d", resp.StatusCode)
 	}
@@ -85,11 +121,32 @@ func (u *Updater) UpdateFeed(feedSource string) error {
 		return fmt.Errorf("error reading resource content: %v", err)
 	}
 
+	status.ETag = resp.Header.Get("ETag")
+	status.LastModified = resp.Header.Get("Last-Modified")
+
+	hash := sha256.Sum256(body)
+	contentHash := hex.EncodeToString(hash[:])
+
+	if contentHash != status.ContentHash || !u.storage.HasFeed(source) {
+		if err := u.store(targetFeed, body); err != nil {
+			return err
+		}
+		status.ContentHash = contentHash
+		status.LastChanged = status.LastChecked
+	}
+
+	return u.setFetchStatus(source, status)
+}
+
+// store passes the content of the feed to the update method of the storage suiting the format.
+func (u *Updater) store(targetFeed *feed.Feed, body []byte) error {
 	switch targetFeed.Format() {
-	case feed.RSS:
+	case feed.RSS, feed.ATOM:
 		return u.storage.UpdateRSSFeed(targetFeed.Source(), body)
 	case feed.HTML:
 		return u.storage.UpdateHTMLFeed(targetFeed.Source(), body)
+	case feed.JSON, feed.JSONFEED:
+		return u.storage.UpdateJSONFeed(targetFeed.Source(), body)
 	default:
 		return fmt.Errorf("unsupported format")
 	}
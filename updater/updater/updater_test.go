@@ -4,7 +4,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
+	"updater/fetcher"
 	"updater/updater/mocks"
 	feed2 "updater/updater/model/feed"
 
@@ -17,6 +21,14 @@ func (e *errorReader) Read(p []byte) (n int, err error) {
 	return 0, fmt.Errorf("simulated read error")
 }
 
+// newTestClient creates a fetch client retrying without noticeable delays.
+func newTestClient() *fetcher.Client {
+	c := fetcher.New()
+	c.SetBaseDelay(time.Millisecond)
+	c.SetMaxDelay(5 * time.Millisecond)
+	return c
+}
+
 func TestNewUpdater(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -105,6 +117,30 @@ func TestUpdateFeed(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name:       "successful atom feed update",
+			feedSource: "atom-feed",
+			serverHandler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte("<feed></feed>"))
+			},
+			mockSetup: func(m *mocks.MockStorageInterface) {
+				m.EXPECT().UpdateRSSFeed(feed2.Source("atom-feed"), gomock.Any()).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name:       "successful json feed update",
+			feedSource: "json-feed",
+			serverHandler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				w.Write([]byte(`{"items": []}`))
+			},
+			mockSetup: func(m *mocks.MockStorageInterface) {
+				m.EXPECT().UpdateJSONFeed(feed2.Source("json-feed"), gomock.Any()).Return(nil)
+			},
+			expectedError: nil,
+		},
 		{
 			name:       "error fetching resource from link",
 			feedSource: "abc-news",
@@ -114,6 +150,25 @@ func TestUpdateFeed(t *testing.T) {
 			mockSetup:     func(m *mocks.MockStorageInterface) {},
 			expectedError: fmt.Errorf("error fetching resource from link: status code 500"),
 		},
+		{
+			name:       "retried server error",
+			feedSource: "abc-news",
+			serverHandler: func() http.HandlerFunc {
+				attempts := 0
+				return func(w http.ResponseWriter, r *http.Request) {
+					attempts++
+					if attempts == 1 {
+						w.WriteHeader(http.StatusServiceUnavailable)
+						return
+					}
+					_, _ = w.Write([]byte("feed content"))
+				}
+			}(),
+			mockSetup: func(m *mocks.MockStorageInterface) {
+				m.EXPECT().UpdateRSSFeed(feed2.Source("abc-news"), gomock.Any()).Return(nil)
+			},
+			expectedError: nil,
+		},
 		{
 			name:       "error reading resource content",
 			feedSource: "abc-news",
@@ -138,12 +193,15 @@ func TestUpdateFeed(t *testing.T) {
 
 			testFeedABC, _ := feed2.New("abc-news", feed2.RSS, feed2.Link(server.URL))
 			testFeedWT, _ := feed2.New("washington-times", feed2.RSS, feed2.Link(server.URL))
-			feeds := []*feed2.Feed{testFeedABC, testFeedWT}
+			testFeedAtom, _ := feed2.New("atom-feed", feed2.ATOM, feed2.Link(server.URL))
+			testFeedJSON, _ := feed2.New("json-feed", feed2.JSONFEED, feed2.Link(server.URL))
+			feeds := []*feed2.Feed{testFeedABC, testFeedWT, testFeedAtom, testFeedJSON}
 
 			storageMock := mocks.NewMockStorageInterface(ctrl)
 			updater := Updater{
 				feeds:   feeds,
 				storage: storageMock,
+				client:  newTestClient(),
 			}
 
 			tt.mockSetup(storageMock)
@@ -158,3 +216,61 @@ func TestUpdateFeed(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateFeed_Conditional(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	const etag = `"v1"`
+	notModified := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/etag" && r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if r.URL.Path == "/etag" {
+			w.Header().Set("ETag", etag)
+		}
+		_, _ = w.Write([]byte("feed content"))
+	}))
+	defer server.Close()
+
+	feedsConfigPath := filepath.Join(t.TempDir(), "feeds.json")
+	config := fmt.Sprintf(`[{"source": "etag-feed", "format": "RSS", "link": "%s/etag"},
+		{"source": "plain-feed", "format": "RSS", "link": "%s/plain"}]`, server.URL, server.URL)
+	if err := os.WriteFile(feedsConfigPath, []byte(config), 0644); err != nil {
+		t.Fatalf("can't write feeds config: %v", err)
+	}
+
+	storageMock := mocks.NewMockStorageInterface(ctrl)
+	storageMock.EXPECT().UpdateRSSFeed(feed2.Source("etag-feed"), gomock.Any()).Return(nil).Times(1)
+	storageMock.EXPECT().UpdateRSSFeed(feed2.Source("plain-feed"), gomock.Any()).Return(nil).Times(1)
+	storageMock.EXPECT().HasFeed(feed2.Source("plain-feed")).Return(true).Times(1)
+
+	for i := 0; i < 2; i++ {
+		// The statuses are kept between the runs.
+		updater, err := New(feedsConfigPath, storageMock)
+		if err != nil {
+			t.Fatalf("expected no error, got: %v", err)
+		}
+
+		if errs := updater.UpdateAllFeeds(); len(errs) > 0 {
+			t.Fatalf("expected no errors, got: %v", errs)
+		}
+
+		for _, source := range []feed2.Source{"etag-feed", "plain-feed"} {
+			status, exists := updater.FetchStatus(source)
+			if !exists || status.LastChecked.IsZero() || status.LastChanged.IsZero() {
+				t.Errorf("expected fetch status of %s, got: %+v", source, status)
+			}
+			if i == 1 && !status.LastChecked.After(status.LastChanged) {
+				t.Errorf("expected %s to be checked after the last change, got: %+v", source, status)
+			}
+		}
+	}
+
+	if notModified != 1 {
+		t.Errorf("expected 1 not modified response, got: %d", notModified)
+	}
+}